@@ -0,0 +1,27 @@
+// Activation key registering hosts to the production lifecycle environment
+resource "foreman_katello_activation_key" "rhel9_production" {
+  name            = "rhel9-production"
+  description     = "RHEL 9 production hosts"
+  organization_id = 1
+
+  content_view_id          = foreman_katello_content_view.rhel9.id
+  lifecycle_environment_id = foreman_katello_lifecycle_environment.production.id
+
+  max_hosts       = 100
+  release_version = "9.2"
+  service_level   = "Premium"
+  auto_attach     = true
+
+  host_collection_ids = [3, 4]
+
+  // Disable the AppStream repository by default
+  content_override {
+    content_label = "rhel-9-for-x86_64-appstream-rpms"
+    enabled       = false
+  }
+}
+
+// Look up an existing activation key
+data "foreman_katello_activation_key" "rhel9_production" {
+  name = "rhel9-production"
+}
@@ -0,0 +1,50 @@
+variable "ldap_bind_password" {
+  type      = string
+  sensitive = true
+}
+
+// Directory login against a FreeIPA server. Users are created in Foreman on
+// their first login.
+resource "foreman_auth_source_ldap" "corporate" {
+  name        = "Corporate LDAP"
+  host        = "ldap.example.com"
+  tls         = true
+  server_type = "free_ipa"
+
+  base_dn     = "ou=people,dc=example,dc=com"
+  groups_base = "ou=groups,dc=example,dc=com"
+  ldap_filter = "(memberOf=cn=foreman,ou=groups,dc=example,dc=com)"
+
+  account          = "uid=foreman,ou=services,dc=example,dc=com"
+  account_password = var.ldap_bind_password
+
+  attr_login     = "uid"
+  attr_firstname = "givenName"
+  attr_lastname  = "sn"
+  attr_mail      = "mail"
+
+  onthefly_register = true
+  usergroup_sync    = true
+}
+
+// Existing authentication sources are looked up by name
+data "foreman_auth_source_ldap" "corporate" {
+  name = foreman_auth_source_ldap.corporate.name
+}
+
+resource "foreman_user" "jdoe" {
+  login          = "jdoe"
+  mail           = "jdoe@example.com"
+  auth_source_id = data.foreman_auth_source_ldap.corporate.id
+}
+
+// Map a directory group to a usergroup
+resource "foreman_usergroup" "admins" {
+  name  = "admins"
+  admin = true
+
+  external_group {
+    name           = "foreman-admins"
+    auth_source_id = foreman_auth_source_ldap.corporate.id
+  }
+}
@@ -26,6 +26,60 @@ resource "foreman_katello_content_view" "test_cv_write" {
   }
 }
 
+// Content View for RHEL 9 with package, errata and module stream filters
+resource "foreman_katello_content_view" "rhel9" {
+  name = "RHEL 9 patched"
+  repository_ids = [data.foreman_katello_repository.rhel9_baseos.id]
+
+  // Only include security and bugfix errata published in the first half of 2024
+  filter {
+    name = "errata 2024-H1"
+    type = "erratum"
+    inclusion = true
+
+    rule {
+      start_date = "2024-01-01"
+      end_date = "2024-06-30"
+      date_type = "issued"
+      types = ["security", "bugfix"]
+    }
+  }
+
+  // Exclude a known bad erratum
+  filter {
+    name = "bad errata"
+    type = "erratum"
+
+    rule {
+      errata_id = "RHBA-2024:1234"
+    }
+  }
+
+  // Pin kernel packages to a version range
+  filter {
+    name = "kernel"
+    type = "rpm"
+    inclusion = true
+
+    rule {
+      name = "kernel*"
+      min_version = "5.14.0-362"
+      max_version = "5.14.0-427"
+      architecture = "x86_64"
+    }
+  }
+
+  // Include a single module stream
+  filter {
+    name = "nodejs 18"
+    type = "modulemd"
+    inclusion = true
+
+    rule {
+      module_stream_id = 42
+    }
+  }
+}
 
 //// Content view example (repos are not defined in this example)
 // Content view with repo for Ceph Pacific
@@ -0,0 +1,20 @@
+// Publish a new version of the content view whenever the repository content changes
+// and promote it to the Test and Production environments
+resource "foreman_katello_content_view_version" "base" {
+  content_view_id = foreman_katello_content_view.base.id
+  description     = "Published by Terraform"
+
+  triggers = {
+    repositories = join(",", foreman_katello_content_view.base.repository_ids)
+  }
+
+  environment_ids = [
+    foreman_katello_lifecycle_environment.test.id,
+    foreman_katello_lifecycle_environment.production.id,
+  ]
+
+  // Keep the previous version until the new one is promoted
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,21 @@
+// Look up a host which is not managed by Terraform, e.g. a hypervisor
+data "foreman_host" "hypervisor" {
+  fqdn = "hv01.dc1.company.com"
+}
+
+output "hypervisor_ip" {
+  value = data.foreman_host.hypervisor.ip
+}
+
+output "hypervisor_cpus" {
+  value = data.foreman_host.hypervisor.facts["processorcount"]
+}
+
+// Parameters including the ones inherited from the hostgroup and globals
+output "hypervisor_ntp_server" {
+  value = data.foreman_host.hypervisor.all_parameters["ntp_server"]
+}
+
+output "hypervisor_healthy" {
+  value = data.foreman_host.hypervisor.global_status == 0
+}
@@ -0,0 +1,19 @@
+// Data sources can be looked up with a Foreman scoped search instead of an
+// exact name. The search has to match exactly one object.
+data "foreman_hostgroup" "web" {
+  search = "title = \"Production/Web\""
+}
+
+data "foreman_operatingsystem" "rhel" {
+  search = "family = Redhat and major = 9 and minor = 4"
+}
+
+// Use the first result if the search is allowed to match several objects
+data "foreman_smartproxy" "any_dhcp" {
+  search = "feature = DHCP"
+  first  = true
+}
+
+output "web_hostgroup_id" {
+  value = data.foreman_hostgroup.web.id
+}
@@ -98,3 +98,101 @@ EOF
     }
   }
 }
+
+// Wait until the host is installed and has applied its configuration before
+// resources depending on it are created
+resource "foreman_host" "database" {
+  name = "database01"
+
+  hostgroup_id        = data.foreman_hostgroup.app.id
+  compute_profile_id  = data.foreman_computeprofile.default.id
+  compute_resource_id = data.foreman_computeresource.vcenter.id
+
+  wait_for_build         = true
+  wait_for_config_report = true
+
+  timeouts {
+    create = "90m"
+  }
+
+  interfaces_attributes {
+    type       = "interface"
+    primary    = true
+    identifier = "ens160"
+    provision  = true
+    managed    = true
+  }
+}
+
+# A host which is kept powered off until it is needed. Setting power_state to
+# "on" later powers it on in place.
+resource "foreman_host" "standby" {
+  name = "standby01"
+
+  hostgroup_id        = data.foreman_hostgroup.app.id
+  compute_profile_id  = data.foreman_computeprofile.default.id
+  compute_resource_id = data.foreman_computeresource.vcenter.id
+
+  power_state = "off"
+
+  interfaces_attributes {
+    type       = "interface"
+    primary    = true
+    identifier = "ens160"
+    provision  = true
+    managed    = true
+  }
+}
+
+# A bare metal host booted through its BMC. It is powered off softly, set to
+# boot from PXE and powered on again, with time for the BMC to settle. The
+# same actions run when set_build_flag is changed to true.
+resource "foreman_host" "baremetal" {
+  name = "baremetal01"
+
+  hostgroup_id = data.foreman_hostgroup.app.id
+  enable_bmc   = true
+
+  power_actions {
+    power_action   = "soft"
+    delay          = 30
+    ignore_failure = true
+  }
+  power_actions {
+    boot_device = "pxe"
+    retry_count = 3
+    retry_delay = 10
+  }
+  power_actions {
+    power_action = "on"
+  }
+
+  power_actions_on_rebuild = true
+
+  # Rebuilds the host in place when a new operating system version is rolled
+  # out, including its DHCP reservation and TFTP boot files
+  rebuild_triggers = {
+    operatingsystem = data.foreman_operatingsystem.Centos74.id
+  }
+  rebuild_orchestration = true
+
+  interfaces_attributes {
+    type       = "interface"
+    primary    = true
+    identifier = "eno1"
+    mac        = "c0:ff:ee:ba:be:01"
+    subnet_id  = data.foreman_subnet.app1.id
+    provision  = true
+    managed    = true
+  }
+  interfaces_attributes {
+    type         = "bmc"
+    identifier   = "ipmi"
+    mac          = "c0:ff:ee:ba:be:02"
+    subnet_id    = data.foreman_subnet.app1.id
+    managed      = true
+    bmc_provider = "IPMI"
+    username     = "admin"
+    password     = "changeme"
+  }
+}
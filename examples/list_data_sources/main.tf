@@ -0,0 +1,22 @@
+// List data sources return the IDs and key attributes of all objects
+// matching a Foreman scoped search, or of all objects without a search.
+data "foreman_subnets" "dmz" {
+  search = "name ~ \"dmz\""
+}
+
+data "foreman_hosts" "web" {
+  search = "hostgroup_title = \"Production/Web\""
+}
+
+// Manage a parameter on each matching host
+resource "foreman_parameter" "monitoring" {
+  for_each = { for host in data.foreman_hosts.web.hosts : host.name => host.id }
+
+  host_id = each.value
+  name    = "monitoring"
+  value   = "enabled"
+}
+
+output "dmz_subnet_ids" {
+  value = data.foreman_subnets.dmz.ids
+}
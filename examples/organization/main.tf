@@ -0,0 +1,49 @@
+// Nested organizations, the title of the child is "ACME/Engineering"
+resource "foreman_organization" "acme" {
+  name        = "ACME"
+  description = "ACME Corporation"
+}
+
+resource "foreman_organization" "engineering" {
+  name        = "Engineering"
+  description = "Engineering department"
+  parent_id   = foreman_organization.acme.id
+
+  // Do not automatically assign all domains and media
+  ignore_types = ["Domain", "Medium"]
+
+  parameters = {
+    cost_center = "4711"
+  }
+}
+
+// Nested locations
+resource "foreman_location" "europe" {
+  name = "Europe"
+}
+
+resource "foreman_location" "berlin" {
+  name      = "Berlin"
+  parent_id = foreman_location.europe.id
+
+  parameters = {
+    ntp_server = "ntp.ber.example.com"
+  }
+}
+
+// Look up existing taxonomies, nested ones by their title
+data "foreman_organization" "engineering" {
+  title = "ACME/Engineering"
+}
+
+data "foreman_location" "berlin" {
+  name = "Berlin"
+}
+
+// Assign a domain to the organization and location above instead of the
+// provider's organization_id and location_id
+resource "foreman_domain" "engineering" {
+  name             = "eng.example.com"
+  organization_ids = [foreman_organization.engineering.id]
+  location_ids     = [foreman_location.berlin.id]
+}
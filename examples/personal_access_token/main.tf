@@ -0,0 +1,31 @@
+variable "automation_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "foreman_user" "automation" {
+  login          = "svc-automation"
+  mail           = "automation@example.com"
+  auth_source_id = 1
+  password       = var.automation_password
+}
+
+// The token is only returned on creation and kept in the state. It is revoked
+// when the resource is destroyed. Once it is expired, it is created again.
+resource "foreman_personal_access_token" "automation" {
+  user_id    = foreman_user.automation.id
+  name       = "terraform"
+  expires_at = "2030-01-01T00:00:00Z"
+}
+
+output "automation_token" {
+  value     = foreman_personal_access_token.automation.token
+  sensitive = true
+}
+
+// Key deployed to the hosts for remote execution as the user
+resource "foreman_user_ssh_key" "automation" {
+  user_id = foreman_user.automation.id
+  name    = "remote-execution"
+  key     = file("~/.ssh/id_ed25519.pub")
+}
@@ -9,4 +9,16 @@ resource "foreman_katello_repository" "centos7base" {
   checksum_type = "sha256"
   http_proxy_id = 1
   download_policy = "immediate"
-}
\ No newline at end of file
+}
+// Synchronize the repository after creating it and whenever its URL changes
+resource "foreman_katello_repository_sync" "centos7base" {
+  repository_id = foreman_katello_repository.centos7base.id
+
+  triggers = {
+    url = foreman_katello_repository.centos7base.url
+  }
+
+  timeouts {
+    create = "2h"
+  }
+}
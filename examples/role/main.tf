@@ -0,0 +1,41 @@
+// Permissions are looked up by name
+data "foreman_permission" "view_hosts" {
+  name = "view_hosts"
+}
+
+data "foreman_permission" "power_hosts" {
+  name = "power_hosts"
+}
+
+// A custom role allowed to view and power cycle the web servers
+resource "foreman_role" "host_operators" {
+  name        = "Host operators"
+  description = "Operate hosts of the web hostgroup"
+}
+
+resource "foreman_filter" "web_hosts" {
+  role_id = foreman_role.host_operators.id
+  permission_ids = [
+    data.foreman_permission.view_hosts.id,
+    data.foreman_permission.power_hosts.id,
+  ]
+  search = "hostgroup = web"
+}
+
+// Builtin roles are looked up by name
+data "foreman_role" "viewer" {
+  name = "Viewer"
+}
+
+resource "foreman_user" "operator" {
+  login          = "operator"
+  mail           = "operator@example.com"
+  auth_source_id = 1
+  password       = "changeme123"
+  role_ids       = [foreman_role.host_operators.id, data.foreman_role.viewer.id]
+}
+
+resource "foreman_usergroup" "operators" {
+  name     = "operators"
+  role_ids = [foreman_role.host_operators.id]
+}
@@ -0,0 +1,16 @@
+// Find the most recent sync of a repository that was started outside of Terraform
+data "foreman_task" "last_sync" {
+  search_label  = "Actions::Katello::Repository::Sync"
+  resource_type = "Katello::Repository"
+  resource_id   = 12
+  most_recent   = true
+}
+
+// Block until the sync is done before publishing a content view with its content
+resource "foreman_task_wait" "last_sync" {
+  task_id = data.foreman_task.last_sync.id
+
+  timeouts {
+    create = "2h"
+  }
+}
@@ -0,0 +1,23 @@
+resource "foreman_usergroup" "oncall" {
+  name = "oncall"
+}
+
+// Members are managed by the usergroup. Members of nested usergroups inherit
+// its roles.
+resource "foreman_usergroup" "operators" {
+  name          = "operators"
+  user_ids      = [5, 6]
+  usergroup_ids = [foreman_usergroup.oncall.id]
+  role_ids      = [23]
+
+  // Members of the LDAP groups are synchronized into the usergroup by Foreman
+  external_group {
+    name           = "ops"
+    auth_source_id = 7
+  }
+
+  external_group {
+    name           = "dba"
+    auth_source_id = 7
+  }
+}
@@ -195,7 +195,7 @@ func (c *Client) DeleteArchitecture(ctx context.Context, id int) error {
 // QueryArchitecture queries for a ForemanArchitecture based on the attributes
 // of the supplied ForemanArchitecture reference and returns a QueryResponse
 // struct containing query/response metadata and the matching architectures.
-func (c *Client) QueryArchitecture(ctx context.Context, a *ForemanArchitecture) (QueryResponse, error) {
+func (c *Client) QueryArchitecture(ctx context.Context, a *ForemanArchitecture, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/architecture.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -217,7 +217,7 @@ func (c *Client) QueryArchitecture(ctx context.Context, a *ForemanArchitecture)
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
+)
+
+const (
+	// Header used by Foreman to map OAuth signed requests to a user
+	OAuthUserHeader = "FOREMAN-USER"
+
+	// Endpoint requested to validate the credentials of the client
+	credentialsValidationEndpoint = "/status"
+	// How long to wait for the validation of the credentials
+	credentialsValidationTimeout = 10 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Request Authentication
+// ----------------------------------------------------------------------------
+
+// usesOAuth returns whether the client signs its requests with an OAuth
+// consumer key and secret
+func (c ClientCredentials) usesOAuth() bool {
+	return c.OAuthConsumerKey != ""
+}
+
+// Validate returns an error if the credentials combine authentication
+// mechanisms which exclude each other
+func (c ClientCredentials) Validate() error {
+	if c.Token != "" && c.Password != "" {
+		return fmt.Errorf("Only one of a password and a token can be used to authenticate against Foreman")
+	}
+	if c.Token != "" && c.Username == "" {
+		return fmt.Errorf("A personal access token requires the username of the user it belongs to")
+	}
+	if (c.OAuthConsumerKey == "") != (c.OAuthConsumerSecret == "") {
+		return fmt.Errorf("OAuth authentication requires both the consumer key and the consumer secret")
+	}
+	if c.usesOAuth() && (c.Token != "" || c.Password != "") {
+		return fmt.Errorf("OAuth authentication cannot be combined with a password or a token")
+	}
+	return nil
+}
+
+// authenticateRequest sets the Authorization header of the request for the
+// credentials of the client:
+//
+//   - a token is sent as the password of HTTP basic authentication, which is
+//     how Foreman accepts personal access tokens
+//   - the username and password are sent with HTTP basic authentication
+//
+// OAuth signed requests are signed in Client.Send() instead, since the query
+// of a request is usually set after it was constructed.
+func (client *Client) authenticateRequest(req *http.Request) {
+	cred := client.credentials
+	switch {
+	case cred.usesOAuth():
+		if cred.Username != "" {
+			req.Header.Set(OAuthUserHeader, cred.Username)
+		}
+	case cred.Token != "":
+		req.SetBasicAuth(cred.Username, cred.Token)
+	default:
+		req.SetBasicAuth(cred.Username, cred.Password)
+	}
+}
+
+// signOAuthRequest signs the request with the OAuth consumer key and secret of
+// the client (two-legged OAuth 1.0a, HMAC-SHA1). The body of the request is
+// not part of the signature since it is never form encoded.
+func (client *Client) signOAuthRequest(req *http.Request) error {
+	utils.TraceFunctionCall()
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     client.credentials.OAuthConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	oauthParams["oauth_signature"] = oauthSignature(
+		req.Method,
+		req.URL,
+		oauthParams,
+		client.credentials.OAuthConsumerSecret,
+	)
+
+	keys := make([]string, 0, len(oauthParams))
+	for key := range oauthParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := make([]string, 0, len(keys))
+	for _, key := range keys {
+		header = append(header, fmt.Sprintf(`%s="%s"`, key, oauthEscape(oauthParams[key])))
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+
+	return nil
+}
+
+// oauthSignature computes the HMAC-SHA1 signature of a request as defined in
+// RFC 5849, section 3.4
+func oauthSignature(method string, reqURL *url.URL, oauthParams map[string]string, consumerSecret string) string {
+	// collect and normalize the query and protocol parameters
+	params := []string{}
+	for key, values := range reqURL.Query() {
+		for _, value := range values {
+			params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+		}
+	}
+	for key, value := range oauthParams {
+		if key == "oauth_signature" {
+			continue
+		}
+		params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+	}
+	sort.Strings(params)
+
+	baseURL := url.URL{
+		Scheme: strings.ToLower(reqURL.Scheme),
+		Host:   strings.ToLower(reqURL.Host),
+		Path:   reqURL.EscapedPath(),
+	}
+	baseString := strings.ToUpper(method) + "&" +
+		oauthEscape(baseURL.Scheme+"://"+baseURL.Host+baseURL.Path) + "&" +
+		oauthEscape(strings.Join(params, "&"))
+
+	// there is no token secret in two-legged OAuth
+	mac := hmac.New(sha1.New, []byte(oauthEscape(consumerSecret)+"&"))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oauthEscape percent-encodes a value as required by RFC 5849, section 3.6
+func oauthEscape(s string) string {
+	escaped := url.QueryEscape(s)
+	escaped = strings.ReplaceAll(escaped, "+", "%20")
+	return strings.ReplaceAll(escaped, "%7E", "~")
+}
+
+// ----------------------------------------------------------------------------
+// Credential Validation
+// ----------------------------------------------------------------------------
+
+// ValidateCredentials sends an authenticated request to Foreman and returns
+// an HTTPError if the credentials of the client are rejected. The request is
+// sent once and limited to a short timeout, an unreachable Foreman must not
+// stall the provider configuration.
+func (client *Client) ValidateCredentials(ctx context.Context) error {
+	log.Tracef("foreman/api/auth.go#ValidateCredentials")
+
+	ctx, cancel := context.WithTimeout(ctx, credentialsValidationTimeout)
+	defer cancel()
+
+	req, reqErr := client.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		credentialsValidationEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	statusCode, _, respBody, sendErr := client.sendOnce(req)
+	if sendErr != nil {
+		return sendErr
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return HTTPError{req.URL.String(), statusCode, string(respBody)}
+	}
+	return nil
+}
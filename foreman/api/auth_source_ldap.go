@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	AuthSourceLDAPEndpointPrefix = "auth_source_ldaps"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanAuthSourceLDAP API model represents a LDAP authentication
+// source. Users of a LDAP authentication source log in with their directory
+// credentials.
+type ForemanAuthSourceLDAP struct {
+	// Inherits the base object's attributes
+	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
+
+	// Hostname of the LDAP server
+	Host string `json:"host"`
+	// Port of the LDAP server
+	Port int `json:"port,omitempty"`
+	// Whether to connect to the LDAP server with TLS (LDAPS)
+	TLS bool `json:"tls"`
+	// Type of the LDAP server, one of "posix", "free_ipa" or "active_directory"
+	ServerType string `json:"server_type,omitempty"`
+
+	// Base DN to search users in
+	BaseDN string `json:"base_dn"`
+	// Base DN to search groups in
+	GroupsBase string `json:"groups_base"`
+	// LDAP filter restricting the users which can log in
+	LDAPFilter string `json:"ldap_filter"`
+	// Whether to use netgroups instead of POSIX groups
+	UseNetgroups bool `json:"use_netgroups"`
+
+	// Account used to bind to the LDAP server. Use "$login" to bind with the
+	// credentials of the user logging in.
+	Account string `json:"account"`
+	// Password of the bind account. Foreman never returns it.
+	AccountPassword string `json:"account_password,omitempty"`
+
+	// LDAP attributes the user's details are read from
+	AttrLogin     string `json:"attr_login"`
+	AttrFirstname string `json:"attr_firstname"`
+	AttrLastname  string `json:"attr_lastname"`
+	AttrMail      string `json:"attr_mail"`
+	AttrPhoto     string `json:"attr_photo"`
+
+	// Whether users are created in Foreman on their first login
+	OntheflyRegister bool `json:"onthefly_register"`
+	// Whether the members of external usergroups are synchronized on login
+	UsergroupSync bool `json:"usergroup_sync"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateAuthSourceLDAP creates a new ForemanAuthSourceLDAP with the attributes
+// of the supplied ForemanAuthSourceLDAP reference and returns the created
+// ForemanAuthSourceLDAP reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLDAPEndpointPrefix)
+
+	authSourceJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("authSourceJSONBytes: [%s]", authSourceJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(authSourceJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdAuthSource ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &createdAuthSource)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdAuthSource: [%+v]", createdAuthSource)
+
+	return &createdAuthSource, nil
+}
+
+// ReadAuthSourceLDAP reads the attributes of a ForemanAuthSourceLDAP
+// identified by the supplied ID and returns a ForemanAuthSourceLDAP reference.
+func (c *Client) ReadAuthSourceLDAP(ctx context.Context, id int) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readAuthSource ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &readAuthSource)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readAuthSource: [%+v]", readAuthSource)
+
+	return &readAuthSource, nil
+}
+
+// UpdateAuthSourceLDAP updates a ForemanAuthSourceLDAP's attributes.  The
+// authentication source with the supplied ID will be updated. A new
+// ForemanAuthSourceLDAP reference is returned with the attributes from the
+// result of the update operation.
+func (c *Client) UpdateAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP, id int) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, id)
+
+	authSourceJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("authSourceJSONBytes: [%s]", authSourceJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(authSourceJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedAuthSource ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &updatedAuthSource)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedAuthSource: [%+v]", updatedAuthSource)
+
+	return &updatedAuthSource, nil
+}
+
+// DeleteAuthSourceLDAP deletes the ForemanAuthSourceLDAP identified by the
+// supplied ID
+func (c *Client) DeleteAuthSourceLDAP(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/auth_source_ldap.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryAuthSourceLDAP queries for a ForemanAuthSourceLDAP based on the
+// attributes of the supplied ForemanAuthSourceLDAP reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// LDAP authentication sources.
+func (c *Client) QueryAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLDAPEndpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + a.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanAuthSourceLDAP for
+	// the results
+	results := []ForemanAuthSourceLDAP{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanAuthSourceLDAP to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
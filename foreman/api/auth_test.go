@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Ensures a token is sent as the password of HTTP basic authentication
+func TestNewRequest_Token(t *testing.T) {
+	cases := []struct {
+		cred     ClientCredentials
+		expected string
+	}{
+		{
+			ClientCredentials{Username: "svc-automation", Token: "s3cr3t"},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("svc-automation:s3cr3t")),
+		},
+		{
+			ClientCredentials{Username: "admin", Password: "changeme"},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("admin:changeme")),
+		},
+	}
+
+	for _, c := range cases {
+		client := NewClient(Server{}, c.cred, ClientConfig{})
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+		if req.Header.Get("Authorization") != c.expected {
+			t.Errorf(
+				"Client.NewRequestWithContext() set Authorization [%s], expected [%s]",
+				req.Header.Get("Authorization"),
+				c.expected,
+			)
+		}
+	}
+}
+
+// Ensures combinations of authentication mechanisms which exclude each other
+// are rejected
+func TestClientCredentials_Validate(t *testing.T) {
+	cases := []struct {
+		cred  ClientCredentials
+		valid bool
+	}{
+		{ClientCredentials{Username: "admin", Password: "changeme"}, true},
+		{ClientCredentials{Username: "admin", Token: "s3cr3t"}, true},
+		{ClientCredentials{OAuthConsumerKey: "key", OAuthConsumerSecret: "secret"}, true},
+		{ClientCredentials{Username: "admin", OAuthConsumerKey: "key", OAuthConsumerSecret: "secret"}, true},
+		{ClientCredentials{Username: "admin", Password: "changeme", Token: "s3cr3t"}, false},
+		{ClientCredentials{Token: "s3cr3t"}, false},
+		{ClientCredentials{OAuthConsumerKey: "key"}, false},
+		{ClientCredentials{OAuthConsumerSecret: "secret"}, false},
+		{ClientCredentials{Username: "admin", Token: "s3cr3t", OAuthConsumerKey: "key", OAuthConsumerSecret: "secret"}, false},
+	}
+
+	for _, c := range cases {
+		err := c.cred.Validate()
+		if (err == nil) != c.valid {
+			t.Errorf("ClientCredentials.Validate() returned [%v] for [%+v]", err, c.cred)
+		}
+	}
+}
+
+// Ensures the OAuth signature matches a signature computed independently for
+// the same request
+func TestOAuthSignature(t *testing.T) {
+	reqURL, _ := url.Parse("https://Foreman.example.com/api/hosts?search=name%3D%22web+1%22&per_page=20")
+	params := map[string]string{
+		"oauth_consumer_key":     "foreman-key",
+		"oauth_nonce":            "0123456789abcdef",
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        "1718200000",
+		"oauth_version":          "1.0",
+	}
+
+	signature := oauthSignature(http.MethodGet, reqURL, params, "foreman secret~")
+	if signature != "DIbvkd/T2Fvl89QLyruno/WDsr4=" {
+		t.Errorf("oauthSignature returned [%s], expected [DIbvkd/T2Fvl89QLyruno/WDsr4=]", signature)
+	}
+}
+
+// Ensures OAuth requests are signed including the query set after the request
+// was constructed and mapped to the configured user
+func TestSend_OAuthSignedRequest(t *testing.T) {
+	cred := ClientCredentials{
+		Username:            "svc-automation",
+		OAuthConsumerKey:    "foreman-key",
+		OAuthConsumerSecret: "foreman secret~",
+	}
+	mux, server, client := NewForemanAPIAndClient(cred, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(OAuthUserHeader) != "svc-automation" {
+			t.Errorf("OAuth request was mapped to user [%s], expected [svc-automation]", r.Header.Get(OAuthUserHeader))
+		}
+
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, "OAuth ") {
+			t.Fatalf("OAuth request has Authorization [%s]", header)
+		}
+		params := map[string]string{}
+		for _, param := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+			kv := strings.SplitN(param, "=", 2)
+			value, _ := url.QueryUnescape(strings.Trim(kv[1], `"`))
+			params[kv[0]] = value
+		}
+		if params["oauth_consumer_key"] != "foreman-key" || params["oauth_signature_method"] != "HMAC-SHA1" {
+			t.Errorf("OAuth request has unexpected parameters [%+v]", params)
+		}
+
+		reqURL := *r.URL
+		reqURL.Scheme = "http"
+		reqURL.Host = r.Host
+		expected := oauthSignature(r.Method, &reqURL, params, "foreman secret~")
+		if params["oauth_signature"] != expected {
+			t.Errorf("OAuth request has signature [%s], expected [%s]", params["oauth_signature"], expected)
+		}
+
+		// the query is part of the signature
+		reqURL.RawQuery = ""
+		if params["oauth_signature"] == oauthSignature(r.Method, &reqURL, params, "foreman secret~") {
+			t.Errorf("OAuth request signature does not cover the query")
+		}
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts", nil)
+	query := req.URL.Query()
+	query.Set("search", `name="web 1"`)
+	req.URL.RawQuery = query.Encode()
+
+	if err := client.SendAndParse(req, nil); err != nil {
+		t.Fatalf("SendAndParse returned an error: [%s]", err)
+	}
+}
+
+// Ensures rejected credentials are reported with their status code
+func TestValidateCredentials_Unauthorized(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{Username: "admin", Token: "expired"}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"message":"Unable to authenticate user"}}`)
+	})
+
+	err := client.ValidateCredentials(context.Background())
+
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("ValidateCredentials returned [%v], expected a HTTPError with status code [401]", err)
+	}
+}
+
+// Ensures the credentials are validated with a single request, even if the
+// client retries failed requests
+func TestValidateCredentials_SingleAttempt(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(
+		ClientCredentials{Username: "admin", Token: "s3cr3t"},
+		ClientConfig{RetryMaxAttempts: 4},
+	)
+	defer server.Close()
+
+	requests := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/status", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	err := client.ValidateCredentials(context.Background())
+
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("ValidateCredentials returned [%v], expected a HTTPError with status code [503]", err)
+	}
+	if requests != 1 {
+		t.Errorf("ValidateCredentials sent [%d] requests, expected [1]", requests)
+	}
+}
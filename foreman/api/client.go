@@ -4,12 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/dpotapov/go-spnego"
@@ -50,6 +50,13 @@ const (
 type ClientCredentials struct {
 	Username string
 	Password string
+
+	// Personal access token, used instead of the password
+	Token string
+
+	// OAuth consumer key and secret to sign the requests with
+	OAuthConsumerKey    string
+	OAuthConsumerSecret string
 }
 
 // Configurable features to apply the REST client
@@ -61,6 +68,18 @@ type ClientConfig struct {
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information
 	TLSInsecureEnabled bool
 
+	// PEM encoded CA certificates to verify the server's certificate with,
+	// in addition to the system's certificate pool
+	TLSCABundle []byte
+
+	// PEM encoded client certificate and private key presented to the server
+	// for mutual TLS authentication
+	TLSClientCert []byte
+	TLSClientKey  []byte
+
+	// Overrides the hostname the server's certificate is verified against
+	TLSServerName string
+
 	// Whether or not the client should try to authenticate to foreman
 	// through the HTTP negotiate mechanism.
 	NegotiateAuthEnabled bool
@@ -68,6 +87,38 @@ type ClientConfig struct {
 	// Information as required by all API calls
 	LocationID     int
 	OrganizationID int
+
+	// Polling behaviour when waiting for asynchronous Foreman tasks and host
+	// builds. The interval between two polls starts at TaskPollInterval and
+	// is multiplied by TaskPollBackoffFactor after every poll, up to
+	// TaskPollMaxInterval.
+	TaskPollInterval      time.Duration
+	TaskPollMaxInterval   time.Duration
+	TaskPollBackoffFactor float64
+
+	// Retry behaviour for transient failures. A request is sent at most
+	// RetryMaxAttempts times (values below 2 disable retries) and not retried
+	// once RetryMaxElapsed has passed since the first attempt (0 means no
+	// limit). The wait between two attempts starts at RetryWaitMin and
+	// doubles up to RetryWaitMax, zero values use the defaults.  Requests
+	// with non-idempotent methods (POST, PATCH) are only retried if
+	// RetryNonIdempotent is set.
+	RetryMaxAttempts   int
+	RetryMaxElapsed    time.Duration
+	RetryWaitMin       time.Duration
+	RetryWaitMax       time.Duration
+	RetryNonIdempotent bool
+
+	// Client-side throttling shared by all requests of the client. RateLimit
+	// is the maximum number of requests per second, MaxConcurrentRequests the
+	// maximum number of requests in flight at the same time. 0 disables the
+	// respective limit.
+	RateLimit             float64
+	MaxConcurrentRequests int
+
+	// Number of results requested per page when walking the pages of a
+	// query. 0 uses DefaultQueryPerPage.
+	QueryPerPage int
 }
 
 type Client struct {
@@ -82,6 +133,10 @@ type Client struct {
 
 	// Keep a copy of the client configuration for use in API calls
 	clientConfig ClientConfig
+
+	// Throttling of the requests, nil if disabled
+	rateLimiter *rateLimiter
+	inFlight    chan struct{}
 }
 
 type HTTPError struct {
@@ -144,12 +199,17 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		cfg,
 	)
 
-	// Initialize the HTTP client for use by the provider.  The insecure flag
-	// from the provider config is used when configuring the TLS settings of
-	// the HTTP client.
+	// Initialize the HTTP client for use by the provider.  The TLS options
+	// from the provider config are used when configuring the TLS settings of
+	// the HTTP client.  The provider validates them before creating the
+	// client, so an error here only falls back to the insecure flag.
 	cleanClient := cleanhttp.DefaultClient()
-	tlsClientConfig := &tls.Config{
-		InsecureSkipVerify: cfg.TLSInsecureEnabled,
+	tlsClientConfig, tlsErr := cfg.TLSConfig()
+	if tlsErr != nil {
+		log.Errorf("Invalid TLS configuration: %s", tlsErr)
+		tlsClientConfig = &tls.Config{
+			InsecureSkipVerify: cfg.TLSInsecureEnabled,
+		}
 	}
 	if cfg.NegotiateAuthEnabled {
 		transCfg := &spnego.Transport{}
@@ -168,6 +228,12 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		credentials:  c,
 		clientConfig: cfg,
 	}
+	if cfg.RateLimit > 0 {
+		client.rateLimiter = newRateLimiter(cfg.RateLimit)
+	}
+	if cfg.MaxConcurrentRequests > 0 {
+		client.inFlight = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
 	return &client
 }
 
@@ -188,7 +254,7 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 //	User-Agent
 //	ACCEPT
 //	Content-Type
-//	Authorization
+//	Authorization (set by Client.Send() for OAuth signed requests)
 //
 // method
 //
@@ -265,7 +331,7 @@ func (client *Client) NewRequestWithContext(ctx context.Context, method string,
 	req.Header.Add("User-Agent", "terraform-provider-foreman")
 	req.Header.Add("Accept", "application/json,"+version_append)
 	req.Header.Add("Content-Type", "application/json")
-	req.SetBasicAuth(client.credentials.Username, client.credentials.Password)
+	client.authenticateRequest(req)
 	return req, nil
 }
 
@@ -302,6 +368,11 @@ func isValidRequestMethod(method string) bool {
 // the StatusCode, response. Serves as a facade to the Client's underlying
 // HTTP client.
 //
+// Transient failures (connection errors, 409, 429, 502, 503 and 504) are
+// retried with an exponential backoff according to the client
+// configuration.  Only idempotent requests are retried unless
+// RetryNonIdempotent is set.
+//
 // If an error is encountered when reading the server's response, the returned
 // StatusCode will be -1.  If an error is encountered during any step of the
 // the send and response parsing, an empty slice will be returned as the
@@ -313,13 +384,77 @@ func isValidRequestMethod(method string) bool {
 func (client *Client) Send(request *http.Request) (int, []byte, error) {
 	utils.TraceFunctionCall()
 
-	emptySlice := []byte{}
-
 	if request == nil {
 		log.Errorf("Client trying to send a nil request")
-		return -1, emptySlice, fmt.Errorf("Client trying to send a nil request")
+		return -1, []byte{}, fmt.Errorf("Client trying to send a nil request")
 	}
 
+	cfg := client.clientConfig
+	// A request body which cannot be recreated can only be sent once
+	retry := cfg.retryEnabled(request.Method) &&
+		(request.Body == nil || request.Body == http.NoBody || request.GetBody != nil)
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		statusCode, header, respBody, sendErr := client.sendOnce(request)
+		if !retry || attempt >= cfg.RetryMaxAttempts || !shouldRetry(request.Context(), statusCode, sendErr) {
+			return statusCode, respBody, sendErr
+		}
+
+		wait := cfg.retryWait(attempt, header)
+		if cfg.RetryMaxElapsed > 0 && time.Since(start)+wait > cfg.RetryMaxElapsed {
+			log.Warningf(
+				"Giving up on %s %s after %d attempts, retrying would exceed the maximum elapsed time [%s]",
+				request.Method,
+				request.URL,
+				attempt,
+				cfg.RetryMaxElapsed,
+			)
+			return statusCode, respBody, sendErr
+		}
+
+		log.Warningf(
+			"Retrying %s %s in %s (attempt %d of %d), status code [%d], error [%v]",
+			request.Method,
+			request.URL,
+			wait,
+			attempt+1,
+			cfg.RetryMaxAttempts,
+			statusCode,
+			sendErr,
+		)
+		if sleepErr := SleepContext(request.Context(), wait); sleepErr != nil {
+			return statusCode, respBody, sendErr
+		}
+
+		if request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return -1, []byte{}, bodyErr
+			}
+			request.Body = body
+		}
+	}
+}
+
+// sendOnce performs a single attempt of Client.Send() and additionally
+// returns the response headers
+func (client *Client) sendOnce(request *http.Request) (int, http.Header, []byte, error) {
+	emptySlice := []byte{}
+
+	// Sign the request as late as possible, the signature covers the query
+	if client.credentials.usesOAuth() {
+		if signErr := client.signOAuthRequest(request); signErr != nil {
+			return -1, nil, emptySlice, signErr
+		}
+	}
+
+	release, throttleErr := client.throttle(request)
+	if throttleErr != nil {
+		return -1, nil, emptySlice, throttleErr
+	}
+	defer release()
+
 	// Send the request to the server
 	resp, respErr := client.httpClient.Do(request)
 	if respErr != nil {
@@ -328,7 +463,7 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			respErr.Error(),
 		)
-		return -1, emptySlice, respErr
+		return -1, nil, emptySlice, respErr
 	}
 	// NOTE(ALL): Golang stdlib dictates that it is the caller's resposibility
 	//   to close the response body.  See net/http Response type for more
@@ -343,10 +478,10 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			readErr.Error(),
 		)
-		return resp.StatusCode, emptySlice, readErr
+		return resp.StatusCode, resp.Header, emptySlice, readErr
 	}
 
-	return resp.StatusCode, respBody, nil
+	return resp.StatusCode, resp.Header, respBody, nil
 }
 
 // SendAndParse sends an HTTP request generated by Client.NewRequestWithContext() and
@@ -376,7 +511,7 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	)
 
 	// Handle Katello async responses.
-	// Be aware, that waitForKatelloAsyncTask also lands here. We just need to trust that the
+	// Be aware, that WaitForForemanTask also lands here. We just need to trust that the
 	// foreman_tasks API endpoint does not omit 202 as well.
 	// Officially, 202 is the code for "accepted, but not processed yet".
 	if statusCode == 202 {
@@ -389,7 +524,8 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 
 		if asyncTask.Pending {
 			log.Debugf("KatelloResponse is pending")
-			finishedTask, err := client.waitForKatelloAsyncTask(asyncTask.Id)
+			ctx := req.Context()
+			finishedTask, err := client.WaitForForemanTask(ctx, asyncTask.Id)
 			if err != nil {
 				return err
 			}
@@ -403,7 +539,6 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 					ForemanObject: ForemanObject{Id: int(output["content_view_id"].(float64))},
 				}
 
-				ctx := context.TODO()
 				updatedCv, err := client.ReadKatelloContentView(ctx, &cvToRead)
 				if err != nil {
 					return err
@@ -414,12 +549,11 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 					return err
 				}
 
-			case "Actions::Katello::ContentView::Remove":
-				// Used by endpoint PUT /katello/api/content_views/:id/remove
-				success := finishedTask.Result == "success"
-				if !success {
-					errorMsg := fmt.Sprintf("error in removing content_view: %v", finishedTask.Humanized.Errors)
-					return errors.New(errorMsg)
+			default:
+				// Return the finished task instead of the pending one
+				respBody, err = json.Marshal(finishedTask)
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -488,10 +622,34 @@ func (client *Client) WrapJSONWithTaxonomy(name interface{}, item interface{}) (
 		return nil, err
 	}
 
+	// Send emptied organizations and locations as empty lists to remove all
+	// of them from the object
+	if clearer, ok := item.(taxonomyClearer); ok {
+		if cleared := clearer.clearedTaxonomyIds(); len(cleared) > 0 {
+			obj := wrapped
+			if name != nil {
+				if obj, err = client.wrapParameters(nil, item); err != nil {
+					return nil, err
+				}
+				wrapped[fmt.Sprintf("%v", name)] = obj
+			}
+			for key, ids := range cleared {
+				obj[key] = ids
+			}
+		}
+	}
+
 	// Workaround for Foreman versions < 1.21 in case no default location/organization was defined for resources
 	if client.clientConfig.LocationID >= 0 && client.clientConfig.OrganizationID >= 0 {
-		wrapped["location_id"] = client.clientConfig.LocationID
-		wrapped["organization_id"] = client.clientConfig.OrganizationID
+		// Objects assigned to locations or organizations of their own are not
+		// scoped to the provider's default, as they might not be part of it
+		override, ok := item.(taxonomyOverride)
+		if !ok || !override.overridesLocation() {
+			wrapped["location_id"] = client.clientConfig.LocationID
+		}
+		if !ok || !override.overridesOrganization() {
+			wrapped["organization_id"] = client.clientConfig.OrganizationID
+		}
 		log.Debugf("client.go#WrapJSONWithTaxonomy: item %+v", wrapped)
 	}
 
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -471,3 +472,144 @@ func TestSendAndParseStatusCodeNoError(t *testing.T) {
 		)
 	}
 }
+
+// Ensures SendAndParse() waits for the task of an accepted (202) request and
+// parses the finished task instead of the pending one
+func TestSendAndParse_AcceptedReturnsFinishedTask(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"abc","label":"Actions::Foo","pending":true,"state":"planned"}`))
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"abc","label":"Actions::Foo",` +
+			`"pending":false,"state":"stopped","result":"success","ended_at":"2024-05-01 10:00:00 UTC"}`))
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodPost, "/foo", nil)
+	var task ForemanTask
+	if sendErr := client.SendAndParse(req, &task); sendErr != nil {
+		t.Fatalf("Client.SendAndParse() returned an error: [%s]", sendErr)
+	}
+	if task.Pending || task.Result != "success" || task.EndedAt == "" {
+		t.Fatalf("Client.SendAndParse() parsed the pending task: [%+v]", task)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// WrapJSONWithTaxonomy
+// ----------------------------------------------------------------------------
+
+// Ensures the provider's default organization and location are only added
+// for objects that do not set their own
+func TestWrapJSONWithTaxonomy_Override(t *testing.T) {
+	conf := ClientConfig{
+		LocationID:     2,
+		OrganizationID: 1,
+	}
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+	defer server.Close()
+
+	locationId := 5
+	testCases := []struct {
+		name     string
+		item     interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "default",
+			item: &ForemanDomain{},
+			expected: map[string]interface{}{
+				"location_id":     float64(2),
+				"organization_id": float64(1),
+			},
+		},
+		{
+			name: "location_ids",
+			item: &ForemanDomain{
+				ForemanTaxonomyIds: ForemanTaxonomyIds{LocationIds: []int{3, 4}},
+			},
+			expected: map[string]interface{}{
+				"organization_id": float64(1),
+			},
+		},
+		{
+			name: "organization_ids",
+			item: &ForemanDomain{
+				ForemanTaxonomyIds: ForemanTaxonomyIds{OrganizationIds: []int{3}},
+			},
+			expected: map[string]interface{}{
+				"location_id": float64(2),
+			},
+		},
+		{
+			name: "host location_id",
+			item: &ForemanHost{LocationId: &locationId},
+			expected: map[string]interface{}{
+				"organization_id": float64(1),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		wrappedBytes, err := client.WrapJSONWithTaxonomy("item", testCase.item)
+		if err != nil {
+			t.Fatalf("[%s] WrapJSONWithTaxonomy returned an error: [%s]", testCase.name, err)
+		}
+
+		var wrapped map[string]interface{}
+		if err := json.Unmarshal(wrappedBytes, &wrapped); err != nil {
+			t.Fatalf("[%s] could not decode the wrapped JSON: [%s]", testCase.name, err)
+		}
+		delete(wrapped, "item")
+
+		if !reflect.DeepEqual(wrapped, testCase.expected) {
+			t.Errorf(
+				"[%s] WrapJSONWithTaxonomy added the wrong taxonomy. Expected [%v], got [%v]",
+				testCase.name,
+				testCase.expected,
+				wrapped,
+			)
+		}
+	}
+}
+
+// Ensures emptied organizations and locations are sent as empty lists and the
+// object is not scoped to the provider's defaults
+func TestWrapJSONWithTaxonomy_ClearedIds(t *testing.T) {
+	conf := ClientConfig{
+		LocationID:     2,
+		OrganizationID: 1,
+	}
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+	defer server.Close()
+
+	item := &ForemanDomain{
+		ForemanObject:      ForemanObject{Name: "example.com"},
+		ForemanTaxonomyIds: ForemanTaxonomyIds{LocationIds: []int{}},
+	}
+	wrappedBytes, err := client.WrapJSONWithTaxonomy("domain", item)
+	if err != nil {
+		t.Fatalf("WrapJSONWithTaxonomy returned an error: [%s]", err)
+	}
+
+	var wrapped map[string]interface{}
+	if err := json.Unmarshal(wrappedBytes, &wrapped); err != nil {
+		t.Fatalf("Could not decode the wrapped JSON: [%s]", err)
+	}
+	domain := wrapped["domain"].(map[string]interface{})
+	if ids, ok := domain["location_ids"]; !ok || !reflect.DeepEqual(ids, []interface{}{}) {
+		t.Errorf("WrapJSONWithTaxonomy sent location_ids [%v], expected []", ids)
+	}
+	if _, ok := domain["organization_ids"]; ok {
+		t.Errorf("WrapJSONWithTaxonomy sent the unset organization_ids: [%s]", wrappedBytes)
+	}
+	if domain["name"] != "example.com" {
+		t.Errorf("WrapJSONWithTaxonomy lost the attributes of the domain: [%s]", wrappedBytes)
+	}
+	if _, ok := wrapped["location_id"]; ok || wrapped["organization_id"] != float64(1) {
+		t.Errorf("WrapJSONWithTaxonomy added the wrong taxonomy: [%s]", wrappedBytes)
+	}
+}
@@ -169,7 +169,7 @@ func (c *Client) DeleteCommonParameter(ctx context.Context, d *ForemanCommonPara
 // QueryCommonParameter queries for a ForemanCommonParameter based on the attributes of the
 // supplied ForemanCommonParameter reference and returns a QueryResponse struct
 // containing query/response metadata and the matching commonParameters.
-func (c *Client) QueryCommonParameter(ctx context.Context, d *ForemanCommonParameter) (QueryResponse, error) {
+func (c *Client) QueryCommonParameter(ctx context.Context, d *ForemanCommonParameter, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/common_parameter.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -191,7 +191,7 @@ func (c *Client) QueryCommonParameter(ctx context.Context, d *ForemanCommonParam
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -137,7 +137,7 @@ func (c *Client) ReadComputeProfile(ctx context.Context, id int) (*ForemanComput
 // QueryComputeProfile queries for a ForemanComputeProfile based on the attributes
 // of the supplied ForemanComputeProfile reference and returns a QueryResponse
 // struct containing query/response metadata and the matching template kinds
-func (c *Client) QueryComputeProfile(ctx context.Context, t *ForemanComputeProfile) (QueryResponse, error) {
+func (c *Client) QueryComputeProfile(ctx context.Context, t *ForemanComputeProfile, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/templatekind.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -159,7 +159,7 @@ func (c *Client) QueryComputeProfile(ctx context.Context, t *ForemanComputeProfi
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
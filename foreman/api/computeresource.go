@@ -25,6 +25,8 @@ const (
 type ForemanComputeResource struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	Description string `json:"description"`
 	URL         string `json:"url"`
@@ -54,6 +56,12 @@ func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
 	}
 	fcr.ForemanObject = fo
 
+	// Unmarshal the organizations and locations of the object
+	jsonDecErr = json.Unmarshal(b, &fcr.ForemanTaxonomyIds)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
 	// Without this, properties will define as default values in state file.
@@ -232,7 +240,7 @@ func (c *Client) DeleteComputeResource(ctx context.Context, id int) error {
 // QueryComputeResource queries for a ForemanComputeResource based on the attributes of the
 // supplied ForemanComputeResource reference and returns a QueryResponse struct
 // containing query/response metadata and the matching computeresources.
-func (c *Client) QueryComputeResource(ctx context.Context, d *ForemanComputeResource) (QueryResponse, error) {
+func (c *Client) QueryComputeResource(ctx context.Context, d *ForemanComputeResource, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/computeresource.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -254,7 +262,7 @@ func (c *Client) QueryComputeResource(ctx context.Context, d *ForemanComputeReso
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -161,7 +161,7 @@ func (c *Client) DeleteDefaultTemplate(ctx context.Context, d *ForemanDefaultTem
 // QueryDefaultTemplate queries for a ForemanDefaultTemplate based on the attributes of the
 // supplied ForemanDefaultTemplate reference and returns a QueryResponse struct
 // containing query/response metadata and the matching parameters.
-func (c *Client) QueryDefaultTemplate(ctx context.Context, d *ForemanDefaultTemplate) (QueryResponse, error) {
+func (c *Client) QueryDefaultTemplate(ctx context.Context, d *ForemanDefaultTemplate, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/parameter.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -183,7 +183,7 @@ func (c *Client) QueryDefaultTemplate(ctx context.Context, d *ForemanDefaultTemp
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -162,7 +162,7 @@ func (c *Client) DeleteDiscoveryRule(ctx context.Context, id int) error {
 }
 
 // QueryDiscoveryRule queries the ForemanDiscoveryRule identified by the supplied ForemanDiscoveryRule
-func (c *Client) QueryDiscoveryRule(ctx context.Context, d *ForemanDiscoveryRule) (QueryResponse, error) {
+func (c *Client) QueryDiscoveryRule(ctx context.Context, d *ForemanDiscoveryRule, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/discovery_rule.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -181,7 +181,7 @@ func (c *Client) QueryDiscoveryRule(ctx context.Context, d *ForemanDiscoveryRule
 	reqQuery.Set("search", fmt.Sprintf("name=\"%s\"", d.Name))
 
 	req.URL.RawQuery = reqQuery.Encode()
-	if err := c.SendAndParse(req, &queryResponse); err != nil {
+	if err := c.SendAndParseQuery(req, &queryResponse, opts...); err != nil {
 		return queryResponse, err
 	}
 
@@ -24,6 +24,8 @@ const (
 type ForemanDomain struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// Fully qualified domain name
 	Fullname string `json:"fullname"`
@@ -163,7 +165,7 @@ func (c *Client) DeleteDomain(ctx context.Context, id int) error {
 // QueryDomain queries for a ForemanDomain based on the attributes of the
 // supplied ForemanDomain reference and returns a QueryResponse struct
 // containing query/response metadata and the matching domains.
-func (c *Client) QueryDomain(ctx context.Context, d *ForemanDomain) (QueryResponse, error) {
+func (c *Client) QueryDomain(ctx context.Context, d *ForemanDomain, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/domain.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -185,7 +187,7 @@ func (c *Client) QueryDomain(ctx context.Context, d *ForemanDomain) (QueryRespon
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
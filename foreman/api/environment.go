@@ -22,6 +22,8 @@ const (
 type ForemanEnvironment struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 }
 
 // -----------------------------------------------------------------------------
@@ -157,7 +159,7 @@ func (c *Client) DeleteEnvironment(ctx context.Context, id int) error {
 // QueryEnvironment queries for a ForemanEnvironment based on the attributes of
 // the supplied ForemanEnvironment reference and returns a QueryResponse struct
 // containing query/response metadata and the matching environments.
-func (c *Client) QueryEnvironment(ctx context.Context, e *ForemanEnvironment) (QueryResponse, error) {
+func (c *Client) QueryEnvironment(ctx context.Context, e *ForemanEnvironment, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/environment.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -179,7 +181,7 @@ func (c *Client) QueryEnvironment(ctx context.Context, e *ForemanEnvironment) (Q
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	ExternalUsergroupEndpointPrefix = "external_usergroups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanExternalUsergroup API model represents a group of an external
+// authentication source (eg. a LDAP group) whose members are synchronized
+// into a Foreman usergroup.
+type ForemanExternalUsergroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the authentication source the group is looked up in
+	AuthSourceId int `json:"auth_source_id"`
+}
+
+// foremanExternalUsergroupDecode is used to decode the nested authentication
+// source of an external usergroup.
+type foremanExternalUsergroupDecode struct {
+	ForemanExternalUsergroup
+	AuthSourceDecode EntityResponse `json:"auth_source"`
+}
+
+// toForemanExternalUsergroup returns the decoded external usergroup with the
+// ID of its nested authentication source
+func (d foremanExternalUsergroupDecode) toForemanExternalUsergroup() ForemanExternalUsergroup {
+	eu := d.ForemanExternalUsergroup
+	if d.AuthSourceDecode.ID != 0 {
+		eu.AuthSourceId = d.AuthSourceDecode.ID
+	}
+	return eu
+}
+
+// Implement the Marshaler interface
+func (eu ForemanExternalUsergroup) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/external_usergroup.go#MarshalJSON")
+
+	euMap := map[string]interface{}{}
+
+	euMap["name"] = eu.Name
+	euMap["auth_source_id"] = eu.AuthSourceId
+
+	log.Debugf("euMap: [%v]", euMap)
+
+	return json.Marshal(euMap)
+}
+
+// sendAndParseExternalUsergroup sends the request and decodes the external
+// usergroup of the response
+func (c *Client) sendAndParseExternalUsergroup(req *http.Request) (*ForemanExternalUsergroup, error) {
+	var decoded foremanExternalUsergroupDecode
+	sendErr := c.SendAndParse(req, &decoded)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	eu := decoded.toForemanExternalUsergroup()
+	return &eu, nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateExternalUsergroup maps a new external group to the usergroup with the
+// supplied ID and returns the created ForemanExternalUsergroup reference.
+// Foreman synchronizes the members of the external group on creation.
+func (c *Client) CreateExternalUsergroup(ctx context.Context, usergroupId int, eu *ForemanExternalUsergroup) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/external_usergroup.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", UsergroupEndpointPrefix, usergroupId, ExternalUsergroupEndpointPrefix)
+
+	euJSONBytes, jsonEncErr := c.WrapJSON("external_usergroup", eu)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("externalUsergroupJSONBytes: [%s]", euJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(euJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	createdExternalUsergroup, sendErr := c.sendAndParseExternalUsergroup(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdExternalUsergroup: [%+v]", createdExternalUsergroup)
+
+	return createdExternalUsergroup, nil
+}
+
+// DeleteExternalUsergroup removes the external group with the supplied ID from
+// the usergroup with the supplied ID
+func (c *Client) DeleteExternalUsergroup(ctx context.Context, usergroupId int, id int) error {
+	log.Tracef("foreman/api/external_usergroup.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%d", UsergroupEndpointPrefix, usergroupId, ExternalUsergroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
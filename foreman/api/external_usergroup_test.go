@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// Ensures the members and external groups of a usergroup are decoded
+func TestReadUsergroup_Members(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/usergroups/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"operators","admin":false,`+
+			`"users":[{"id":5,"login":"jdoe"},{"id":6,"login":"asmith"}],`+
+			`"usergroups":[{"id":4,"name":"oncall"}],`+
+			`"external_usergroups":[{"id":9,"name":"ops",`+
+			`"auth_source":{"id":7,"name":"Corporate LDAP","type":"AuthSourceLdap"}}]}`)
+	})
+
+	usergroup, err := client.ReadUsergroup(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ReadUsergroup returned an error: [%s]", err)
+	}
+	if !reflect.DeepEqual(usergroup.UserIds, []int{5, 6}) {
+		t.Errorf("ReadUsergroup returned user IDs [%v], expected [[5 6]]", usergroup.UserIds)
+	}
+	if !reflect.DeepEqual(usergroup.UsergroupIds, []int{4}) {
+		t.Errorf("ReadUsergroup returned usergroup IDs [%v], expected [[4]]", usergroup.UsergroupIds)
+	}
+
+	expected := ForemanExternalUsergroup{AuthSourceId: 7}
+	expected.Id = 9
+	expected.Name = "ops"
+	if !reflect.DeepEqual(usergroup.ExternalUsergroups, []ForemanExternalUsergroup{expected}) {
+		t.Errorf("ReadUsergroup returned external usergroups [%+v], expected [%+v]", usergroup.ExternalUsergroups, expected)
+	}
+}
+
+// Ensures the members of a usergroup are only replaced if they are set
+func TestForemanUsergroupMarshalJSON_Members(t *testing.T) {
+	usergroup := ForemanUsergroup{UsergroupIds: []int{4}}
+	usergroup.Name = "operators"
+
+	b, err := json.Marshal(usergroup)
+	if err != nil {
+		t.Fatalf("ForemanUsergroup MarshalJSON returned an error: [%s]", err)
+	}
+
+	var m map[string]interface{}
+	json.Unmarshal(b, &m)
+	if _, ok := m["user_ids"]; ok {
+		t.Errorf("ForemanUsergroup MarshalJSON sent user_ids which were not set: [%s]", b)
+	}
+	if !reflect.DeepEqual(m["usergroup_ids"], []interface{}{float64(4)}) {
+		t.Errorf("ForemanUsergroup MarshalJSON sent usergroup_ids [%v], expected [[4]]", m["usergroup_ids"])
+	}
+	if _, ok := m["external_usergroups"]; ok {
+		t.Errorf("ForemanUsergroup MarshalJSON sent external_usergroups: [%s]", b)
+	}
+}
+
+// Ensures an external group is mapped through the nested endpoint of the
+// usergroup
+func TestCreateExternalUsergroup(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/usergroups/3/external_usergroups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("external usergroup was created with method [%s], expected [POST]", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"external_usergroup":{"auth_source_id":7,"name":"ops"}}`
+		if string(body) != expected {
+			t.Errorf("external usergroup was created with [%s], expected [%s]", body, expected)
+		}
+		fmt.Fprint(w, `{"id":9,"name":"ops","auth_source":{"id":7,"name":"Corporate LDAP"}}`)
+	})
+
+	eu := ForemanExternalUsergroup{AuthSourceId: 7}
+	eu.Name = "ops"
+
+	created, err := client.CreateExternalUsergroup(context.Background(), 3, &eu)
+	if err != nil {
+		t.Fatalf("CreateExternalUsergroup returned an error: [%s]", err)
+	}
+	if created.Id != 9 || created.AuthSourceId != 7 {
+		t.Errorf("CreateExternalUsergroup returned [%+v], expected ID [9] and auth source [7]", created)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	FilterEndpointPrefix = "filters"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanFilter API model represents a filter of a role. A filter grants
+// permissions on one resource type, optionally limited to the objects
+// matching its search.
+type ForemanFilter struct {
+	// Inherits the base object's attributes. Filters do not have a name.
+	ForemanObject
+	// Organizations and locations the filter is limited to. Only used if
+	// Override is set, otherwise the filter inherits them from its role.
+	ForemanTaxonomyIds
+
+	// ID of the role the filter belongs to
+	RoleId int `json:"role_id"`
+	// Search query limiting the objects the permissions apply to, e.g.
+	// "hostgroup = web". An empty search applies to all objects.
+	Search string `json:"search"`
+	// Whether the filter uses organizations and locations of its own instead
+	// of the ones of its role
+	Override bool `json:"override"`
+	// Whether the filter applies to all objects, set by Foreman if the
+	// filter has no search
+	Unlimited bool `json:"unlimited"`
+	// IDs of the permissions granted by the filter
+	PermissionIds []int `json:"permission_ids"`
+	// Resource type of the permissions, e.g. "Host". Set by Foreman from the
+	// permissions of the filter.
+	ResourceType string `json:"resource_type"`
+}
+
+// foremanFilterDecode struct used for JSON decode.
+type foremanFilterDecode struct {
+	ForemanFilter
+	RoleDecode        EntityResponse      `json:"role"`
+	PermissionsDecode []ForemanPermission `json:"permissions"`
+}
+
+// Implement the Marshaler interface
+func (ff ForemanFilter) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/filter.go#MarshalJSON")
+
+	// NOTE(ALL): only marshal the attributes which can be set, the resource
+	//   type and unlimited flag are derived by Foreman
+
+	ffMap := map[string]interface{}{}
+
+	ffMap["role_id"] = ff.RoleId
+	ffMap["search"] = ff.Search
+	ffMap["override"] = ff.Override
+	ffMap["permission_ids"] = ff.PermissionIds
+
+	// Foreman ignores the organizations and locations unless the filter
+	// overrides the ones of its role
+	if ff.Override {
+		ffMap["location_ids"] = ff.LocationIds
+		ffMap["organization_ids"] = ff.OrganizationIds
+	}
+
+	log.Debugf("ffMap: [%v]", ffMap)
+
+	return json.Marshal(ffMap)
+}
+
+// sendAndParseFilter sends the request and decodes the filter response into
+// a ForemanFilter
+func (c *Client) sendAndParseFilter(req *http.Request) (*ForemanFilter, error) {
+	var decodedFilter foremanFilterDecode
+	sendErr := c.SendAndParse(req, &decodedFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	decodedFilter.RoleId = decodedFilter.RoleDecode.ID
+	decodedFilter.PermissionIds = make([]int, 0, len(decodedFilter.PermissionsDecode))
+	for _, permission := range decodedFilter.PermissionsDecode {
+		decodedFilter.PermissionIds = append(decodedFilter.PermissionIds, permission.Id)
+	}
+
+	return &decodedFilter.ForemanFilter, nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateFilter creates a new ForemanFilter with the attributes of the
+// supplied ForemanFilter reference and returns the created ForemanFilter
+// reference. The returned reference will have its ID and other API default
+// values set by this function.
+func (c *Client) CreateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", FilterEndpointPrefix)
+
+	filterJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	createdFilter, sendErr := c.sendAndParseFilter(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdFilter: [%+v]", createdFilter)
+
+	return createdFilter, nil
+}
+
+// ReadFilter reads the attributes of a ForemanFilter identified by the
+// supplied ID and returns a ForemanFilter reference.
+func (c *Client) ReadFilter(ctx context.Context, id int) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	readFilter, sendErr := c.sendAndParseFilter(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readFilter: [%+v]", readFilter)
+
+	return readFilter, nil
+}
+
+// UpdateFilter updates a ForemanFilter's attributes. The filter with the ID
+// of the supplied ForemanFilter will be updated. A new ForemanFilter
+// reference is returned with the attributes from the result of the update
+// operation.
+func (c *Client) UpdateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, f.Id)
+
+	filterJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	updatedFilter, sendErr := c.sendAndParseFilter(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedFilter: [%+v]", updatedFilter)
+
+	return updatedFilter, nil
+}
+
+// DeleteFilter deletes the ForemanFilter identified by the supplied ID
+func (c *Client) DeleteFilter(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/filter.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// Ensures ReadFilter sets the role and permission IDs from the nested role
+// and permissions of the response
+func TestReadFilter_RoleAndPermissionIds(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/filters/212", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":212,"search":"hostgroup = web","resource_type":"Host",`+
+			`"unlimited":false,"override":false,"role":{"id":23,"name":"Host operators"},`+
+			`"permissions":[{"id":74,"name":"view_hosts","resource_type":"Host"},`+
+			`{"id":80,"name":"power_hosts","resource_type":"Host"}]}`)
+	})
+
+	filter, err := client.ReadFilter(context.Background(), 212)
+	if err != nil {
+		t.Fatalf("ReadFilter returned an error: [%s]", err)
+	}
+	if filter.RoleId != 23 {
+		t.Errorf("ReadFilter returned role ID [%d], expected [23]", filter.RoleId)
+	}
+	if !reflect.DeepEqual(filter.PermissionIds, []int{74, 80}) {
+		t.Errorf("ReadFilter returned permission IDs [%v], expected [[74 80]]", filter.PermissionIds)
+	}
+	if filter.ResourceType != "Host" || filter.Search != "hostgroup = web" {
+		t.Errorf("ReadFilter did not decode the filter attributes: [%+v]", filter)
+	}
+}
+
+// Ensures the organizations and locations of a filter are only sent if the
+// filter overrides the ones of its role
+func TestForemanFilterMarshalJSON_Override(t *testing.T) {
+	filter := ForemanFilter{
+		RoleId:        23,
+		PermissionIds: []int{74},
+		ForemanTaxonomyIds: ForemanTaxonomyIds{
+			LocationIds:     []int{2},
+			OrganizationIds: []int{4},
+		},
+	}
+
+	for _, override := range []bool{false, true} {
+		filter.Override = override
+
+		filterBytes, err := json.Marshal(filter)
+		if err != nil {
+			t.Fatalf("ForemanFilter MarshalJSON returned an error: [%s]", err)
+		}
+
+		var filterMap map[string]interface{}
+		if err := json.Unmarshal(filterBytes, &filterMap); err != nil {
+			t.Fatalf("could not decode the marshalled filter: [%s]", err)
+		}
+
+		_, hasLocations := filterMap["location_ids"]
+		_, hasOrganizations := filterMap["organization_ids"]
+		if hasLocations != override || hasOrganizations != override {
+			t.Errorf(
+				"ForemanFilter MarshalJSON with override [%t] sent location_ids [%t] "+
+					"and organization_ids [%t]",
+				override,
+				hasLocations,
+				hasOrganizations,
+			)
+		}
+		if _, hasResourceType := filterMap["resource_type"]; hasResourceType {
+			t.Errorf("ForemanFilter MarshalJSON sent the computed resource_type")
+		}
+	}
+}
@@ -2,31 +2,45 @@ package api
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
-	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	ForemanTaskEndpointPrefix = "/foreman_tasks/api/tasks"
+	ForemanTaskById           = ForemanTaskEndpointPrefix + "/%s" // :id
+
+	// Default values for polling asynchronous tasks, used when the client
+	// configuration does not define them.
+	DefaultTaskPollInterval      = 1 * time.Second
+	DefaultTaskPollMaxInterval   = 30 * time.Second
+	DefaultTaskPollBackoffFactor = 2.0
 )
 
 // ForemanTask is either the task from /foreman_tasks/.../<uuid> or a response
 // from a Katello endpoint, which uses the async_task (in Foreman source code) function.
 // The most important fields are covered, but there are more.
 type ForemanTask struct {
-	Id        string      `json:"id"`
-	Label     string      `json:"label"`
-	Pending   bool        `json:"pending"`
-	Action    string      `json:"action"`
-	Username  string      `json:"username"`
-	StartedAt string      `json:"started_at"`
-	EndedAt   string      `json:"ended_at"`
-	Duration  string      `json:"duration"`
-	State     string      `json:"state"`
-	Result    string      `json:"result"`
-	Progress  float64     `json:"progress"`
-	Input     interface{} `json:"input"`
-	Output    interface{} `json:"output"`
-	Humanized struct {
+	Id           string      `json:"id"`
+	ParentTaskId string      `json:"parent_task_id"`
+	Label        string      `json:"label"`
+	Pending      bool        `json:"pending"`
+	Action       string      `json:"action"`
+	Username     string      `json:"username"`
+	StartedAt    string      `json:"started_at"`
+	EndedAt      string      `json:"ended_at"`
+	Duration     string      `json:"duration"`
+	State        string      `json:"state"`
+	Result       string      `json:"result"`
+	Progress     float64     `json:"progress"`
+	Input        interface{} `json:"input"`
+	Output       interface{} `json:"output"`
+	Humanized    struct {
 		Action string      `json:"action"`
 		Input  interface{} `json:"input"`
 		Output interface{} `json:"output"`
@@ -40,36 +54,194 @@ type ForemanTask struct {
 	} `json:"available_actions"`
 }
 
-// waitForKatelloAsyncTask provides a method to wait for a Katello asynchronous task to finish.
-func (c *Client) waitForKatelloAsyncTask(taskID string) (*ForemanTask, error) {
-	log.Tracef("waitForKatelloAsyncTask")
+// isFailed reports whether a finished task ended without success. Foreman
+// marks tasks with the results "success", "warning", "error" and "cancelled".
+func (task *ForemanTask) isFailed() bool {
+	return task.Result == "error" || task.Result == "cancelled"
+}
+
+// isFinished reports whether the task will not make any more progress on its
+// own.  A failed Dynflow task is "paused" until it is resumed or cancelled,
+// and foreman-tasks still reports it as pending, as only "stopped" tasks are
+// not pending.
+func (task *ForemanTask) isFinished() bool {
+	return !task.Pending || task.State == "paused" || task.isFailed()
+}
+
+// ForemanTaskError is returned when an asynchronous task finished, but did
+// not succeed. It carries the humanized errors and the result of the task.
+type ForemanTaskError struct {
+	TaskId string
+	Label  string
+	State  string
+	Result string
+	Errors []string
+}
+
+func (e ForemanTaskError) Error() string {
+	return fmt.Sprintf(
+		"task %s (%s) finished with state [%s] and result [%s]: %s",
+		e.TaskId,
+		e.Label,
+		e.State,
+		e.Result,
+		strings.Join(e.Errors, "; "),
+	)
+}
+
+// ReadForemanTask reads the task identified by the supplied UUID
+func (c *Client) ReadForemanTask(ctx context.Context, taskID string) (*ForemanTask, error) {
+	log.Tracef("foreman/api/foreman_task.go#Read")
 
-	ctx := context.TODO()
-	const endpoint = "/foreman_tasks/api/tasks/%s"
-	req, err := c.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(endpoint, taskID), nil)
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(ForemanTaskById, taskID), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// This works as a retry counter, currently set to 5 tries, e.g. 4 retries
-	for counter := 0; counter < 5; counter++ {
-		log.Tracef("waitForKatelloAsyncTask retry loop with counter %d", counter)
+	var task ForemanTask
+	if err := c.SendAndParse(req, &task); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("task: %+v", task)
+
+	return &task, nil
+}
+
+// QueryForemanTask queries for tasks matching the supplied scoped search
+// string. The results are ordered by their start time, newest first. With
+// mostRecent, only the most recently started task is requested instead of all
+// pages of matching tasks.
+func (c *Client) QueryForemanTask(ctx context.Context, search string, mostRecent bool) (QueryResponse, error) {
+	log.Tracef("foreman/api/foreman_task.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, ForemanTaskEndpointPrefix, nil)
+	if err != nil {
+		return queryResponse, err
+	}
+
+	reqQuery := req.URL.Query()
+	if search != "" {
+		reqQuery.Set("search", search)
+	}
+	reqQuery.Set("order", "started_at DESC")
+
+	if mostRecent {
+		reqQuery.Set("page", "1")
+		reqQuery.Set("per_page", "1")
+		req.URL.RawQuery = reqQuery.Encode()
+		if err := c.SendAndParse(req, &queryResponse); err != nil {
+			return queryResponse, err
+		}
+	} else {
+		req.URL.RawQuery = reqQuery.Encode()
+		if err := c.SendAndParseQuery(req, &queryResponse); err != nil {
+			return queryResponse, err
+		}
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	results := []ForemanTask{}
+	resultsBytes, err := json.Marshal(queryResponse.Results)
+	if err != nil {
+		return queryResponse, err
+	}
+
+	if err := json.Unmarshal(resultsBytes, &results); err != nil {
+		return queryResponse, err
+	}
+
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
+// pollBackoff returns the initial and maximum interval between two polls and
+// the factor the interval grows by after every poll, from the client
+// configuration or the defaults
+func (c *Client) pollBackoff() (time.Duration, time.Duration, float64) {
+	interval := c.clientConfig.TaskPollInterval
+	if interval <= 0 {
+		interval = DefaultTaskPollInterval
+	}
+	maxInterval := c.clientConfig.TaskPollMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultTaskPollMaxInterval
+	}
+	factor := c.clientConfig.TaskPollBackoffFactor
+	if factor < 1 {
+		factor = DefaultTaskPollBackoffFactor
+	}
+	return interval, maxInterval, factor
+}
+
+// waitForPoll waits for the supplied interval before the next poll and
+// returns the interval of the poll after it.  An error is returned if the
+// context ends before.
+func (c *Client) waitForPoll(ctx context.Context, interval time.Duration) (time.Duration, error) {
+	_, maxInterval, factor := c.pollBackoff()
+
+	if err := SleepContext(ctx, interval); err != nil {
+		return interval, err
+	}
+
+	interval = time.Duration(float64(interval) * factor)
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval, nil
+}
+
+// WaitForForemanTask polls the task identified by the supplied UUID until it
+// is no longer pending or paused. The time between two polls grows exponentially
+// according to the client configuration. Waiting stops as soon as the context
+// is cancelled or its deadline (i.e. the resource timeout) is exceeded.
+//
+// If the task finished with an error, was cancelled or is paused due to an
+// error, a ForemanTaskError is returned along with the task.
+func (c *Client) WaitForForemanTask(ctx context.Context, taskID string) (*ForemanTask, error) {
+	log.Tracef("foreman/api/foreman_task.go#Wait")
+
+	interval, _, _ := c.pollBackoff()
 
-		var task ForemanTask
-		err = c.SendAndParse(req, &task)
+	for {
+		task, err := c.ReadForemanTask(ctx, taskID)
 		if err != nil {
 			return nil, err
 		}
 
-		log.Debugf("task: %+v", task)
-		if !task.Pending {
-			return &task, nil
+		if task.isFinished() {
+			if task.isFailed() || task.State == "paused" {
+				return task, ForemanTaskError{
+					TaskId: task.Id,
+					Label:  task.Label,
+					State:  task.State,
+					Result: task.Result,
+					Errors: task.Humanized.Errors,
+				}
+			}
+			log.Infof("Task %s (%s) finished with result [%s]", task.Id, task.Label, task.Result)
+			return task, nil
 		}
 
-		log.Infof("Task %s is still pending, sleeping for %d seconds and then retrying…", task.Id, counter)
-		time.Sleep(time.Duration(counter) * time.Second)
-	}
+		log.Infof(
+			"Task %s (%s) is %s at %.0f%%, polling again in %s",
+			task.Id,
+			task.Label,
+			task.State,
+			task.Progress*100,
+			interval,
+		)
 
-	// The retries should produce a success. If not, fail with error
-	return nil, errors.New("Error in retrying to wait for task " + taskID)
+		if interval, err = c.waitForPoll(ctx, interval); err != nil {
+			return task, fmt.Errorf("stopped waiting for task %s (%s): %w", task.Id, task.Label, err)
+		}
+	}
 }
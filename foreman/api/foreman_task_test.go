@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Client configuration polling tasks as fast as possible
+var fastTaskPollConfig = ClientConfig{
+	TaskPollInterval:      time.Millisecond,
+	TaskPollMaxInterval:   5 * time.Millisecond,
+	TaskPollBackoffFactor: 2,
+}
+
+// Ensures WaitForForemanTask keeps polling a pending task and returns the
+// finished task once it is no longer pending.
+func TestWaitForForemanTask_PollsUntilFinished(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	polls := 0
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"id":"abc","pending":true,"state":"running","progress":0.5}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"abc","pending":false,"state":"stopped","result":"success","progress":1.0}`)
+	})
+
+	task, err := client.WaitForForemanTask(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("WaitForForemanTask returned an error: [%s]", err)
+	}
+	if polls != 3 {
+		t.Fatalf("WaitForForemanTask polled [%d] times, expected [3]", polls)
+	}
+	if task.Result != "success" {
+		t.Fatalf("WaitForForemanTask returned result [%s], expected [success]", task.Result)
+	}
+}
+
+// Ensures WaitForForemanTask returns a ForemanTaskError carrying the
+// humanized errors when the task failed.
+func TestWaitForForemanTask_FailedTask(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	polls := 0
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		fmt.Fprint(w, `{"id":"abc","label":"Actions::Katello::ContentView::Publish",`+
+			`"pending":true,"state":"paused","result":"error",`+
+			`"humanized":{"errors":["Pulp task error"]}}`)
+	})
+
+	// A paused task stays pending until it is resumed, the deadline ensures
+	// the test fails instead of waiting forever
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.WaitForForemanTask(ctx, "abc")
+	if polls != 1 {
+		t.Errorf("WaitForForemanTask polled the paused task [%d] times, expected [1]", polls)
+	}
+
+	var taskErr ForemanTaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("WaitForForemanTask returned [%v], expected a ForemanTaskError", err)
+	}
+	if taskErr.Result != "error" || len(taskErr.Errors) != 1 || taskErr.Errors[0] != "Pulp task error" {
+		t.Fatalf("ForemanTaskError does not carry the task details: [%+v]", taskErr)
+	}
+}
+
+// Ensures WaitForForemanTask stops polling once the context deadline is
+// exceeded.
+func TestWaitForForemanTask_ContextDeadline(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"abc","pending":true,"state":"running","progress":0.1}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForForemanTask(ctx, "abc")
+	if err == nil {
+		t.Fatalf("WaitForForemanTask did not return an error after the deadline was exceeded")
+	}
+}
+
+// Ensures querying the most recent task only requests a single task instead
+// of walking all pages of matching tasks
+func TestQueryForemanTask_MostRecent(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	requests := 0
+	mux.HandleFunc("/foreman_tasks/api/tasks", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if query.Get("per_page") != "1" || query.Get("page") != "1" || query.Get("order") != "started_at DESC" {
+			t.Errorf("Most recent task was queried with [%s]", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"total":5000,"subtotal":2000,"page":1,"per_page":1,`+
+			`"results":[{"id":"abc","label":"Actions::Katello::Repository::Sync"}]}`)
+	})
+
+	queryResponse, err := client.QueryForemanTask(context.Background(), `label = "Actions::Katello::Repository::Sync"`, true)
+	if err != nil {
+		t.Fatalf("QueryForemanTask returned an error: [%s]", err)
+	}
+	if requests != 1 {
+		t.Errorf("QueryForemanTask sent [%d] requests, expected [1]", requests)
+	}
+	if len(queryResponse.Results) != 1 || queryResponse.Results[0].(ForemanTask).Id != "abc" {
+		t.Errorf("QueryForemanTask returned [%+v], expected the task abc", queryResponse.Results)
+	}
+}
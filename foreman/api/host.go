@@ -18,6 +18,8 @@ const (
 	PowerSuffix = "power"
 	// ComputeAttributesSuffix : Suffix appended to API url for getting the VM attributes
 	ComputeAttributesSuffix = "vm_compute_attributes"
+	// FactsSuffix : Suffix appended to API url for getting the facts of a host
+	FactsSuffix = "facts"
 	// PowerOn : Power on operation
 	PowerOn = "on"
 	// PowerOff : Power off operation
@@ -67,6 +69,12 @@ type ForemanHost struct {
 	BuildStatusLabel string `json:"build_status_label"`
 	// Describes the way this host will be provisioned by Foreman
 	ProvisionMethod string `json:"provision_method,omitempty"`
+	// ID of the location of the host. Hosts are part of exactly one
+	// location, replaces the provider's default location if set.
+	LocationId *int `json:"location_id,omitempty"`
+	// ID of the organization of the host. Hosts are part of exactly one
+	// organization, replaces the provider's default organization if set.
+	OrganizationId *int `json:"organization_id,omitempty"`
 	// ID of the domain to assign the host
 	DomainId *int `json:"domain_id,omitempty"`
 	// Name of the Domain. To substract from the Machine name
@@ -123,10 +131,32 @@ type ForemanHost struct {
 	PuppetAttributes PuppetAttribute `json:"puppet_attributes"`
 	// Default Root Password for this host (on creation)
 	RootPassword string `json:"root_pass,omitempty"`
+	// IP and MAC address of the primary interface. Only set when the host is
+	// read, the interfaces are managed through InterfacesAttributes.
+	IP  string `json:"-"`
+	MAC string `json:"-"`
+	// Overall status of the host and its label, e.g. "OK". From Foreman:
+	// OK = 0, WARN = 1, ERROR = 2. Only set when the host is read.
+	GlobalStatus      int    `json:"-"`
+	GlobalStatusLabel string `json:"-"`
+	// Parameters of the host including the ones inherited from its hostgroup,
+	// operating system, domain, subnet, location, organization and the global
+	// parameters. Only set when the host is read.
+	AllParameters []ForemanKVParameter `json:"-"`
 }
 
 func (fh *ForemanHost) isBuilt() bool {
-	return fh.BuildStatus == 0
+	return fh.BuildStatus == HostBuildStatusBuilt
+}
+
+// overridesLocation reports whether the host sets its own location
+func (fh ForemanHost) overridesLocation() bool {
+	return fh.LocationId != nil
+}
+
+// overridesOrganization reports whether the host sets its own organization
+func (fh ForemanHost) overridesOrganization() bool {
+	return fh.OrganizationId != nil
 }
 
 // ForemanInterfacesAttribute representing a hosts defined network interfaces
@@ -172,6 +202,30 @@ type foremanHostDecode struct {
 	PuppetClassesDecode        []ForemanObject              `json:"puppetclasses"`
 	ConfigGroupsDecode         []ForemanObject              `json:"config_groups"`
 	HostParametersDecode       []ForemanKVParameter         `json:"parameters"`
+	IPDecode                   string                       `json:"ip"`
+	MACDecode                  string                       `json:"mac"`
+	GlobalStatusDecode         int                          `json:"global_status"`
+	GlobalStatusLabelDecode    string                       `json:"global_status_label"`
+	AllParametersDecode        []ForemanKVParameter         `json:"all_parameters"`
+}
+
+// toForemanHost converts the decoded host into a ForemanHost reference
+func (fh *foremanHostDecode) toForemanHost() (*ForemanHost, error) {
+	if err := constructShortname(fh); err != nil {
+		return nil, err
+	}
+
+	fh.InterfacesAttributes = fh.InterfacesAttributesDecode
+	fh.PuppetClassIds = foremanObjectArrayToIdIntArray(fh.PuppetClassesDecode)
+	fh.ConfigGroupIds = foremanObjectArrayToIdIntArray(fh.ConfigGroupsDecode)
+	fh.HostParameters = fh.HostParametersDecode
+	fh.IP = fh.IPDecode
+	fh.MAC = fh.MACDecode
+	fh.GlobalStatus = fh.GlobalStatusDecode
+	fh.GlobalStatusLabel = fh.GlobalStatusLabelDecode
+	fh.AllParameters = fh.AllParametersDecode
+
+	return &fh.ForemanHost, nil
 }
 
 // Power struct for marshal/unmarshal of power state
@@ -257,6 +311,42 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 	return nil
 }
 
+// ReadHostPowerState reads the power state of the host identified by the
+// supplied ID with the "state" power action, usually PowerOn or PowerOff.
+// Hosts without a BMC or compute resource have no power state and Foreman
+// returns an error for them.
+//
+// Example: https://<foreman>/api/hosts/<id>/power
+func (c *Client) ReadHostPowerState(ctx context.Context, id int) (string, error) {
+	log.Tracef("foreman/api/host.go#ReadHostPowerState")
+
+	reqHost := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, PowerSuffix)
+
+	JSONBytes, jsonEncErr := json.Marshal(Power{PowerAction: PowerState})
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+
+	req, reqErr := c.NewRequestWithContext(ctx, http.MethodPut, reqHost, bytes.NewBuffer(JSONBytes))
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	// The state is returned as string instead of the boolean result of the
+	// other power actions
+	var powerResponse struct {
+		Power string `json:"power"`
+	}
+	sendErr := c.SendAndParse(req, &powerResponse)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	log.Debugf("Power state of host %d: [%s]", id, powerResponse.Power)
+
+	return powerResponse.Power, nil
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -307,15 +397,6 @@ func (c *Client) CreateHost(ctx context.Context, h *ForemanHost, retryCount int)
 		return nil, sendErr
 	}
 
-	if err := constructShortname(&createdHost); err != nil {
-		return nil, err
-	}
-
-	createdHost.InterfacesAttributes = createdHost.InterfacesAttributesDecode
-	createdHost.PuppetClassIds = foremanObjectArrayToIdIntArray(createdHost.PuppetClassesDecode)
-	createdHost.ConfigGroupIds = foremanObjectArrayToIdIntArray(createdHost.ConfigGroupsDecode)
-	createdHost.HostParameters = createdHost.HostParametersDecode
-
 	computeAttributes, _ := c.readComputeAttributes(ctx, createdHost.Id)
 	if len(computeAttributes) > 0 {
 		createdHost.ComputeAttributes = computeAttributes
@@ -323,7 +404,7 @@ func (c *Client) CreateHost(ctx context.Context, h *ForemanHost, retryCount int)
 
 	log.Debugf("createdHost: [%+v]", createdHost)
 
-	return &createdHost.ForemanHost, nil
+	return createdHost.toForemanHost()
 }
 
 // ReadHost reads the attributes of a ForemanHost identified by the supplied ID
@@ -349,20 +430,12 @@ func (c *Client) ReadHost(ctx context.Context, id int) (*ForemanHost, error) {
 		return nil, sendErr
 	}
 
-	if err := constructShortname(&readHost); err != nil {
-		return nil, err
-	}
-
 	computeAttributes, _ := c.readComputeAttributes(ctx, id)
 	if len(computeAttributes) > 0 {
 		readHost.ComputeAttributes = computeAttributes
 	}
-	readHost.InterfacesAttributes = readHost.InterfacesAttributesDecode
-	readHost.PuppetClassIds = foremanObjectArrayToIdIntArray(readHost.PuppetClassesDecode)
-	readHost.ConfigGroupIds = foremanObjectArrayToIdIntArray(readHost.ConfigGroupsDecode)
-	readHost.HostParameters = readHost.HostParametersDecode
 
-	return &readHost.ForemanHost, nil
+	return readHost.toForemanHost()
 }
 
 // UpdateHost updates a ForemanHost's attributes.  The host with the ID of the
@@ -409,21 +482,13 @@ func (c *Client) UpdateHost(ctx context.Context, h *ForemanHost, retryCount int)
 		return nil, sendErr
 	}
 
-	if err := constructShortname(&updatedHost); err != nil {
-		return nil, err
-	}
-
 	computeAttributes, _ := c.readComputeAttributes(ctx, h.Id)
 	if len(computeAttributes) > 0 {
 		updatedHost.ComputeAttributes = computeAttributes
 	}
-	updatedHost.InterfacesAttributes = updatedHost.InterfacesAttributesDecode
-	updatedHost.PuppetClassIds = foremanObjectArrayToIdIntArray(updatedHost.PuppetClassesDecode)
-	updatedHost.ConfigGroupIds = foremanObjectArrayToIdIntArray(updatedHost.ConfigGroupsDecode)
-	updatedHost.HostParameters = updatedHost.HostParametersDecode
 	log.Debugf("updatedHost: [%+v]", updatedHost)
 
-	return &updatedHost.ForemanHost, nil
+	return updatedHost.toForemanHost()
 }
 
 // DeleteHost deletes the ForemanHost identified by the supplied ID
@@ -445,6 +510,71 @@ func (c *Client) DeleteHost(ctx context.Context, id int) error {
 	return c.SendAndParse(req, nil)
 }
 
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryHost queries for a ForemanHost based on the attributes of the supplied
+// ForemanHost reference and returns a QueryResponse struct containing
+// query/response metadata and the matching hosts. The hosts of the results
+// do not contain their interfaces, parameters and compute attributes.
+func (c *Client) QueryHost(ctx context.Context, h *ForemanHost, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/host.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + h.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []foremanHostDecode for
+	// the results
+	results := []foremanHostDecode{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []foremanHostDecode to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx := range results {
+		host, convErr := results[idx].toForemanHost()
+		if convErr != nil {
+			return queryResponse, convErr
+		}
+		iArr[idx] = *host
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
 // Compute Attributes are only available via dedicated API endpoint. readComputeAttributes gets this endpoint.
 func (c *Client) readComputeAttributes(ctx context.Context, id int) (map[string]interface{}, error) {
 	log.Tracef("foreman/api/host.go#readComputeAttributes")
@@ -475,6 +605,62 @@ func (c *Client) readComputeAttributes(ctx context.Context, id int) (map[string]
 	return readVmAttributesStr, nil
 }
 
+// ReadHostFacts reads the facts of the host with the supplied ID, e.g. the
+// ones reported by Puppet, Ansible or subscription-manager.  Foreman flattens
+// nested facts into names like "networking::ip".  The facts are paginated by
+// Foreman like query results, all pages are read.
+func (c *Client) ReadHostFacts(ctx context.Context, id int) (map[string]string, error) {
+	log.Tracef("foreman/api/host.go#ReadHostFacts")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, FactsSuffix)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	// The results are a map of the host's name to its facts instead of an
+	// array
+	facts := map[string]string{}
+	sendErr := c.walkQueryPages(req, QueryOptions{}, func(pageReq *http.Request) (queryPage, error) {
+		var pageResponse struct {
+			QueryResponse
+			Results map[string]map[string]interface{} `json:"results"`
+		}
+		if err := c.SendAndParse(pageReq, &pageResponse); err != nil {
+			return queryPage{}, err
+		}
+		count := 0
+		for _, hostFacts := range pageResponse.Results {
+			for name, value := range hostFacts {
+				if value == nil {
+					facts[name] = ""
+				} else {
+					facts[name] = fmt.Sprint(value)
+				}
+				count++
+			}
+		}
+		return queryPage{
+			Results:  count,
+			Subtotal: pageResponse.Subtotal,
+			PerPage:  pageResponse.PerPage,
+		}, nil
+	})
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("facts: [%+v]", facts)
+
+	return facts, nil
+}
+
 func constructShortname(host *foremanHostDecode) error {
 	log.Tracef("foreman/api/host.go#constructShortname")
 
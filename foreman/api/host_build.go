@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// Build status of a host. From Foreman:
+	// BUILT = 0, PENDING = 1, TOKEN_EXPIRED = 2, BUILD_FAILED = 3
+	HostBuildStatusBuilt        = 0
+	HostBuildStatusPending      = 1
+	HostBuildStatusTokenExpired = 2
+	HostBuildStatusBuildFailed  = 3
+
+	// ConfigReportsSuffix : Suffix appended to API url for the config
+	// reports of a host
+	ConfigReportsSuffix = "config_reports"
+	// RebuildConfigSuffix : Suffix appended to API url to rebuild the
+	// orchestration configuration of a host
+	RebuildConfigSuffix = "rebuild_config"
+)
+
+// HostBuildError is returned when the build of a host failed or its build
+// token expired before the build finished
+type HostBuildError struct {
+	HostId           int
+	Name             string
+	BuildStatus      int
+	BuildStatusLabel string
+}
+
+func (e HostBuildError) Error() string {
+	return fmt.Sprintf(
+		"build of host %s (%d) did not finish, build status is %d [%s]",
+		e.Name,
+		e.HostId,
+		e.BuildStatus,
+		e.BuildStatusLabel,
+	)
+}
+
+// ForemanConfigReport is a report of a configuration management run on a
+// host, e.g. by Puppet or Ansible
+type ForemanConfigReport struct {
+	Id         int    `json:"id"`
+	HostId     int    `json:"host_id"`
+	ReportedAt string `json:"reported_at"`
+	Status     struct {
+		Applied        int `json:"applied"`
+		Restarted      int `json:"restarted"`
+		Failed         int `json:"failed"`
+		FailedRestarts int `json:"failed_restarts"`
+		Skipped        int `json:"skipped"`
+		Pending        int `json:"pending"`
+	} `json:"status"`
+}
+
+// isFailed reports whether a resource failed to apply or restart during the
+// run of the report
+func (r *ForemanConfigReport) isFailed() bool {
+	return r.Status.Failed > 0 || r.Status.FailedRestarts > 0
+}
+
+// WaitForHostBuild polls the host identified by the supplied ID until its
+// build is finished.  The time between two polls grows like the one of
+// WaitForForemanTask.  Waiting stops as soon as the context is cancelled or
+// its deadline (i.e. the resource timeout) is exceeded.
+//
+// If the build failed or the build token expired, a HostBuildError is
+// returned along with the host.
+func (c *Client) WaitForHostBuild(ctx context.Context, id int) (*ForemanHost, error) {
+	log.Tracef("foreman/api/host_build.go#WaitForHostBuild")
+
+	interval, _, _ := c.pollBackoff()
+
+	for {
+		host, err := c.ReadHost(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if host.isBuilt() {
+			log.Infof("Host %s (%d) is built", host.Name, host.Id)
+			return host, nil
+		}
+		if host.BuildStatus == HostBuildStatusTokenExpired || host.BuildStatus == HostBuildStatusBuildFailed {
+			return host, HostBuildError{
+				HostId:           host.Id,
+				Name:             host.Name,
+				BuildStatus:      host.BuildStatus,
+				BuildStatusLabel: host.BuildStatusLabel,
+			}
+		}
+
+		log.Infof(
+			"Host %s (%d) is [%s], polling again in %s",
+			host.Name,
+			host.Id,
+			host.BuildStatusLabel,
+			interval,
+		)
+
+		if interval, err = c.waitForPoll(ctx, interval); err != nil {
+			return host, fmt.Errorf("stopped waiting for the build of host %s (%d): %w", host.Name, host.Id, err)
+		}
+	}
+}
+
+// ReadLastHostConfigReport reads the latest config report of the host
+// identified by the supplied ID.  If the host has not reported yet, nil is
+// returned without an error.
+func (c *Client) ReadLastHostConfigReport(ctx context.Context, id int) (*ForemanConfigReport, error) {
+	log.Tracef("foreman/api/host_build.go#ReadLastHostConfigReport")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/last", HostEndpointPrefix, id, ConfigReportsSuffix)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var report ForemanConfigReport
+	sendErr := c.SendAndParse(req, &report)
+	if httpErr, ok := sendErr.(HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("report: [%+v]", report)
+
+	return &report, nil
+}
+
+// WaitForHostConfigReport polls the config reports of the host identified by
+// the supplied ID until a successful report newer than the report with the
+// ID afterReportId arrives.  Failed reports are logged and waiting goes on,
+// as configuration management often needs several runs to converge.
+// Waiting stops as soon as the context is cancelled or its deadline is
+// exceeded.
+func (c *Client) WaitForHostConfigReport(ctx context.Context, id int, afterReportId int) (*ForemanConfigReport, error) {
+	log.Tracef("foreman/api/host_build.go#WaitForHostConfigReport")
+
+	interval, _, _ := c.pollBackoff()
+	lastFailed := 0
+
+	for {
+		report, err := c.ReadLastHostConfigReport(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case report == nil || report.Id <= afterReportId:
+			log.Infof("Host %d has no new config report, polling again in %s", id, interval)
+		case report.isFailed():
+			if report.Id != lastFailed {
+				log.Warningf(
+					"Config report %d of host %d has %d failed resources and %d failed restarts",
+					report.Id,
+					id,
+					report.Status.Failed,
+					report.Status.FailedRestarts,
+				)
+				lastFailed = report.Id
+			}
+		default:
+			log.Infof("Host %d reported successfully in config report %d", id, report.Id)
+			return report, nil
+		}
+
+		if interval, err = c.waitForPoll(ctx, interval); err != nil {
+			if lastFailed != 0 {
+				return report, fmt.Errorf(
+					"stopped waiting for a successful config report of host %d, "+
+						"config report %d failed: %w",
+					id,
+					lastFailed,
+					err,
+				)
+			}
+			return report, fmt.Errorf("stopped waiting for a config report of host %d: %w", id, err)
+		}
+	}
+}
+
+// RebuildHostConfig rebuilds the orchestration configuration of the host
+// identified by the supplied ID, i.e. its DNS records, DHCP reservations and
+// TFTP boot files, like the "Rebuild Config" action of the Foreman UI.
+//
+// Example: https://<foreman>/api/hosts/<id>/rebuild_config
+func (c *Client) RebuildHostConfig(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/host_build.go#RebuildHostConfig")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, RebuildConfigSuffix)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	var response map[string]interface{}
+	if sendErr := c.SendAndParse(req, &response); sendErr != nil {
+		return fmt.Errorf("rebuilding the configuration of host %d failed: %w", id, sendErr)
+	}
+
+	log.Debugf("rebuild_config response: [%+v]", response)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensures the facts of all pages are read and converted to strings
+func TestReadHostFacts_Pagination(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{QueryPerPage: 2})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/7/facts", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"subtotal":3,"page":2,"per_page":2,"results":{"host01.example.com":{"is_virtual":true}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"subtotal":3,"page":1,"per_page":2,"results":{"host01.example.com":`+
+			`{"networking::ip":"10.0.0.7","processorcount":4}}}`)
+	})
+
+	facts, err := client.ReadHostFacts(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("ReadHostFacts returned an error: [%s]", err)
+	}
+	expected := map[string]string{
+		"networking::ip": "10.0.0.7",
+		"processorcount": "4",
+		"is_virtual":     "true",
+	}
+	if !reflect.DeepEqual(facts, expected) {
+		t.Errorf("ReadHostFacts returned [%v], expected [%v]", facts, expected)
+	}
+}
+
+// Ensures waiting for a host build polls until the host is built and fails
+// fast with the status label if the build fails or the token expires
+func TestWaitForHostBuild(t *testing.T) {
+	cases := []struct {
+		statuses []string
+		polls    int
+		failed   bool
+	}{
+		{[]string{`1,"build_status_label":"Pending installation"`, `0,"build_status_label":"Installed"`}, 2, false},
+		{[]string{`1,"build_status_label":"Pending installation"`, `3,"build_status_label":"Installation error"`}, 2, true},
+		{[]string{`2,"build_status_label":"Token expired"`}, 1, true},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{TaskPollInterval: time.Millisecond})
+		polls := 0
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/7", func(w http.ResponseWriter, r *http.Request) {
+			status := c.statuses[len(c.statuses)-1]
+			if polls < len(c.statuses) {
+				status = c.statuses[polls]
+			}
+			polls++
+			fmt.Fprintf(w, `{"id":7,"name":"host01.example.com","build_status":%s}`, status)
+		})
+
+		host, err := client.WaitForHostBuild(context.Background(), 7)
+		server.Close()
+
+		if polls != c.polls {
+			t.Errorf("WaitForHostBuild polled [%d] times, expected [%d]", polls, c.polls)
+		}
+		if !c.failed && err != nil {
+			t.Errorf("WaitForHostBuild returned an error: [%s]", err)
+			continue
+		}
+		if c.failed {
+			buildErr, ok := err.(HostBuildError)
+			if !ok || buildErr.BuildStatusLabel != host.BuildStatusLabel {
+				t.Errorf("WaitForHostBuild returned [%v], expected a HostBuildError with the status label", err)
+			}
+		}
+	}
+}
+
+// Ensures waiting for a host build stops once the context ends
+func TestWaitForHostBuild_Timeout(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{TaskPollInterval: time.Millisecond})
+	defer server.Close()
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":7,"name":"host01.example.com","build_status":1}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForHostBuild(ctx, 7); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForHostBuild returned [%v], expected the deadline to be exceeded", err)
+	}
+}
+
+// Ensures waiting for a config report ignores missing, old and failed reports
+func TestWaitForHostConfigReport(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{TaskPollInterval: time.Millisecond})
+	defer server.Close()
+
+	responses := []string{
+		"",
+		`{"id":3,"host_id":7,"status":{"applied":0,"failed":0}}`,
+		`{"id":4,"host_id":7,"status":{"applied":2,"failed":1}}`,
+		`{"id":5,"host_id":7,"status":{"applied":3,"failed":0,"failed_restarts":0}}`,
+	}
+	polls := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/7/config_reports/last", func(w http.ResponseWriter, r *http.Request) {
+		response := responses[polls]
+		polls++
+		if response == "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"message":"Resource config_report not found"}}`)
+			return
+		}
+		fmt.Fprint(w, response)
+	})
+
+	report, err := client.WaitForHostConfigReport(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("WaitForHostConfigReport returned an error: [%s]", err)
+	}
+	if report.Id != 5 || polls != len(responses) {
+		t.Errorf("WaitForHostConfigReport returned report [%d] after [%d] polls, expected [5] after [%d]", report.Id, polls, len(responses))
+	}
+}
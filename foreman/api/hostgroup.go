@@ -29,6 +29,8 @@ const (
 type ForemanHostgroup struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// The title is a computed property representing the fullname of the
 	// hostgroup.  A hostgroup's title is a path-like string from the head
@@ -233,7 +235,7 @@ func (c *Client) DeleteHostgroup(ctx context.Context, id int) error {
 // QueryHostgroup queries for a ForemanHostgroup based on the attributes of the
 // supplied ForemanHostgroup reference and returns a QueryResponse struct
 // containing query/response metadata and the matching hostgroups.
-func (c *Client) QueryHostgroup(ctx context.Context, h *ForemanHostgroup) (QueryResponse, error) {
+func (c *Client) QueryHostgroup(ctx context.Context, h *ForemanHostgroup, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/hostgroup.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -255,7 +257,7 @@ func (c *Client) QueryHostgroup(ctx context.Context, h *ForemanHostgroup) (Query
 	reqQuery.Set("search", "title="+title)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -23,6 +23,8 @@ const (
 type ForemanHTTPProxy struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
@@ -160,7 +162,7 @@ func (c *Client) DeleteHTTPProxy(ctx context.Context, id int) error {
 // QueryHTTPProxy queries for a ForemanHTTPProxy based on the attributes of
 // the supplied ForemanHTTPProxy reference and returns a QueryResponse struct
 // containing query/response metadata and the matching smart proxy.
-func (c *Client) QueryHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (QueryResponse, error) {
+func (c *Client) QueryHTTPProxy(ctx context.Context, s *ForemanHTTPProxy, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/HTTPProxy.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -182,7 +184,7 @@ func (c *Client) QueryHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (Query
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
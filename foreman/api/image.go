@@ -189,7 +189,7 @@ func (c *Client) DeleteImage(ctx context.Context, compute_resource, id int) erro
 // QueryImage queries for a ForemanImage based on the attributes of the
 // supplied ForemanImage reference and returns a QueryResponse struct
 // containing query/response metadata and the matching images.
-func (c *Client) QueryImage(ctx context.Context, d *ForemanImage) (QueryResponse, error) {
+func (c *Client) QueryImage(ctx context.Context, d *ForemanImage, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/image.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -211,7 +211,7 @@ func (c *Client) QueryImage(ctx context.Context, d *ForemanImage) (QueryResponse
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
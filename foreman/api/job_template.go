@@ -17,6 +17,8 @@ const (
 
 type ForemanJobTemplate struct {
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	Description       string                 `json:"description"`
 	DescriptionFormat string                 `json:"description_format"`
@@ -27,9 +29,6 @@ type ForemanJobTemplate struct {
 	Snippet           bool                   `json:"snippet"`
 	TemplateInputs    []ForemanTemplateInput `json:"template_inputs"`
 	EffectiveUser     interface{}            `json:"effective_user"`
-
-	Locations     []interface{} `json:"locations"`
-	Organizations []interface{} `json:"organizations"`
 }
 
 /// CRUD
@@ -83,7 +82,7 @@ func (c *Client) CreateJobTemplate(ctx context.Context, jtObj *ForemanJobTemplat
 	return &createdJT, nil
 }
 
-func (c *Client) QueryJobTemplate(ctx context.Context, jt *ForemanJobTemplate) (QueryResponse, error) {
+func (c *Client) QueryJobTemplate(ctx context.Context, jt *ForemanJobTemplate, opts ...QueryOptions) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
 	qresp := QueryResponse{}
@@ -101,7 +100,7 @@ func (c *Client) QueryJobTemplate(ctx context.Context, jt *ForemanJobTemplate) (
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &qresp)
+	err = c.SendAndParseQuery(req, &qresp, opts...)
 	if err != nil {
 		return qresp, err
 	}
@@ -0,0 +1,438 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// KatelloActivationKeyEndpointPrefix api endpoint prefix for katello activation keys
+	// 'katello/ will be removed, it's a marker to detect talking with katello api
+	KatelloActivationKeyEndpointPrefix  = "katello/activation_keys"
+	KatelloActivationKeyById            = KatelloActivationKeyEndpointPrefix + "/%d"                  // :id
+	KatelloActivationKeyContentOverride = KatelloActivationKeyEndpointPrefix + "/%d/content_override" // :id
+	KatelloActivationKeyHostCollections = KatelloActivationKeyEndpointPrefix + "/%d/host_collections" // :id
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanKatelloActivationKey API model representing an activation key. Activation
+// keys define the content view, lifecycle environment and subscriptions of hosts
+// registering with them.
+type ForemanKatelloActivationKey struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	Description    string `json:"description"`
+	OrganizationId int    `json:"organization_id"`
+
+	ContentViewId int `json:"content_view_id"`
+	EnvironmentId int `json:"environment_id"`
+
+	// Maximum number of hosts that may register with the key, only used if
+	// UnlimitedHosts is false
+	MaxHosts       int  `json:"max_hosts"`
+	UnlimitedHosts bool `json:"unlimited_hosts"`
+	// Number of hosts registered with the key
+	UsageCount int `json:"usage_count"`
+
+	ReleaseVersion string `json:"release_version"`
+	ServiceLevel   string `json:"service_level"`
+	AutoAttach     bool   `json:"auto_attach"`
+
+	// Only used for reading, the IDs are sent on their own
+	ContentView *ForemanKatelloActivationKeyReference `json:"content_view"`
+	Environment *ForemanKatelloActivationKeyReference `json:"environment"`
+
+	HostCollections  []ForemanKatelloActivationKeyReference `json:"host_collections"`
+	ContentOverrides []ForemanKatelloContentOverride        `json:"content_overrides"`
+}
+
+// ForemanKatelloActivationKeyReference references an object related to an activation key
+type ForemanKatelloActivationKeyReference struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ForemanKatelloContentOverride overrides whether a repository (identified by its
+// content label) is enabled for hosts registering with an activation key
+type ForemanKatelloContentOverride struct {
+	ContentLabel string `json:"content_label"`
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+}
+
+// UnmarshalJSON accepts both the snake case and the camel case (candlepin style)
+// notation of the content label
+func (co *ForemanKatelloContentOverride) UnmarshalJSON(b []byte) error {
+	var m struct {
+		ContentLabel      string `json:"content_label"`
+		ContentLabelCamel string `json:"contentLabel"`
+		Name              string `json:"name"`
+		Value             string `json:"value"`
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	co.ContentLabel = m.ContentLabel
+	if co.ContentLabel == "" {
+		co.ContentLabel = m.ContentLabelCamel
+	}
+	co.Name = m.Name
+	co.Value = m.Value
+
+	return nil
+}
+
+// Enabled returns whether the override enables the repository
+func (co *ForemanKatelloContentOverride) Enabled() bool {
+	v, _ := strconv.ParseBool(co.Value)
+	return v
+}
+
+// HostCollectionIds returns the IDs of all host collections the key is a member of
+func (ak *ForemanKatelloActivationKey) HostCollectionIds() []int {
+	ids := make([]int, 0, len(ak.HostCollections))
+	for _, hc := range ak.HostCollections {
+		ids = append(ids, hc.Id)
+	}
+	return ids
+}
+
+// MarshalJSON only sends the attributes that can be set, host collections and
+// content overrides are managed through their own endpoints
+func (ak ForemanKatelloActivationKey) MarshalJSON() ([]byte, error) {
+	jsonMap := map[string]interface{}{
+		"id":              ak.Id,
+		"name":            ak.Name,
+		"description":     ak.Description,
+		"organization_id": ak.OrganizationId,
+		"unlimited_hosts": ak.UnlimitedHosts,
+		"release_version": ak.ReleaseVersion,
+		"service_level":   ak.ServiceLevel,
+		"auto_attach":     ak.AutoAttach,
+	}
+
+	if !ak.UnlimitedHosts {
+		jsonMap["max_hosts"] = ak.MaxHosts
+	}
+
+	// Katello requires both or none of content view and lifecycle environment
+	if ak.ContentViewId != 0 {
+		jsonMap["content_view_id"] = ak.ContentViewId
+	} else {
+		jsonMap["content_view_id"] = nil
+	}
+	if ak.EnvironmentId != 0 {
+		jsonMap["environment_id"] = ak.EnvironmentId
+	} else {
+		jsonMap["environment_id"] = nil
+	}
+
+	return json.Marshal(jsonMap)
+}
+
+// fillIdsFromReferences sets the content view and lifecycle environment IDs from
+// the nested objects, since not all Katello versions return the plain IDs
+func (ak *ForemanKatelloActivationKey) fillIdsFromReferences() {
+	if ak.ContentViewId == 0 && ak.ContentView != nil {
+		ak.ContentViewId = ak.ContentView.Id
+	}
+	if ak.EnvironmentId == 0 && ak.Environment != nil {
+		ak.EnvironmentId = ak.Environment.Id
+	}
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateKatelloActivationKey creates a new ForemanKatelloActivationKey with the attributes
+// of the supplied ForemanKatelloActivationKey reference and returns the created
+// ForemanKatelloActivationKey reference. The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateKatelloActivationKey(ctx context.Context, ak *ForemanKatelloActivationKey) (*ForemanKatelloActivationKey, error) {
+	log.Tracef("foreman/api/katello_activation_keys.go#Create")
+
+	akJSONBytes, jsonEncErr := c.WrapJSON(nil, ak)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("KatelloActivationKeyJSONBytes: [%s]", akJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		KatelloActivationKeyEndpointPrefix,
+		bytes.NewBuffer(akJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdActivationKey ForemanKatelloActivationKey
+	sendErr := c.SendAndParse(req, &createdActivationKey)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	createdActivationKey.fillIdsFromReferences()
+
+	log.Debugf("createdActivationKey: [%+v]", createdActivationKey)
+
+	return &createdActivationKey, nil
+}
+
+// ReadKatelloActivationKey reads the attributes of a ForemanKatelloActivationKey
+// identified by the supplied ID and returns a ForemanKatelloActivationKey reference.
+func (c *Client) ReadKatelloActivationKey(ctx context.Context, id int) (*ForemanKatelloActivationKey, error) {
+	log.Tracef("foreman/api/katello_activation_keys.go#Read")
+
+	reqEndpoint := fmt.Sprintf(KatelloActivationKeyById, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readActivationKey ForemanKatelloActivationKey
+	sendErr := c.SendAndParse(req, &readActivationKey)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	readActivationKey.fillIdsFromReferences()
+
+	log.Debugf("readActivationKey: [%+v]", readActivationKey)
+
+	return &readActivationKey, nil
+}
+
+// UpdateKatelloActivationKey updates a ForemanKatelloActivationKey's attributes. The
+// activation key with the ID of the supplied ForemanKatelloActivationKey will be
+// updated. A new ForemanKatelloActivationKey reference is returned with the attributes
+// from the result of the update operation.
+func (c *Client) UpdateKatelloActivationKey(ctx context.Context, ak *ForemanKatelloActivationKey) (*ForemanKatelloActivationKey, error) {
+	log.Tracef("foreman/api/katello_activation_keys.go#Update")
+
+	reqEndpoint := fmt.Sprintf(KatelloActivationKeyById, ak.Id)
+
+	akJSONBytes, jsonEncErr := c.WrapJSON(nil, ak)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("KatelloActivationKeyJSONBytes: [%s]", akJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(akJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedActivationKey ForemanKatelloActivationKey
+	sendErr := c.SendAndParse(req, &updatedActivationKey)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	updatedActivationKey.fillIdsFromReferences()
+
+	log.Debugf("updatedActivationKey: [%+v]", updatedActivationKey)
+
+	return &updatedActivationKey, nil
+}
+
+// DeleteKatelloActivationKey deletes the ForemanKatelloActivationKey identified by the
+// supplied ID
+func (c *Client) DeleteKatelloActivationKey(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/katello_activation_keys.go#Delete")
+
+	reqEndpoint := fmt.Sprintf(KatelloActivationKeyById, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Host Collections and Content Overrides
+// -----------------------------------------------------------------------------
+
+// AddKatelloActivationKeyHostCollections adds the activation key to the host
+// collections with the supplied IDs
+func (c *Client) AddKatelloActivationKeyHostCollections(ctx context.Context, id int, hostCollectionIds []int) error {
+	log.Tracef("foreman/api/katello_activation_keys.go#AddHostCollections")
+
+	return c.sendKatelloActivationKeyHostCollections(ctx, http.MethodPost, id, hostCollectionIds)
+}
+
+// RemoveKatelloActivationKeyHostCollections removes the activation key from the host
+// collections with the supplied IDs
+func (c *Client) RemoveKatelloActivationKeyHostCollections(ctx context.Context, id int, hostCollectionIds []int) error {
+	log.Tracef("foreman/api/katello_activation_keys.go#RemoveHostCollections")
+
+	return c.sendKatelloActivationKeyHostCollections(ctx, http.MethodPut, id, hostCollectionIds)
+}
+
+func (c *Client) sendKatelloActivationKeyHostCollections(ctx context.Context, method string, id int, hostCollectionIds []int) error {
+	reqEndpoint := fmt.Sprintf(KatelloActivationKeyHostCollections, id)
+
+	body := map[string]interface{}{
+		"host_collection_ids": hostCollectionIds,
+	}
+	bodyJSONBytes, jsonEncErr := json.Marshal(body)
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	log.Debugf("hostCollectionsJSONBytes: [%s]", bodyJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		method,
+		reqEndpoint,
+		bytes.NewBuffer(bodyJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// SetKatelloActivationKeyContentOverrides sets the supplied content overrides of the
+// activation key and removes the overrides for the content labels in remove
+func (c *Client) SetKatelloActivationKeyContentOverrides(ctx context.Context, id int, overrides []ForemanKatelloContentOverride, remove []string) error {
+	log.Tracef("foreman/api/katello_activation_keys.go#SetContentOverrides")
+
+	reqEndpoint := fmt.Sprintf(KatelloActivationKeyContentOverride, id)
+
+	var contentOverrides []map[string]interface{}
+	for _, co := range overrides {
+		contentOverrides = append(contentOverrides, map[string]interface{}{
+			"content_label": co.ContentLabel,
+			"name":          "enabled",
+			"value":         co.Value,
+		})
+	}
+	for _, label := range remove {
+		contentOverrides = append(contentOverrides, map[string]interface{}{
+			"content_label": label,
+			"name":          "enabled",
+			"remove":        true,
+		})
+	}
+
+	body := map[string]interface{}{
+		"content_overrides": contentOverrides,
+	}
+	bodyJSONBytes, jsonEncErr := json.Marshal(body)
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	log.Debugf("contentOverridesJSONBytes: [%s]", bodyJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(bodyJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryKatelloActivationKey queries for a ForemanKatelloActivationKey based on the
+// attributes of the supplied ForemanKatelloActivationKey reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// activation keys.
+func (c *Client) QueryKatelloActivationKey(ctx context.Context, ak *ForemanKatelloActivationKey, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/katello_activation_keys.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		KatelloActivationKeyEndpointPrefix,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + ak.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	// organization_id is a required parameter
+	orgId := ak.OrganizationId
+	if orgId == 0 {
+		orgId = c.clientConfig.OrganizationID
+	}
+	reqQuery.Set("organization_id", strconv.Itoa(orgId))
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanKatelloActivationKey for
+	// the results
+	results := []ForemanKatelloActivationKey{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanKatelloActivationKey to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		val.fillIdsFromReferences()
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
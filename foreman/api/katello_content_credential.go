@@ -160,7 +160,7 @@ func (c *Client) DeleteKatelloContentCredential(ctx context.Context, id int) err
 // QueryKatelloContentCredential queries for a ForemanKatelloContentCredential based on the attributes of
 // the supplied ForemanKatelloContentCredential reference and returns a QueryResponse struct
 // containing query/response metadata and the matching smart proxy.
-func (c *Client) QueryKatelloContentCredential(ctx context.Context, s *ForemanKatelloContentCredential) (QueryResponse, error) {
+func (c *Client) QueryKatelloContentCredential(ctx context.Context, s *ForemanKatelloContentCredential, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/katello_content_credential.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -188,7 +188,7 @@ func (c *Client) QueryKatelloContentCredential(ctx context.Context, s *ForemanKa
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
 	"net/http"
+	"slices"
 )
 
 const (
@@ -42,11 +43,102 @@ func (cvf *ContentViewFilter) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMap)
 }
 
+// ContentViewFilterRule is a single rule of a ContentViewFilter. Which attributes apply
+// depends on the type of the filter:
+//   - rpm, deb: Name, Architecture and either Version or MinVersion/MaxVersion
+//   - package_group: Name and Uuid of the package group
+//   - erratum, erratum_id: ErrataId
+//   - erratum, erratum_date: StartDate, EndDate, DateType, Types and AllowOtherTypes
+//   - docker: Name of the tag
+//   - modulemd: ModuleStreamId
 type ContentViewFilterRule struct {
 	ForemanObject
 
 	ContentViewFilterId int    `json:"content_view_filter_id"`
 	Architecture        string `json:"architecture"`
+
+	// Package rules
+	Version    string `json:"version"`
+	MinVersion string `json:"min_version"`
+	MaxVersion string `json:"max_version"`
+
+	// Errata rules
+	ErrataId        string   `json:"errata_id"`
+	StartDate       string   `json:"start_date"`
+	EndDate         string   `json:"end_date"`
+	DateType        string   `json:"date_type"`
+	Types           []string `json:"types"`
+	AllowOtherTypes bool     `json:"allow_other_types"`
+
+	// Package group rules
+	Uuid string `json:"uuid"`
+
+	// Module stream rules
+	ModuleStreamId int `json:"module_stream_id"`
+
+	// FilterType is the type of the filter the rule belongs to, it determines which
+	// attributes are sent. Katello does not return it with the rule.
+	FilterType string `json:"-"`
+}
+
+// IsErratumDateRule returns true if the rule filters errata by their date or type. Every
+// rule of an erratum_date filter does, rules of an erratum filter unless they have an ErrataId.
+func (cvfr *ContentViewFilterRule) IsErratumDateRule() bool {
+	switch cvfr.FilterType {
+	case "erratum_date":
+		return true
+	case "erratum":
+		return cvfr.ErrataId == ""
+	}
+	return false
+}
+
+// MarshalJSON only sends the attributes that belong to the kind of rule, which is derived
+// from FilterType. Unset attributes of that kind are sent as null, so that they are cleared
+// on update.
+func (cvfr ContentViewFilterRule) MarshalJSON() ([]byte, error) {
+	emptyToNil := func(s string) interface{} {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+
+	jsonMap := map[string]interface{}{
+		"id": cvfr.Id,
+	}
+
+	switch cvfr.FilterType {
+	case "modulemd":
+		// Creating a rule only takes a list of module streams, updating it a single one
+		jsonMap["module_stream_ids"] = []int{cvfr.ModuleStreamId}
+		jsonMap["module_stream_id"] = cvfr.ModuleStreamId
+	case "erratum", "erratum_id", "erratum_date":
+		if !cvfr.IsErratumDateRule() {
+			jsonMap["errata_id"] = cvfr.ErrataId
+			break
+		}
+		jsonMap["start_date"] = emptyToNil(cvfr.StartDate)
+		jsonMap["end_date"] = emptyToNil(cvfr.EndDate)
+		jsonMap["types"] = cvfr.Types
+		jsonMap["allow_other_types"] = cvfr.AllowOtherTypes
+		if cvfr.DateType != "" {
+			jsonMap["date_type"] = cvfr.DateType
+		}
+	case "package_group":
+		jsonMap["name"] = cvfr.Name
+		jsonMap["uuid"] = cvfr.Uuid
+	case "docker":
+		jsonMap["name"] = cvfr.Name
+	default:
+		jsonMap["name"] = emptyToNil(cvfr.Name)
+		jsonMap["architecture"] = emptyToNil(cvfr.Architecture)
+		jsonMap["version"] = emptyToNil(cvfr.Version)
+		jsonMap["min_version"] = emptyToNil(cvfr.MinVersion)
+		jsonMap["max_version"] = emptyToNil(cvfr.MaxVersion)
+	}
+
+	return json.Marshal(jsonMap)
 }
 
 // QueryContentViewFilters returns the filters including their rules
@@ -60,7 +152,7 @@ func (c *Client) QueryContentViewFilters(ctx context.Context, cvId int) (QueryRe
 		return queryResponse, err
 	}
 
-	err = c.SendAndParse(req, &queryResponse)
+	err = c.SendAndParseQuery(req, &queryResponse)
 	if err != nil {
 		return queryResponse, err
 	}
@@ -114,9 +206,12 @@ func (c *Client) CreateKatelloContentViewFilters(ctx context.Context, cvId int,
 
 		utils.Debugf("createdCvf: %+v", createdCvf)
 
+		for idx := range cvf.Rules {
+			cvf.Rules[idx].FilterType = cvf.Type
+		}
 		createdRules, err := c.CreateKatelloContentViewFilterRules(ctx, createdCvf.Id, &cvf.Rules)
 		if err != nil {
-			utils.Fatalf("%+v", err)
+			return nil, err
 		}
 		createdCvf.Rules = *createdRules
 
@@ -180,12 +275,30 @@ func (c *Client) ReadKatelloContentViewFilters(ctx context.Context, cvId int) (*
 	return &cvfs, nil
 }
 
+// UpdateKatelloContentViewFilters updates the filters of the content view and their rules.
+// Filters without an ID are created, existing filters that are not part of cvfs are deleted.
 func (c *Client) UpdateKatelloContentViewFilters(ctx context.Context, cvId int, cvfs *[]ContentViewFilter) (*[]ContentViewFilter, error) {
 	utils.TraceFunctionCall()
 
+	existingCvfs, err := c.ReadKatelloContentViewFilters(ctx, cvId)
+	if err != nil {
+		return nil, err
+	}
+
 	var updatedCvfs []ContentViewFilter
+	var keptIds []int
 
 	for _, item := range *cvfs {
+		if item.Id == 0 {
+			createdCvfs, err := c.CreateKatelloContentViewFilters(ctx, cvId, &[]ContentViewFilter{item})
+			if err != nil {
+				return nil, err
+			}
+			updatedCvfs = append(updatedCvfs, *createdCvfs...)
+			continue
+		}
+		keptIds = append(keptIds, item.Id)
+
 		endpoint := fmt.Sprintf(ContentViewFiltersUpdate, cvId, item.Id)
 
 		jsonBytes, err := c.WrapJSONWithTaxonomy(nil, item)
@@ -206,6 +319,9 @@ func (c *Client) UpdateKatelloContentViewFilters(ctx context.Context, cvId int,
 			return nil, err
 		}
 
+		for idx := range item.Rules {
+			item.Rules[idx].FilterType = item.Type
+		}
 		cvfrs, err := c.UpdateKatelloContentViewFilterRules(ctx, updatedCvf.Id, &item.Rules)
 		if err != nil {
 			return nil, err
@@ -217,15 +333,89 @@ func (c *Client) UpdateKatelloContentViewFilters(ctx context.Context, cvId int,
 		updatedCvfs = append(updatedCvfs, updatedCvf)
 	}
 
+	for _, existing := range *existingCvfs {
+		if slices.Contains(keptIds, existing.Id) {
+			continue
+		}
+		err = c.DeleteKatelloContentViewFilter(ctx, cvId, existing.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &updatedCvfs, nil
 }
 
-func (c *Client) UpdateKatelloContentViewFilterRules(ctx context.Context, cvId int, cvfrs *[]ContentViewFilterRule) (*[]ContentViewFilterRule, error) {
+func (c *Client) DeleteKatelloContentViewFilter(ctx context.Context, cvId int, cvfId int) error {
 	utils.TraceFunctionCall()
 
+	endpoint := fmt.Sprintf(ContentViewFiltersUpdate, cvId, cvfId)
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// ReadKatelloContentViewFilterRules returns all rules of the filter
+func (c *Client) ReadKatelloContentViewFilterRules(ctx context.Context, cvfId int) (*[]ContentViewFilterRule, error) {
+	utils.TraceFunctionCall()
+
+	endpoint := fmt.Sprintf(ContentViewFilterRules, cvfId)
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResponse := QueryResponse{}
+	err = c.SendAndParseQuery(req, &queryResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	var cvfrs []ContentViewFilterRule
+	resultsBytes, err := json.Marshal(queryResponse.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(resultsBytes, &cvfrs)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Debugf("read content_view filter rules: %+v", cvfrs)
+
+	return &cvfrs, nil
+}
+
+// UpdateKatelloContentViewFilterRules updates the rules of the filter. Rules without an ID
+// are created, existing rules that are not part of cvfrs are deleted.
+func (c *Client) UpdateKatelloContentViewFilterRules(ctx context.Context, cvfId int, cvfrs *[]ContentViewFilterRule) (*[]ContentViewFilterRule, error) {
+	utils.TraceFunctionCall()
+
+	existingRules, err := c.ReadKatelloContentViewFilterRules(ctx, cvfId)
+	if err != nil {
+		return nil, err
+	}
+
 	var updatedRules []ContentViewFilterRule
+	var keptIds []int
+
 	for _, item := range *cvfrs {
-		endpoint := fmt.Sprintf(ContentViewFilterRulesUpdate, cvId, item.Id)
+		if item.Id == 0 {
+			createdRules, err := c.CreateKatelloContentViewFilterRules(ctx, cvfId, &[]ContentViewFilterRule{item})
+			if err != nil {
+				return nil, err
+			}
+			updatedRules = append(updatedRules, *createdRules...)
+			continue
+		}
+		keptIds = append(keptIds, item.Id)
+
+		endpoint := fmt.Sprintf(ContentViewFilterRulesUpdate, cvfId, item.Id)
 		jsonBytes, err := c.WrapJSONWithTaxonomy(nil, item)
 		if err != nil {
 			return nil, err
@@ -246,5 +436,29 @@ func (c *Client) UpdateKatelloContentViewFilterRules(ctx context.Context, cvId i
 		utils.Debugf("updatedCvfr: %+v", updatedCvfr)
 		updatedRules = append(updatedRules, updatedCvfr)
 	}
+
+	for _, existing := range *existingRules {
+		if slices.Contains(keptIds, existing.Id) {
+			continue
+		}
+		err = c.DeleteKatelloContentViewFilterRule(ctx, cvfId, existing.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &updatedRules, nil
 }
+
+func (c *Client) DeleteKatelloContentViewFilterRule(ctx context.Context, cvfId int, id int) error {
+	utils.TraceFunctionCall()
+
+	endpoint := fmt.Sprintf(ContentViewFilterRulesUpdate, cvfId, id)
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// Ensures every kind of rule keeps its attributes when it is marshaled and
+// read back
+func TestContentViewFilterRule_MarshalUnmarshal(t *testing.T) {
+	cases := []struct {
+		kind       string
+		filterType string
+		rule       ContentViewFilterRule
+	}{
+		{
+			"package",
+			"rpm",
+			ContentViewFilterRule{
+				ForemanObject: ForemanObject{Id: 1, Name: "bash"},
+				Architecture:  "x86_64",
+				Version:       "5.1",
+			},
+		},
+		{
+			"package version range",
+			"deb",
+			ContentViewFilterRule{
+				ForemanObject: ForemanObject{Id: 2, Name: "bash"},
+				MinVersion:    "5.0",
+				MaxVersion:    "5.2",
+			},
+		},
+		{
+			"package group",
+			"package_group",
+			ContentViewFilterRule{
+				ForemanObject: ForemanObject{Id: 3, Name: "Development Tools"},
+				Uuid:          "b4c5e2f0",
+			},
+		},
+		{
+			"erratum id",
+			"erratum",
+			ContentViewFilterRule{
+				ForemanObject: ForemanObject{Id: 4},
+				ErrataId:      "RHSA-2024:0001",
+			},
+		},
+		{
+			"erratum date",
+			"erratum_date",
+			ContentViewFilterRule{
+				ForemanObject:   ForemanObject{Id: 5},
+				StartDate:       "2024-01-01",
+				EndDate:         "2024-12-31",
+				DateType:        "issued",
+				Types:           []string{"security", "bugfix"},
+				AllowOtherTypes: true,
+			},
+		},
+		{
+			"docker tag",
+			"docker",
+			ContentViewFilterRule{
+				ForemanObject: ForemanObject{Id: 6, Name: "latest"},
+			},
+		},
+		{
+			"module stream",
+			"modulemd",
+			ContentViewFilterRule{
+				ForemanObject:  ForemanObject{Id: 7},
+				ModuleStreamId: 12,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		typedRule := c.rule
+		typedRule.FilterType = c.filterType
+		ruleJSON, marshalErr := json.Marshal(typedRule)
+		if marshalErr != nil {
+			t.Fatalf("Marshaling the %s rule returned an error: [%s]", c.kind, marshalErr)
+		}
+
+		var rule ContentViewFilterRule
+		if unmarshalErr := json.Unmarshal(ruleJSON, &rule); unmarshalErr != nil {
+			t.Fatalf("Unmarshaling the %s rule [%s] returned an error: [%s]", c.kind, ruleJSON, unmarshalErr)
+		}
+		if !reflect.DeepEqual(rule, c.rule) {
+			t.Errorf("The %s rule [%+v] was read back as [%+v] from [%s]", c.kind, c.rule, rule, ruleJSON)
+		}
+	}
+}
+
+// Ensures a rule only sends the attributes of the kind its filter type
+// implies and clears the unset ones of that kind
+func TestContentViewFilterRule_MarshalJSON(t *testing.T) {
+	cases := []struct {
+		rule     ContentViewFilterRule
+		expected string
+	}{
+		{
+			ContentViewFilterRule{ForemanObject: ForemanObject{Name: "bash"}, Version: "5.1", FilterType: "rpm"},
+			`{"architecture":null,"id":0,"max_version":null,"min_version":null,"name":"bash","version":"5.1"}`,
+		},
+		{
+			ContentViewFilterRule{ForemanObject: ForemanObject{Name: "Development Tools"}, Uuid: "b4c5e2f0", FilterType: "package_group"},
+			`{"id":0,"name":"Development Tools","uuid":"b4c5e2f0"}`,
+		},
+		{
+			ContentViewFilterRule{ErrataId: "RHSA-2024:0001", Architecture: "x86_64", FilterType: "erratum_id"},
+			`{"errata_id":"RHSA-2024:0001","id":0}`,
+		},
+		{
+			ContentViewFilterRule{ErrataId: "RHSA-2024:0001", FilterType: "erratum"},
+			`{"errata_id":"RHSA-2024:0001","id":0}`,
+		},
+		{
+			ContentViewFilterRule{Types: []string{"security"}, FilterType: "erratum_date"},
+			`{"allow_other_types":false,"end_date":null,"id":0,"start_date":null,"types":["security"]}`,
+		},
+		{
+			ContentViewFilterRule{AllowOtherTypes: true, FilterType: "erratum_date"},
+			`{"allow_other_types":true,"end_date":null,"id":0,"start_date":null,"types":null}`,
+		},
+		{
+			ContentViewFilterRule{DateType: "updated", FilterType: "erratum"},
+			`{"allow_other_types":false,"date_type":"updated","end_date":null,"id":0,"start_date":null,"types":null}`,
+		},
+		{
+			ContentViewFilterRule{ForemanObject: ForemanObject{Name: "latest"}, FilterType: "docker"},
+			`{"id":0,"name":"latest"}`,
+		},
+		{
+			ContentViewFilterRule{ModuleStreamId: 12, FilterType: "modulemd"},
+			`{"id":0,"module_stream_id":12,"module_stream_ids":[12]}`,
+		},
+	}
+
+	for _, c := range cases {
+		ruleJSON, err := json.Marshal(c.rule)
+		if err != nil {
+			t.Fatalf("Marshaling the rule [%+v] returned an error: [%s]", c.rule, err)
+		}
+		if string(ruleJSON) != c.expected {
+			t.Errorf("The rule [%+v] was marshaled as [%s], expected [%s]", c.rule, ruleJSON, c.expected)
+		}
+	}
+}
+
+// Ensures the rules of a created filter are sent as the kind of rule the type
+// of their filter implies
+func TestCreateKatelloContentViewFilters_RuleKind(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(fmt.Sprintf(ContentViewFilters, 1), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"name":"errata","type":"erratum"}`)
+	})
+	var ruleJSON map[string]interface{}
+	mux.HandleFunc(fmt.Sprintf(ContentViewFilterRules, 2), func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &ruleJSON); err != nil {
+			t.Errorf("Decoding the rule [%s] failed: %s", body, err)
+		}
+		fmt.Fprint(w, `{"id":3,"allow_other_types":true}`)
+	})
+
+	cvfs := []ContentViewFilter{{
+		ForemanObject: ForemanObject{Name: "errata"},
+		Type:          "erratum_date",
+		Rules:         []ContentViewFilterRule{{AllowOtherTypes: true}},
+	}}
+	if _, err := client.CreateKatelloContentViewFilters(context.Background(), 1, &cvfs); err != nil {
+		t.Fatalf("CreateKatelloContentViewFilters returned an error: [%s]", err)
+	}
+
+	if ruleJSON["allow_other_types"] != true {
+		t.Errorf("The rule of the erratum_date filter was sent as [%v], expected allow_other_types", ruleJSON)
+	}
+	if _, ok := ruleJSON["name"]; ok {
+		t.Errorf("The rule of the erratum_date filter was sent as [%v], expected no package attributes", ruleJSON)
+	}
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
+	"net/http"
+	"slices"
+)
+
+const (
+	ContentViewVersionEndpointPrefix = "/katello/api/content_view_versions"
+	ContentViewVersionById           = ContentViewVersionEndpointPrefix + "/%d"         // :id
+	ContentViewVersionPromote        = ContentViewVersionEndpointPrefix + "/%d/promote" // :id
+	ContentViewRemove                = ContentViewEndpointPrefix + "/%d/remove"         // :content_view_id
+)
+
+// ContentViewVersionEnvironment is a lifecycle environment a content view version is promoted to
+type ContentViewVersionEnvironment struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+}
+
+// A ContentViewVersion is a published, immutable snapshot of a ContentView.
+type ContentViewVersion struct {
+	ForemanObject
+
+	Version       string `json:"version"`
+	Major         int    `json:"major"`
+	Minor         int    `json:"minor"`
+	Description   string `json:"description"`
+	ContentViewId int    `json:"content_view_id"`
+	ContentView   struct {
+		Id    int    `json:"id"`
+		Name  string `json:"name"`
+		Label string `json:"label"`
+	} `json:"content_view"`
+
+	Environments []ContentViewVersionEnvironment `json:"environments"`
+}
+
+// EnvironmentIds returns the IDs of all lifecycle environments the version is promoted to
+func (cvv *ContentViewVersion) EnvironmentIds() []int {
+	ids := make([]int, 0, len(cvv.Environments))
+	for _, env := range cvv.Environments {
+		ids = append(ids, env.Id)
+	}
+	return ids
+}
+
+// PublishKatelloContentViewVersion publishes a new version of the content view referenced by
+// ContentViewId and waits for the publish task to finish. Major and Minor are only sent if set.
+func (c *Client) PublishKatelloContentViewVersion(ctx context.Context, cvv *ContentViewVersion) (*ContentViewVersion, error) {
+	utils.TraceFunctionCall()
+
+	endpoint := fmt.Sprintf(ContentViewPublish, cvv.ContentViewId)
+
+	body := map[string]interface{}{
+		"description": cvv.Description,
+	}
+	if cvv.Major > 0 {
+		body["major"] = cvv.Major
+		body["minor"] = cvv.Minor
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	// SendAndParse waits for the publish task and returns the updated content view
+	var publishedCv ContentView
+	err = c.SendAndParse(req, &publishedCv)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Debugf("publishedCv: %+v", publishedCv)
+
+	if publishedCv.LatestVersionId == 0 {
+		return nil, fmt.Errorf("content view %d did not report a latest version after publishing", cvv.ContentViewId)
+	}
+
+	return c.ReadKatelloContentViewVersion(ctx, publishedCv.LatestVersionId)
+}
+
+func (c *Client) ReadKatelloContentViewVersion(ctx context.Context, id int) (*ContentViewVersion, error) {
+	utils.TraceFunctionCall()
+
+	reqEndpoint := fmt.Sprintf(ContentViewVersionById, id)
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, reqEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cvv ContentViewVersion
+	err = c.SendAndParse(req, &cvv)
+	if err != nil {
+		return nil, err
+	}
+
+	if cvv.ContentViewId == 0 {
+		cvv.ContentViewId = cvv.ContentView.Id
+	}
+
+	utils.Debugf("read content_view_version: %+v", cvv)
+
+	return &cvv, nil
+}
+
+// PromoteKatelloContentViewVersion promotes the version to the given lifecycle environments.
+// Katello only promotes to an environment whose prior environment already contains the version,
+// therefore the environments are promoted one step of the lifecycle path at a time. With force,
+// all environments are promoted at once, regardless of the lifecycle path.
+func (c *Client) PromoteKatelloContentViewVersion(ctx context.Context, cvv *ContentViewVersion, environmentIds []int, force bool) (*ContentViewVersion, error) {
+	utils.TraceFunctionCall()
+
+	promoted := cvv.EnvironmentIds()
+
+	var pending []LifecycleEnvironment
+	for _, envId := range environmentIds {
+		if slices.Contains(promoted, envId) {
+			continue
+		}
+		lce, err := c.ReadKatelloLifecycleEnvironment(ctx, &LifecycleEnvironment{ForemanObject: ForemanObject{Id: envId}})
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, *lce)
+	}
+
+	for len(pending) > 0 {
+		var step []int
+		var rest []LifecycleEnvironment
+		for _, lce := range pending {
+			if force || slices.Contains(promoted, lce.Prior.Id) {
+				step = append(step, lce.Id)
+			} else {
+				rest = append(rest, lce)
+			}
+		}
+
+		// None of the environments follows an environment the version is promoted to.
+		// Let Katello report which part of the lifecycle path is missing.
+		if len(step) == 0 {
+			for _, lce := range rest {
+				step = append(step, lce.Id)
+			}
+			rest = nil
+		}
+
+		if err := c.promoteKatelloContentViewVersionTo(ctx, cvv.Id, step, force); err != nil {
+			return nil, err
+		}
+
+		promoted = append(promoted, step...)
+		pending = rest
+	}
+
+	return c.ReadKatelloContentViewVersion(ctx, cvv.Id)
+}
+
+func (c *Client) promoteKatelloContentViewVersionTo(ctx context.Context, id int, environmentIds []int, force bool) error {
+	utils.TraceFunctionCall()
+
+	endpoint := fmt.Sprintf(ContentViewVersionPromote, id)
+
+	body := map[string]interface{}{
+		"environment_ids": environmentIds,
+		"force":           force,
+	}
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	utils.Debugf("jsonBytes: %s", jsonBytes)
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return err
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// RemoveKatelloContentViewFromEnvironments removes the content view from the given lifecycle
+// environments, i.e. the versions promoted to these environments are no longer available there.
+func (c *Client) RemoveKatelloContentViewFromEnvironments(ctx context.Context, cvId int, environmentIds []int) error {
+	utils.TraceFunctionCall()
+
+	return c.removeKatelloContentView(ctx, cvId, map[string]interface{}{
+		"environment_ids": environmentIds,
+	})
+}
+
+// DeleteKatelloContentViewVersion removes the version from all environments it is promoted
+// to and deletes it afterwards.
+func (c *Client) DeleteKatelloContentViewVersion(ctx context.Context, cvv *ContentViewVersion) error {
+	utils.TraceFunctionCall()
+
+	return c.removeKatelloContentView(ctx, cvv.ContentViewId, map[string]interface{}{
+		"environment_ids":          cvv.EnvironmentIds(),
+		"content_view_version_ids": []int{cvv.Id},
+	})
+}
+
+func (c *Client) removeKatelloContentView(ctx context.Context, cvId int, body map[string]interface{}) error {
+	utils.TraceFunctionCall()
+
+	endpoint := fmt.Sprintf(ContentViewRemove, cvId)
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	utils.Debugf("jsonBytes: %s", jsonBytes)
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return err
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// katelloContentViewVersionServer is a minimal Katello API for publishing and
+// promoting version 12 of content view 3.  Publishing and promoting respond
+// with a pending task, which finishes on its first poll.
+type katelloContentViewVersionServer struct {
+	// Environments version 12 is promoted to
+	environments []ContentViewVersionEnvironment
+	// Bodies of the publish requests
+	publishes []map[string]interface{}
+	// Bodies of the promote requests
+	promotes []map[string]interface{}
+	// IDs of the tasks that were polled
+	polledTasks []string
+}
+
+// newKatelloContentViewVersionServer registers the endpoints of the server on
+// the mux.  The lifecycle path is Library (1) -> Test (2) -> Prod (3).
+func newKatelloContentViewVersionServer(t *testing.T, mux *http.ServeMux) *katelloContentViewVersionServer {
+	s := &katelloContentViewVersionServer{
+		environments: []ContentViewVersionEnvironment{{Id: 1, Name: "Library", Label: "Library"}},
+	}
+	taskLabels := map[string]string{}
+
+	// pendingTask records the body of a POST request and responds with a
+	// pending task
+	pendingTask := func(w http.ResponseWriter, r *http.Request, taskId string, label string) map[string]interface{} {
+		if r.Method != http.MethodPost {
+			t.Errorf("%s was requested with method [%s], expected [POST]", r.URL.Path, r.Method)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		taskLabels[taskId] = label
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"id":%q,"label":%q,"pending":true,"state":"planned"}`, taskId, label)
+		return body
+	}
+
+	mux.HandleFunc("/katello/api/content_views/3/publish", func(w http.ResponseWriter, r *http.Request) {
+		body := pendingTask(w, r, "publish", "Actions::Katello::ContentView::Publish")
+		s.publishes = append(s.publishes, body)
+	})
+	mux.HandleFunc("/katello/api/content_view_versions/12/promote", func(w http.ResponseWriter, r *http.Request) {
+		body := pendingTask(w, r, fmt.Sprintf("promote-%d", len(s.promotes)), "Actions::Katello::ContentView::Promote")
+		s.promotes = append(s.promotes, body)
+		for _, id := range body["environment_ids"].([]interface{}) {
+			s.environments = append(s.environments, ContentViewVersionEnvironment{Id: int(id.(float64))})
+		}
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		taskId := strings.TrimPrefix(r.URL.Path, "/foreman_tasks/api/tasks/")
+		s.polledTasks = append(s.polledTasks, taskId)
+		fmt.Fprintf(w, `{"id":%q,"label":%q,"pending":false,"state":"stopped","result":"success",`+
+			`"output":{"content_view_id":3}}`, taskId, taskLabels[taskId])
+	})
+	mux.HandleFunc("/katello/api/content_views/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"base","latest_version_id":12}`)
+	})
+	mux.HandleFunc("/katello/api/content_views/3/filters", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":0,"subtotal":0,"results":[]}`)
+	})
+	mux.HandleFunc("/katello/api/content_view_versions/12", func(w http.ResponseWriter, r *http.Request) {
+		environments, _ := json.Marshal(s.environments)
+		fmt.Fprintf(w, `{"id":12,"name":"base 2.0","version":"2.0","major":2,"minor":0,`+
+			`"content_view":{"id":3,"name":"base"},"environments":%s}`, environments)
+	})
+	for id, prior := range map[int]int{2: 1, 3: 2} {
+		id, prior := id, prior
+		mux.HandleFunc(fmt.Sprintf("/katello/api/environments/%d", id), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id":%d,"name":"env%d","prior":{"id":%d}}`, id, id, prior)
+		})
+	}
+
+	return s
+}
+
+// Ensures publishing waits for the publish task and returns the new version
+// of the content view
+func TestPublishKatelloContentViewVersion(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+	katello := newKatelloContentViewVersionServer(t, mux)
+
+	cvv, err := client.PublishKatelloContentViewVersion(context.Background(), &ContentViewVersion{
+		ContentViewId: 3,
+		Description:   "Patches 2024-05",
+		Major:         2,
+	})
+	if err != nil {
+		t.Fatalf("PublishKatelloContentViewVersion returned an error: [%s]", err)
+	}
+
+	expectedBody := map[string]interface{}{"description": "Patches 2024-05", "major": 2.0, "minor": 0.0}
+	if len(katello.publishes) != 1 || !reflect.DeepEqual(katello.publishes[0], expectedBody) {
+		t.Errorf("Publish was requested with [%v], expected [%v]", katello.publishes, expectedBody)
+	}
+	if !reflect.DeepEqual(katello.polledTasks, []string{"publish"}) {
+		t.Errorf("Polled the tasks %v, expected [publish]", katello.polledTasks)
+	}
+	if cvv.Id != 12 || cvv.ContentViewId != 3 || cvv.Version != "2.0" {
+		t.Errorf("PublishKatelloContentViewVersion returned [%+v], expected version 12 of content view 3", cvv)
+	}
+}
+
+// Ensures promoting follows the lifecycle path one environment at a time and
+// waits for each promote task, unless the promotion is forced
+func TestPromoteKatelloContentViewVersion(t *testing.T) {
+	cases := []struct {
+		force            bool
+		expectedPromotes []map[string]interface{}
+		expectedTasks    []string
+	}{
+		{
+			force: false,
+			expectedPromotes: []map[string]interface{}{
+				{"environment_ids": []interface{}{2.0}, "force": false},
+				{"environment_ids": []interface{}{3.0}, "force": false},
+			},
+			expectedTasks: []string{"promote-0", "promote-1"},
+		},
+		{
+			force: true,
+			expectedPromotes: []map[string]interface{}{
+				{"environment_ids": []interface{}{3.0, 2.0}, "force": true},
+			},
+			expectedTasks: []string{"promote-0"},
+		},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+		katello := newKatelloContentViewVersionServer(t, mux)
+
+		cvv := &ContentViewVersion{
+			ForemanObject: ForemanObject{Id: 12},
+			ContentViewId: 3,
+			Environments:  katello.environments,
+		}
+		promoted, err := client.PromoteKatelloContentViewVersion(context.Background(), cvv, []int{3, 2, 1}, c.force)
+		server.Close()
+		if err != nil {
+			t.Fatalf("PromoteKatelloContentViewVersion returned an error: [%s]", err)
+		}
+
+		if !reflect.DeepEqual(katello.promotes, c.expectedPromotes) {
+			t.Errorf("Promote with force [%t] was requested with %v, expected %v", c.force, katello.promotes, c.expectedPromotes)
+		}
+		if !reflect.DeepEqual(katello.polledTasks, c.expectedTasks) {
+			t.Errorf("Promote with force [%t] polled the tasks %v, expected %v", c.force, katello.polledTasks, c.expectedTasks)
+		}
+		if ids := promoted.EnvironmentIds(); len(ids) != 3 {
+			t.Errorf("PromoteKatelloContentViewVersion returned the environments %v, expected [1 2 3]", ids)
+		}
+	}
+}
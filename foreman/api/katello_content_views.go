@@ -123,7 +123,7 @@ func (cv *ContentView) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMap)
 }
 
-func (c *Client) QueryContentView(ctx context.Context, d *ContentView) (QueryResponse, error) {
+func (c *Client) QueryContentView(ctx context.Context, d *ContentView, opts ...QueryOptions) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
 	queryResponse := QueryResponse{}
@@ -140,7 +140,7 @@ func (c *Client) QueryContentView(ctx context.Context, d *ContentView) (QueryRes
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &queryResponse)
+	err = c.SendAndParseQuery(req, &queryResponse, opts...)
 	if err != nil {
 		return queryResponse, err
 	}
@@ -263,7 +263,7 @@ func (c *Client) ReadContentViewFilters(ctx context.Context, cvId int) (*[]Conte
 		return nil, err
 	}
 
-	err = c.SendAndParse(req, &queryResponse)
+	err = c.SendAndParseQuery(req, &queryResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (lce *LifecycleEnvironment) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMap)
 }
 
-func (c *Client) QueryLifecycleEnvironment(ctx context.Context, d *LifecycleEnvironment) (QueryResponse, error) {
+func (c *Client) QueryLifecycleEnvironment(ctx context.Context, d *LifecycleEnvironment, opts ...QueryOptions) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
 	queryResponse := QueryResponse{}
@@ -88,7 +88,7 @@ func (c *Client) QueryLifecycleEnvironment(ctx context.Context, d *LifecycleEnvi
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &queryResponse)
+	err = c.SendAndParseQuery(req, &queryResponse, opts...)
 	if err != nil {
 		return queryResponse, err
 	}
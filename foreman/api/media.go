@@ -22,6 +22,8 @@ const (
 type ForemanMedia struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// The path to the medium, can be a URL or a valid NFS server (exclusive
 	// of the architecture).  For example:
@@ -62,6 +64,12 @@ func (fm *ForemanMedia) UnmarshalJSON(b []byte) error {
 	}
 	fm.ForemanObject = fo
 
+	// Unmarshal the organizations and locations of the object
+	jsonDecErr = json.Unmarshal(b, &fm.ForemanTaxonomyIds)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with
 	// differently named keys
 	var fmJSON foremanMediaJSON
@@ -219,7 +227,7 @@ func (c *Client) DeleteMedia(ctx context.Context, id int) error {
 // QueryMedia queries for a ForemanMedia based on the attributes of the
 // supplied ForemanMedia reference and returns a QueryResponse struct
 // containing query/response metadata and the matching media.
-func (c *Client) QueryMedia(ctx context.Context, m *ForemanMedia) (QueryResponse, error) {
+func (c *Client) QueryMedia(ctx context.Context, m *ForemanMedia, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/media.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -241,7 +249,7 @@ func (c *Client) QueryMedia(ctx context.Context, m *ForemanMedia) (QueryResponse
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
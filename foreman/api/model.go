@@ -162,7 +162,7 @@ func (c *Client) DeleteModel(ctx context.Context, id int) error {
 // QueryModel queries for a ForemanModel based on the attributes of the
 // supplied ForemanModel reference and returns a QueryResponse struct
 // containing query/response metadata and the matching model.
-func (c *Client) QueryModel(ctx context.Context, m *ForemanModel) (QueryResponse, error) {
+func (c *Client) QueryModel(ctx context.Context, m *ForemanModel, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/model.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -184,7 +184,7 @@ func (c *Client) QueryModel(ctx context.Context, m *ForemanModel) (QueryResponse
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
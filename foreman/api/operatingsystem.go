@@ -258,7 +258,7 @@ func (c *Client) DeleteOperatingSystem(ctx context.Context, id int) error {
 // attributes of the supplied ForemanOperatingSystem reference and returns a
 // QueryResponse struct containing query/response metadata and the matching
 // operating systems.
-func (c *Client) QueryOperatingSystem(ctx context.Context, o *ForemanOperatingSystem) (QueryResponse, error) {
+func (c *Client) QueryOperatingSystem(ctx context.Context, o *ForemanOperatingSystem, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/operatingsystem.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -281,7 +281,7 @@ func (c *Client) QueryOperatingSystem(ctx context.Context, o *ForemanOperatingSy
 	reqQuery.Set("search", "title="+title)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
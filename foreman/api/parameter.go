@@ -218,7 +218,7 @@ func (c *Client) DeleteParameter(ctx context.Context, d *ForemanParameter, id in
 // QueryParameter queries for a ForemanParameter based on the attributes of the
 // supplied ForemanParameter reference and returns a QueryResponse struct
 // containing query/response metadata and the matching parameters.
-func (c *Client) QueryParameter(ctx context.Context, d *ForemanParameter) (QueryResponse, error) {
+func (c *Client) QueryParameter(ctx context.Context, d *ForemanParameter, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/parameter.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -240,7 +240,7 @@ func (c *Client) QueryParameter(ctx context.Context, d *ForemanParameter) (Query
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
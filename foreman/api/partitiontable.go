@@ -24,6 +24,8 @@ const (
 type ForemanPartitionTable struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// The script that defines the partition table layout
 	Layout string `json:"layout"`
@@ -70,6 +72,12 @@ func (ft *ForemanPartitionTable) UnmarshalJSON(b []byte) error {
 	}
 	ft.ForemanObject = fo
 
+	// Unmarshal the organizations and locations of the object
+	jsonDecErr = json.Unmarshal(b, &ft.ForemanTaxonomyIds)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with differently
 	// named keys
 	var ftJSON foremanPartitionTableJSON
@@ -242,7 +250,7 @@ func (c *Client) DeletePartitionTable(ctx context.Context, id int) error {
 // attributes of the supplied ForemanPartitionTable reference and returns a
 // QueryResponse struct containing query/response metadata and the matching
 // partition tables.
-func (c *Client) QueryPartitionTable(ctx context.Context, t *ForemanPartitionTable) (QueryResponse, error) {
+func (c *Client) QueryPartitionTable(ctx context.Context, t *ForemanPartitionTable, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/partitiontable.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -264,7 +272,7 @@ func (c *Client) QueryPartitionTable(ctx context.Context, t *ForemanPartitionTab
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	PermissionEndpointPrefix = "permissions"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPermission API model represents a permission, e.g. "view_hosts".
+// Permissions are predefined by Foreman and its plugins and granted to roles
+// through filters.
+type ForemanPermission struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Resource type the permission applies to, e.g. "Host"
+	ResourceType string `json:"resource_type"`
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryPermission queries for a ForemanPermission based on the attributes of
+// the supplied ForemanPermission reference and returns a QueryResponse struct
+// containing query/response metadata and the matching permissions.
+func (c *Client) QueryPermission(ctx context.Context, p *ForemanPermission, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/permission.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", PermissionEndpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + p.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanPermission for
+	// the results
+	results := []ForemanPermission{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanPermission to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
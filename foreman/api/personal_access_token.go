@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	PersonalAccessTokenEndpointPrefix = "personal_access_tokens"
+
+	// Format of the timestamps returned by Foreman
+	foremanTimestampFormat = "2006-01-02 15:04:05 MST"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPersonalAccessToken API model represents a personal access token
+// of a user. Personal access tokens are used instead of the user's password to
+// authenticate against the API.
+type ForemanPersonalAccessToken struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the user the token belongs to
+	UserId int `json:"user_id"`
+	// Expiry of the token, the token does not expire if empty
+	ExpiresAt string `json:"expires_at"`
+	// Timestamp the token was last used at
+	LastUsedAt string `json:"last_used_at"`
+	// Whether the token was revoked
+	Revoked bool `json:"revoked"`
+
+	// The secret token. It is only returned when the token is created.
+	TokenValue string `json:"token_value"`
+}
+
+// foremanPersonalAccessTokenDecode is used to decode the revocation state
+// which Foreman renders as a predicate with a trailing question mark
+type foremanPersonalAccessTokenDecode struct {
+	ForemanPersonalAccessToken
+	RevokedDecode bool `json:"revoked?"`
+}
+
+// Implement the Marshaler interface
+func (t ForemanPersonalAccessToken) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/personal_access_token.go#MarshalJSON")
+
+	// NOTE(ALL): the token value is generated by Foreman and never sent
+
+	tMap := map[string]interface{}{}
+
+	tMap["name"] = t.Name
+	if t.ExpiresAt != "" {
+		tMap["expires_at"] = t.ExpiresAt
+	}
+
+	return json.Marshal(tMap)
+}
+
+// Expired returns whether the expiry of the token has passed
+func (t ForemanPersonalAccessToken) Expired() bool {
+	if t.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, parseErr := time.Parse(foremanTimestampFormat, t.ExpiresAt)
+	if parseErr != nil {
+		// Foreman might return the expiry in RFC 3339 format as well
+		if expiresAt, parseErr = time.Parse(time.RFC3339, t.ExpiresAt); parseErr != nil {
+			log.Debugf("Could not parse expiry [%s] of personal access token [%d]", t.ExpiresAt, t.Id)
+			return false
+		}
+	}
+	return expiresAt.Before(time.Now())
+}
+
+// sendAndParsePersonalAccessToken sends the request and decodes the personal
+// access token of the response
+func (c *Client) sendAndParsePersonalAccessToken(req *http.Request) (*ForemanPersonalAccessToken, error) {
+	var decoded foremanPersonalAccessTokenDecode
+	sendErr := c.SendAndParse(req, &decoded)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	t := decoded.ForemanPersonalAccessToken
+	t.Revoked = t.Revoked || decoded.RevokedDecode
+	return &t, nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreatePersonalAccessToken creates a new personal access token for the user
+// with the supplied ID and returns the created ForemanPersonalAccessToken
+// reference. The token value is only part of this response.
+func (c *Client) CreatePersonalAccessToken(ctx context.Context, userId int, t *ForemanPersonalAccessToken) (*ForemanPersonalAccessToken, error) {
+	log.Tracef("foreman/api/personal_access_token.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", UserEndpointPrefix, userId, PersonalAccessTokenEndpointPrefix)
+
+	tJSONBytes, jsonEncErr := c.WrapJSON("personal_access_token", t)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(tJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	createdToken, sendErr := c.sendAndParsePersonalAccessToken(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdToken: [id: %d, name: %s]", createdToken.Id, createdToken.Name)
+
+	return createdToken, nil
+}
+
+// ReadPersonalAccessToken reads the attributes of the personal access token
+// identified by the supplied user ID and token ID
+func (c *Client) ReadPersonalAccessToken(ctx context.Context, userId int, id int) (*ForemanPersonalAccessToken, error) {
+	log.Tracef("foreman/api/personal_access_token.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%d", UserEndpointPrefix, userId, PersonalAccessTokenEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	readToken, sendErr := c.sendAndParsePersonalAccessToken(req)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readToken: [%+v]", readToken)
+
+	return readToken, nil
+}
+
+// RevokePersonalAccessToken revokes the personal access token identified by
+// the supplied user ID and token ID. Foreman keeps revoked tokens, they can no
+// longer be used to authenticate.
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, userId int, id int) error {
+	log.Tracef("foreman/api/personal_access_token.go#Revoke")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%d", UserEndpointPrefix, userId, PersonalAccessTokenEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Ensures the token value of a created personal access token is decoded and
+// only the name and expiry are sent
+func TestCreatePersonalAccessToken(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/users/5/personal_access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("personal access token was created with method [%s], expected [POST]", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"personal_access_token":{"expires_at":"2030-01-01T00:00:00Z","name":"terraform"}}`
+		if string(body) != expected {
+			t.Errorf("personal access token was created with [%s], expected [%s]", body, expected)
+		}
+		fmt.Fprint(w, `{"id":3,"name":"terraform","user_id":5,"expires_at":"2030-01-01 00:00:00 UTC",`+
+			`"last_used_at":null,"active?":true,"revoked?":false,"token_value":"s3cr3t"}`)
+	})
+
+	token := ForemanPersonalAccessToken{ExpiresAt: "2030-01-01T00:00:00Z"}
+	token.Name = "terraform"
+
+	created, err := client.CreatePersonalAccessToken(context.Background(), 5, &token)
+	if err != nil {
+		t.Fatalf("CreatePersonalAccessToken returned an error: [%s]", err)
+	}
+	if created.Id != 3 || created.TokenValue != "s3cr3t" || created.Revoked {
+		t.Errorf("CreatePersonalAccessToken returned [%+v]", created)
+	}
+}
+
+// Ensures the revocation state is decoded from the predicate Foreman renders
+func TestReadPersonalAccessToken_Revoked(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/users/5/personal_access_tokens/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"terraform","user_id":5,"active?":false,"revoked?":true}`)
+	})
+
+	token, err := client.ReadPersonalAccessToken(context.Background(), 5, 3)
+	if err != nil {
+		t.Fatalf("ReadPersonalAccessToken returned an error: [%s]", err)
+	}
+	if !token.Revoked {
+		t.Errorf("ReadPersonalAccessToken did not decode the revocation: [%+v]", token)
+	}
+}
+
+// Ensures the expiry of a token is parsed in the formats Foreman returns
+func TestForemanPersonalAccessToken_Expired(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC()
+	past := time.Now().Add(-time.Hour).UTC()
+
+	cases := []struct {
+		expiresAt string
+		expired   bool
+	}{
+		{"", false},
+		{future.Format(foremanTimestampFormat), false},
+		{past.Format(foremanTimestampFormat), true},
+		{future.Format(time.RFC3339), false},
+		{past.Format(time.RFC3339), true},
+		{"not a timestamp", false},
+	}
+
+	for _, c := range cases {
+		token := ForemanPersonalAccessToken{ExpiresAt: c.expiresAt}
+		if token.Expired() != c.expired {
+			t.Errorf("Expired() of a token expiring at [%s] returned [%t], expected [%t]", c.expiresAt, !c.expired, c.expired)
+		}
+	}
+}
@@ -172,7 +172,7 @@ func (c *Client) DeleteKatelloProduct(ctx context.Context, id int) error {
 // QueryKatelloProduct queries for a ForemanKatelloProduct based on the attributes of
 // the supplied ForemanKatelloProduct reference and returns a QueryResponse struct
 // containing query/response metadata and the matching sync plan.
-func (c *Client) QueryKatelloProduct(ctx context.Context, p *ForemanKatelloProduct) (QueryResponse, error) {
+func (c *Client) QueryKatelloProduct(ctx context.Context, p *ForemanKatelloProduct, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/product.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -197,7 +197,7 @@ func (c *Client) QueryKatelloProduct(ctx context.Context, p *ForemanKatelloProdu
 	reqQuery.Set("organization_id", orgId)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
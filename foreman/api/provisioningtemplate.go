@@ -24,6 +24,8 @@ const (
 type ForemanProvisioningTemplate struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// The markup and code of the provisioning template
 	Template string
@@ -124,6 +126,15 @@ func (ft ForemanProvisioningTemplate) MarshalJSON() ([]byte, error) {
 		ftMap["template_combinations_attributes"] = ft.TemplateCombinationsAttributes
 	}
 
+	// only replace the organizations and locations of the template if they
+	// are set, an empty list removes all of them, see WrapJSONWithTaxonomy
+	if ft.LocationIds != nil {
+		ftMap["location_ids"] = ft.LocationIds
+	}
+	if ft.OrganizationIds != nil {
+		ftMap["organization_ids"] = ft.OrganizationIds
+	}
+
 	log.Debugf("ftMap: [%v]", ftMap)
 
 	return json.Marshal(ftMap)
@@ -142,6 +153,12 @@ func (ft *ForemanProvisioningTemplate) UnmarshalJSON(b []byte) error {
 	}
 	ft.ForemanObject = fo
 
+	// Unmarshal the organizations and locations of the object
+	jsonDecErr = json.Unmarshal(b, &ft.ForemanTaxonomyIds)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with differently
 	// named keys
 	var ftJSON foremanProvisioningTemplateJSON
@@ -321,7 +338,7 @@ func (c *Client) DeleteProvisioningTemplate(ctx context.Context, id int) error {
 // the attributes of the supplied ForemanProvisioningTemplate reference and
 // returns a QueryResponse struct containing query/response metadata and the
 // matching templates.
-func (c *Client) QueryProvisioningTemplate(ctx context.Context, t *ForemanProvisioningTemplate) (QueryResponse, error) {
+func (c *Client) QueryProvisioningTemplate(ctx context.Context, t *ForemanProvisioningTemplate, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/provisioningtemplate.go#Query")
 
 	queryResponse := QueryResponse{}
@@ -343,7 +360,7 @@ func (c *Client) QueryProvisioningTemplate(ctx context.Context, t *ForemanProvis
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
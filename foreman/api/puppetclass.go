@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
+	"sort"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -61,10 +61,10 @@ func (c *Client) ReadPuppetClass(ctx context.Context, id int) (*ForemanPuppetCla
 // of the supplied ForemanPuppetClass reference and returns a QueryResponse
 // struct containing query/response metadata
 // The Puppet module search API has a different response format to normal. Results
-// are returned in a map instead of an array, with the class name as the key.
+// are returned in a map instead of an array, with the module name as the key.
 // To work around this the results field is unmarshalled and then remarshalled
 // into an array to normalise it
-func (c *Client) QueryPuppetClass(ctx context.Context, t *ForemanPuppetClass) (QueryResponse, error) {
+func (c *Client) QueryPuppetClass(ctx context.Context, t *ForemanPuppetClass, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/puppetclass.go#Search")
 
 	queryResponse := QueryResponsePuppet{}
@@ -85,38 +85,64 @@ func (c *Client) QueryPuppetClass(ctx context.Context, t *ForemanPuppetClass) (Q
 	name := `"` + t.Name + `"`
 	reqQuery.Set("search", "name="+name)
 
+	// The classes of a module can be spread over several pages, merge them
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	queryResponse.Results = map[string]interface{}{}
+	sendErr := c.walkQueryPages(req, mergeQueryOptions(opts), func(pageReq *http.Request) (queryPage, error) {
+		pageResponse := QueryResponsePuppet{}
+		if err := c.SendAndParse(pageReq, &pageResponse); err != nil {
+			return queryPage{}, err
+		}
+		queryResponse.QueryResponse = pageResponse.QueryResponse
+		count := 0
+		for module, classes := range pageResponse.Results {
+			classList, _ := classes.([]interface{})
+			count += len(classList)
+			if existing, ok := queryResponse.Results[module].([]interface{}); ok {
+				classList = append(existing, classList...)
+			}
+			queryResponse.Results[module] = classList
+		}
+		return queryPage{
+			Results:  count,
+			Subtotal: pageResponse.Subtotal,
+			PerPage:  pageResponse.PerPage,
+		}, nil
+	})
 	if sendErr != nil {
 		return QueryResponse{}, sendErr
 	}
 
 	log.Debugf("queryResponse: [%+v]", queryResponse)
 
-	nestedIndex := strings.Index(t.Name, ":")
-	var indexName string
-	if nestedIndex > 0 {
-		indexName = string(t.Name[0:nestedIndex])
-	} else {
-		indexName = t.Name
+	// Results will be Unmarshaled into a map of module names to
+	// []map[string]interface{}. Encode back to JSON, then Unmarshal the
+	// classes of all modules into []ForemanPuppetClass for the results.
+	// Modules are sorted by name to keep the order of the results stable.
+	modules := make([]string, 0, len(queryResponse.Results))
+	for module := range queryResponse.Results {
+		modules = append(modules, module)
 	}
+	sort.Strings(modules)
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	// Encode back to JSON, then Unmarshal into []ForemanPuppetClass for
-	// the results
 	results := []ForemanPuppetClass{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results[indexName])
-	if jsonEncErr != nil {
-		return QueryResponse{}, jsonEncErr
+	for _, module := range modules {
+		moduleResults := []ForemanPuppetClass{}
+		resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results[module])
+		if jsonEncErr != nil {
+			return QueryResponse{}, jsonEncErr
+		}
+
+		jsonDecErr := json.Unmarshal(resultsBytes, &moduleResults)
+		if jsonDecErr != nil {
+			return QueryResponse{}, jsonDecErr
+		}
+		results = append(results, moduleResults...)
 	}
 
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return QueryResponse{}, jsonDecErr
-	}
 	// convert the search results from []ForemanPuppetClass to []interface
 	// and set the search results on the query
-	iArr := make([]interface{}, 1)
+	iArr := make([]interface{}, len(results))
 	for idx, val := range results {
 		iArr[idx] = val
 	}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+// Number of results requested per page of a query if the client
+// configuration does not set QueryPerPage
+const DefaultQueryPerPage = 100
+
+// QueryOptions change how the Query* functions look up objects.  The zero
+// value keeps the query as the Query* function builds it.
+type QueryOptions struct {
+	// Foreman scoped search sent instead of the search the Query* function
+	// builds from the attributes of the object, if set.  An empty search
+	// lists all objects.
+	Search *string
+}
+
+// SearchQueryOptions returns the QueryOptions which replace the search of a
+// query with the supplied Foreman scoped search
+func SearchQueryOptions(search string) QueryOptions {
+	return QueryOptions{Search: &search}
+}
+
+// mergeQueryOptions combines the options passed to a Query* function, later
+// options take precedence
+func mergeQueryOptions(opts []QueryOptions) QueryOptions {
+	merged := QueryOptions{}
+	for _, opt := range opts {
+		if opt.Search != nil {
+			merged.Search = opt.Search
+		}
+	}
+	return merged
+}
+
+// queryPage is the outcome of fetching a single page of a query
+type queryPage struct {
+	// Number of results on the page
+	Results int
+	// Number of results matching the search criteria on all pages
+	Subtotal int
+	// Page size the server actually used
+	PerPage int
+}
+
+// queryPerPage returns the configured page size of queries
+func (client *Client) queryPerPage() int {
+	if client.clientConfig.QueryPerPage > 0 {
+		return client.clientConfig.QueryPerPage
+	}
+	return DefaultQueryPerPage
+}
+
+// walkQueryPages requests the pages of the query request one after another
+// and passes each page request to fetch, until all results matching the
+// search criteria have been fetched or a page is not full.  The search of
+// the QueryOptions replaces the search of the request.
+func (client *Client) walkQueryPages(req *http.Request, opts QueryOptions, fetch func(pageReq *http.Request) (queryPage, error)) error {
+	perPage := client.queryPerPage()
+	fetched := 0
+
+	for page := 1; ; page++ {
+		pageReq := req.Clone(req.Context())
+		pageQuery := pageReq.URL.Query()
+		if opts.Search != nil && *opts.Search != "" {
+			pageQuery.Set("search", *opts.Search)
+		} else if opts.Search != nil {
+			pageQuery.Del("search")
+		}
+		pageQuery.Set("page", strconv.Itoa(page))
+		pageQuery.Set("per_page", strconv.Itoa(perPage))
+		pageReq.URL.RawQuery = pageQuery.Encode()
+
+		result, fetchErr := fetch(pageReq)
+		if fetchErr != nil {
+			return fetchErr
+		}
+		fetched += result.Results
+
+		// Foreman might use a smaller page size than requested
+		pageSize := perPage
+		if result.PerPage > 0 && result.PerPage < pageSize {
+			pageSize = result.PerPage
+		}
+		if result.Results == 0 || result.Results < pageSize {
+			return nil
+		}
+		// Some endpoints do not return a subtotal, page until a page is not
+		// full for them
+		if result.Subtotal > 0 && fetched >= result.Subtotal {
+			return nil
+		}
+		log.Debugf("Fetched %d of %d results of %s, requesting page %d", fetched, result.Subtotal, req.URL.Path, page+1)
+	}
+}
+
+// SendAndParseQuery sends a query request generated by
+// Client.NewRequestWithContext() and collects the results of all pages of
+// the response in the supplied QueryResponse. The page size is set by
+// ClientConfig.QueryPerPage, the other metadata of the QueryResponse is the
+// one of the first page. The QueryOptions passed to the Query* function are
+// applied to the request.
+func (client *Client) SendAndParseQuery(req *http.Request, queryResponse *QueryResponse, opts ...QueryOptions) error {
+	results := []interface{}{}
+	firstPage := true
+
+	walkErr := client.walkQueryPages(req, mergeQueryOptions(opts), func(pageReq *http.Request) (queryPage, error) {
+		var pageResponse QueryResponse
+		if sendErr := client.SendAndParse(pageReq, &pageResponse); sendErr != nil {
+			return queryPage{}, sendErr
+		}
+		if firstPage {
+			*queryResponse = pageResponse
+			firstPage = false
+		}
+		results = append(results, pageResponse.Results...)
+		return queryPage{
+			Results:  len(pageResponse.Results),
+			Subtotal: pageResponse.Subtotal,
+			PerPage:  pageResponse.PerPage,
+		}, nil
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	queryResponse.Results = results
+	return nil
+}
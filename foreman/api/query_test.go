@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// Ensures all pages of a query are fetched with the configured page size
+func TestSendAndParseQuery_Pagination(t *testing.T) {
+	cases := []struct {
+		// Page size configured on the client
+		perPage int
+		// Page size the server uses, simulating a server-side limit
+		serverPerPage int
+		subtotal      int
+		// Whether the server omits the subtotal from its responses
+		noSubtotal bool
+		pages      int
+	}{
+		{perPage: 2, serverPerPage: 2, subtotal: 5, pages: 3},
+		{perPage: 2, serverPerPage: 2, subtotal: 4, pages: 2},
+		{perPage: 10, serverPerPage: 10, subtotal: 0, pages: 1},
+		{perPage: 10, serverPerPage: 3, subtotal: 7, pages: 3},
+		{perPage: 2, serverPerPage: 2, subtotal: 5, noSubtotal: true, pages: 3},
+		{perPage: 2, serverPerPage: 2, subtotal: 4, noSubtotal: true, pages: 3},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{QueryPerPage: c.perPage})
+		requestedPages := 0
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/domains", func(w http.ResponseWriter, r *http.Request) {
+			requestedPages++
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if r.URL.Query().Get("per_page") != strconv.Itoa(c.perPage) {
+				t.Errorf("Query requested per_page [%s], expected [%d]", r.URL.Query().Get("per_page"), c.perPage)
+			}
+			if r.URL.Query().Get("search") != `name="example.com"` {
+				t.Errorf("Query lost the search parameter, got [%s]", r.URL.Query().Get("search"))
+			}
+
+			results := ""
+			for id := (page-1)*c.serverPerPage + 1; id <= page*c.serverPerPage && id <= c.subtotal; id++ {
+				if results != "" {
+					results += ","
+				}
+				results += fmt.Sprintf(`{"id":%d}`, id)
+			}
+			if c.noSubtotal {
+				fmt.Fprintf(w, `{"page":%d,"per_page":%d,"results":[%s]}`, page, c.serverPerPage, results)
+				return
+			}
+			fmt.Fprintf(
+				w,
+				`{"total":100,"subtotal":%d,"page":%d,"per_page":%d,"results":[%s]}`,
+				c.subtotal, page, c.serverPerPage, results,
+			)
+		})
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/domains", nil)
+		reqQuery := req.URL.Query()
+		reqQuery.Set("search", `name="example.com"`)
+		req.URL.RawQuery = reqQuery.Encode()
+
+		var queryResponse QueryResponse
+		err := client.SendAndParseQuery(req, &queryResponse)
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("SendAndParseQuery() returned an error: %s", err)
+		}
+		expectedSubtotal := c.subtotal
+		if c.noSubtotal {
+			expectedSubtotal = 0
+		}
+		if len(queryResponse.Results) != c.subtotal || queryResponse.Subtotal != expectedSubtotal {
+			t.Errorf(
+				"SendAndParseQuery() returned [%d] results and subtotal [%d], expected [%d] and [%d]",
+				len(queryResponse.Results), queryResponse.Subtotal, c.subtotal, expectedSubtotal,
+			)
+		}
+		if requestedPages != c.pages {
+			t.Errorf("SendAndParseQuery() requested [%d] pages, expected [%d]", requestedPages, c.pages)
+		}
+		for idx, result := range queryResponse.Results {
+			if id := result.(map[string]interface{})["id"].(float64); int(id) != idx+1 {
+				t.Errorf("Result [%d] has id [%v], expected [%d]", idx, id, idx+1)
+			}
+		}
+	}
+}
+
+// Ensures an error on a later page fails the whole query
+func TestSendAndParseQuery_PageError(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{QueryPerPage: 1})
+	defer server.Close()
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/domains", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"subtotal":2,"page":1,"per_page":1,"results":[{"id":1}]}`))
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/domains", nil)
+	var queryResponse QueryResponse
+	if err := client.SendAndParseQuery(req, &queryResponse); err == nil {
+		t.Errorf("SendAndParseQuery() returned no error for a failing page")
+	}
+}
+
+// Ensures the puppet classes of all modules and pages are collected
+func TestQueryPuppetClass_Pagination(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{QueryPerPage: 1})
+	defer server.Close()
+	mux.HandleFunc(FOREMAN_PUPPET_API_URL_PREFIX+"/puppetclasses", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"subtotal":2,"page":2,"per_page":1,"results":{"apache":[{"id":2,"name":"apache::mod"}]}}`))
+			return
+		}
+		w.Write([]byte(`{"subtotal":2,"page":1,"per_page":1,"results":{"apache":[{"id":1,"name":"apache"}]}}`))
+	})
+
+	queryResponse, err := client.QueryPuppetClass(context.TODO(), &ForemanPuppetClass{ForemanObject: ForemanObject{Name: "apache"}})
+	if err != nil {
+		t.Fatalf("QueryPuppetClass() returned an error: %s", err)
+	}
+	if len(queryResponse.Results) != 2 {
+		t.Fatalf("QueryPuppetClass() returned [%d] results, expected [2]", len(queryResponse.Results))
+	}
+	if class := queryResponse.Results[1].(ForemanPuppetClass); class.Name != "apache::mod" {
+		t.Errorf("Second result is [%s], expected [apache::mod]", class.Name)
+	}
+}
+
+// Ensures the search of the QueryOptions replaces the search of the query
+func TestSendAndParseQuery_SearchQueryOptions(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	var searches []string
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/domains", func(w http.ResponseWriter, r *http.Request) {
+		searches = append(searches, r.URL.Query().Get("search"))
+		w.Write([]byte(`{"subtotal":0,"results":[]}`))
+	})
+
+	for _, opts := range [][]QueryOptions{
+		nil,
+		{{}},
+		{SearchQueryOptions(`name ~ "example"`)},
+		{SearchQueryOptions("")},
+		{SearchQueryOptions("name = other"), {}},
+	} {
+		if _, err := client.QueryDomain(context.TODO(), &ForemanDomain{ForemanObject: ForemanObject{Name: "example.com"}}, opts...); err != nil {
+			t.Fatalf("QueryDomain() returned an error: %s", err)
+		}
+	}
+
+	expected := []string{`name="example.com"`, `name="example.com"`, `name ~ "example"`, "", "name = other"}
+	if !reflect.DeepEqual(searches, expected) {
+		t.Errorf("Queries were sent with searches %q, expected %q", searches, expected)
+	}
+}
@@ -14,6 +14,7 @@ const (
 	// KatelloRepositoryEndpointPrefix api endpoint prefix for katello repositories
 	// 'katello/ will be removed, it's a marker to detect talking with katello api
 	KatelloRepositoryEndpointPrefix = "katello/repositories"
+	KatelloRepositorySync           = KatelloRepositoryEndpointPrefix + "/%d/sync" // :id
 )
 
 // -----------------------------------------------------------------------------
@@ -65,6 +66,28 @@ type ForemanKatelloRepository struct {
 	DockerTagsWhitelist string `json:"docker_tags_whitelist"`
 
 	AnsibleCollectionRequirements string `json:"ansible_collection_requirements"`
+
+	// Read-only sync status
+	LastSync      *ForemanKatelloRepositoryLastSync `json:"last_sync"`
+	LastSyncWords string                            `json:"last_sync_words"`
+	ContentCounts map[string]int                    `json:"content_counts"`
+}
+
+// ForemanKatelloRepositoryLastSync is the task of the last synchronization of a repository
+type ForemanKatelloRepositoryLastSync struct {
+	Id        string `json:"id"`
+	State     string `json:"state"`
+	Result    string `json:"result"`
+	StartedAt string `json:"started_at"`
+	EndedAt   string `json:"ended_at"`
+}
+
+// ForemanKatelloRepositorySyncOptions are the optional parameters of a repository sync
+type ForemanKatelloRepositorySyncOptions struct {
+	// Force a sync even if the upstream metadata did not change
+	SkipMetadataCheck bool `json:"skip_metadata_check"`
+	// Check the contents of the repository and repair missing or corrupt units
+	ValidateContents bool `json:"validate_contents"`
 }
 
 func (r *ForemanKatelloRepository) MarshalJSON() ([]byte, error) {
@@ -256,7 +279,7 @@ func (c *Client) DeleteKatelloRepository(ctx context.Context, id int) error {
 // QueryKatelloRepository queries for a ForemanKatelloRepository based on the attributes of
 // the supplied ForemanKatelloRepository reference and returns a QueryResponse struct
 // containing query/response metadata and the matching sync plan.
-func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRepository) (QueryResponse, error) {
+func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRepository, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/repository.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -277,7 +300,7 @@ func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRe
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -305,3 +328,44 @@ func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRe
 
 	return queryResponse, nil
 }
+
+// -----------------------------------------------------------------------------
+// Synchronization
+// -----------------------------------------------------------------------------
+
+// SyncKatelloRepository synchronizes the ForemanKatelloRepository identified by the
+// supplied ID with its upstream URL and waits for the sync task to finish. The finished
+// task is returned.
+func (c *Client) SyncKatelloRepository(ctx context.Context, id int, opts *ForemanKatelloRepositorySyncOptions) (*ForemanTask, error) {
+	log.Tracef("foreman/api/repository.go#Sync")
+
+	reqEndpoint := fmt.Sprintf(KatelloRepositorySync, id)
+
+	sJSONBytes, jsonEncErr := json.Marshal(opts)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("KatelloRepositorySyncJSONBytes: [%s]", sJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(sJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	// SendAndParse waits for the sync task and returns the finished task
+	var syncTask ForemanTask
+	sendErr := c.SendAndParse(req, &syncTask)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("syncTask: [%+v]", syncTask)
+
+	return &syncTask, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// Ensures a repository sync is sent to the Katello API with the sync options
+// and returns the finished sync task
+func TestSyncKatelloRepository(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	var syncOpts map[string]interface{}
+	mux.HandleFunc("/katello/api/repositories/7/sync", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Sync was requested with method [%s], expected [POST]", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&syncOpts)
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"abc","label":"Actions::Katello::Repository::Sync","pending":true,"state":"planned"}`)
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"abc","label":"Actions::Katello::Repository::Sync",`+
+			`"pending":false,"state":"stopped","result":"success","ended_at":"2024-05-01 10:00:00 UTC"}`)
+	})
+
+	opts := &ForemanKatelloRepositorySyncOptions{ValidateContents: true}
+	task, err := client.SyncKatelloRepository(context.Background(), 7, opts)
+	if err != nil {
+		t.Fatalf("SyncKatelloRepository returned an error: [%s]", err)
+	}
+	if task.Id != "abc" || task.Pending || task.Result != "success" {
+		t.Errorf("SyncKatelloRepository returned [%+v], expected the finished task", task)
+	}
+	if syncOpts["validate_contents"] != true || syncOpts["skip_metadata_check"] != false {
+		t.Errorf("Sync was requested with the options [%v]", syncOpts)
+	}
+}
+
+// Ensures a failed sync task is returned as a ForemanTaskError
+func TestSyncKatelloRepository_FailedTask(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, fastTaskPollConfig)
+	defer server.Close()
+
+	mux.HandleFunc("/katello/api/repositories/7/sync", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"abc","label":"Actions::Katello::Repository::Sync","pending":true,"state":"planned"}`)
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"abc","label":"Actions::Katello::Repository::Sync",`+
+			`"pending":false,"state":"stopped","result":"error",`+
+			`"humanized":{"errors":["404 Not Found: upstream repository"]}}`)
+	})
+
+	task, err := client.SyncKatelloRepository(context.Background(), 7, &ForemanKatelloRepositorySyncOptions{})
+	if task != nil {
+		t.Errorf("SyncKatelloRepository returned the task [%+v] of a failed sync", task)
+	}
+	var taskErr ForemanTaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("SyncKatelloRepository returned [%v], expected a ForemanTaskError", err)
+	}
+	if taskErr.TaskId != "abc" || taskErr.Result != "error" || len(taskErr.Errors) != 1 {
+		t.Errorf("ForemanTaskError does not carry the task details: [%+v]", taskErr)
+	}
+}
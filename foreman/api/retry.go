@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+// Default bounds of the wait between two attempts of a request, used if the
+// client configuration does not set them
+const (
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// retryableStatusCodes are the HTTP status codes of transient failures.
+// Foreman answers with 409 when a record is locked by a running task.
+var retryableStatusCodes = map[int]bool{
+	http.StatusConflict:           true,
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// idempotentMethods can be sent again without changing the result
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryEnabled reports whether requests with the supplied HTTP method may be
+// sent more than once
+func (cfg ClientConfig) retryEnabled(method string) bool {
+	if cfg.RetryMaxAttempts <= 1 {
+		return false
+	}
+	return cfg.RetryNonIdempotent || idempotentMethods[method]
+}
+
+// shouldRetry reports whether the outcome of an attempt is a transient
+// failure. Connection errors are transient unless the request's context
+// was cancelled.
+func shouldRetry(ctx context.Context, statusCode int, sendErr error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if sendErr != nil {
+		return !errors.Is(sendErr, context.Canceled) && !errors.Is(sendErr, context.DeadlineExceeded)
+	}
+	return retryableStatusCodes[statusCode]
+}
+
+// retryWait returns how long to wait before the next attempt. The wait
+// doubles with every attempt between RetryWaitMin and RetryWaitMax, with a
+// random jitter of up to half of the wait. A Retry-After header sent by the
+// server takes precedence.
+func (cfg ClientConfig) retryWait(attempt int, header http.Header) time.Duration {
+	if wait, ok := parseRetryAfter(header); ok {
+		return wait
+	}
+
+	waitMin := cfg.RetryWaitMin
+	if waitMin <= 0 {
+		waitMin = DefaultRetryWaitMin
+	}
+	waitMax := cfg.RetryWaitMax
+	if waitMax <= 0 {
+		waitMax = DefaultRetryWaitMax
+	}
+
+	wait := time.Duration(float64(waitMin) * math.Pow(2, float64(attempt-1)))
+	if wait > waitMax || wait <= 0 {
+		wait = waitMax
+	}
+	jitter := time.Duration(rand.Int63n(int64(wait/2) + 1))
+	return wait/2 + jitter
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of
+// seconds or an HTTP date
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	log.Warningf("Ignoring invalid Retry-After header [%s]", value)
+	return 0, false
+}
+
+// SleepContext waits for the supplied duration or until the context is done,
+// in which case the error of the context is returned
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
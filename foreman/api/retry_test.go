@@ -0,0 +1,219 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// retryTestConfig retries quickly to keep the tests fast
+var retryTestConfig = ClientConfig{
+	RetryMaxAttempts: 3,
+	RetryWaitMin:     time.Millisecond,
+	RetryWaitMax:     5 * time.Millisecond,
+}
+
+// Ensures transient status codes are retried until the request succeeds
+func TestSend_RetryTransientStatus(t *testing.T) {
+	for _, statusCode := range []int{
+		http.StatusConflict,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	} {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, retryTestConfig)
+		attempts := 0
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(statusCode)
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		})
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts/1", nil)
+		status, body, err := client.Send(req)
+		server.Close()
+
+		if err != nil || status != http.StatusOK || string(body) != `{"id":1}` || attempts != 3 {
+			t.Errorf(
+				"Send() returned [%d] [%s] [%v] after [%d] attempts for status code [%d], "+
+					"expected [200] after [3] attempts",
+				status, body, err, attempts, statusCode,
+			)
+		}
+	}
+}
+
+// Ensures the last response is returned once the attempts are exhausted and
+// that other errors are not retried
+func TestSend_RetryExhausted(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		attempts   int
+	}{
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusInternalServerError, 1},
+		{http.StatusNotFound, 1},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, retryTestConfig)
+		attempts := 0
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(c.statusCode)
+		})
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts/1", nil)
+		status, _, _ := client.Send(req)
+		server.Close()
+
+		if status != c.statusCode || attempts != c.attempts {
+			t.Errorf(
+				"Send() returned [%d] after [%d] attempts, expected [%d] after [%d] attempts",
+				status, attempts, c.statusCode, c.attempts,
+			)
+		}
+	}
+}
+
+// Ensures POST requests are only retried if non-idempotent retries are
+// enabled and that the request body is sent again
+func TestSend_RetryNonIdempotent(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		conf := retryTestConfig
+		conf.RetryNonIdempotent = enabled
+
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+		var bodies []string
+		mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodPost, "/hosts", bytes.NewBufferString(`{"host":{}}`))
+		client.Send(req)
+		server.Close()
+
+		expected := 1
+		if enabled {
+			expected = 2
+		}
+		if len(bodies) != expected {
+			t.Fatalf("POST was sent [%d] times with RetryNonIdempotent [%t], expected [%d]", len(bodies), enabled, expected)
+		}
+		for _, body := range bodies {
+			if body != `{"host":{}}` {
+				t.Errorf("POST was sent with body [%s], expected [{\"host\":{}}]", body)
+			}
+		}
+	}
+}
+
+// Ensures connection errors are retried
+func TestSend_RetryConnectionError(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{}, retryTestConfig)
+	server.Close()
+
+	start := time.Now()
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts/1", nil)
+	status, _, err := client.Send(req)
+
+	// Two waits of at least half of RetryWaitMin each
+	if err == nil || status != -1 || time.Since(start) < time.Millisecond {
+		t.Errorf("Send() returned [%d] [%v] for a closed server, expected a retried connection error", status, err)
+	}
+}
+
+// Ensures the Retry-After header is honored, both as seconds and HTTP date
+func TestRetryWait_RetryAfter(t *testing.T) {
+	cases := []struct {
+		header string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"2", 2 * time.Second, 2 * time.Second},
+		{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 8 * time.Second, 10 * time.Second},
+		{"invalid", 0, time.Millisecond},
+	}
+
+	for _, c := range cases {
+		header := http.Header{}
+		header.Set("Retry-After", c.header)
+		wait := retryTestConfig.retryWait(1, header)
+		if wait < c.min || wait > c.max {
+			t.Errorf("retryWait() returned [%s] for Retry-After [%s], expected between [%s] and [%s]", wait, c.header, c.min, c.max)
+		}
+	}
+}
+
+// Ensures the wait grows exponentially up to the maximum including jitter
+func TestRetryWait_Backoff(t *testing.T) {
+	conf := ClientConfig{RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+	cases := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, 10 * time.Second},
+	}
+
+	for _, c := range cases {
+		wait := conf.retryWait(c.attempt, http.Header{})
+		if wait < c.max/2 || wait > c.max {
+			t.Errorf("retryWait() returned [%s] for attempt [%d], expected between [%s] and [%s]", wait, c.attempt, c.max/2, c.max)
+		}
+	}
+}
+
+// Ensures no further attempt is made once the maximum elapsed time would be
+// exceeded
+func TestSend_RetryMaxElapsed(t *testing.T) {
+	conf := retryTestConfig
+	conf.RetryMaxElapsed = time.Second
+
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+	defer server.Close()
+	attempts := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts/1", nil)
+	status, _, _ := client.Send(req)
+
+	if status != http.StatusTooManyRequests || attempts != 1 {
+		t.Errorf("Send() returned [%d] after [%d] attempts, expected [429] after [1] attempt", status, attempts)
+	}
+}
+
+// Ensures SleepContext waits for the duration and stops early once the
+// context is done
+func TestSleepContext(t *testing.T) {
+	if err := SleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("SleepContext() returned [%v] for a running context", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := SleepContext(ctx, time.Minute); err != context.Canceled {
+		t.Errorf("SleepContext() returned [%v] for a cancelled context, expected [%v]", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SleepContext() waited [%s] for a cancelled context", elapsed)
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	RoleEndpointPrefix = "roles"
+
+	// DefaultRoleName is the name of the builtin role Foreman assigns to
+	// every user. It can not be removed from a user.
+	DefaultRoleName = "Default role"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRole API model represents a role. A role is a named set of
+// filters, each filter grants permissions on one resource type.
+type ForemanRole struct {
+	// Inherits the base object's attributes
+	ForemanObject
+	// Organizations and locations the role is assigned to. Filters of the
+	// role inherit them unless they override them.
+	ForemanTaxonomyIds
+
+	// Description of the role
+	Description string `json:"description"`
+	// Builtin roles can not be changed, 0 for roles created by users
+	Builtin int `json:"builtin,omitempty"`
+	// Filters of the role, only read
+	Filters []ForemanObject `json:"filters,omitempty"`
+}
+
+// roleIdsFromEntityResponse returns the IDs of the roles assigned to a user
+// or usergroup, without the default role
+func roleIdsFromEntityResponse(roles []EntityResponse) []int {
+	ids := make([]int, 0, len(roles))
+	for _, role := range roles {
+		if role.Name == DefaultRoleName {
+			continue
+		}
+		ids = append(ids, role.ID)
+	}
+	return ids
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRole creates a new ForemanRole with the attributes of the supplied
+// ForemanRole reference and returns the created ForemanRole reference.
+// The returned reference will have its ID and other API default values set by
+// this function.
+func (c *Client) CreateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+
+	roleJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRole ForemanRole
+	sendErr := c.SendAndParse(req, &createdRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRole: [%+v]", createdRole)
+
+	return &createdRole, nil
+}
+
+// ReadRole reads the attributes of a ForemanRole identified by the supplied
+// ID and returns a ForemanRole reference.
+func (c *Client) ReadRole(ctx context.Context, id int) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRole ForemanRole
+	sendErr := c.SendAndParse(req, &readRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRole: [%+v]", readRole)
+
+	return &readRole, nil
+}
+
+// UpdateRole updates a ForemanRole's attributes. The role with the ID of the
+// supplied ForemanRole will be updated. A new ForemanRole reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, r.Id)
+
+	roleJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRole ForemanRole
+	sendErr := c.SendAndParse(req, &updatedRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRole: [%+v]", updatedRole)
+
+	return &updatedRole, nil
+}
+
+// DeleteRole deletes the ForemanRole identified by the supplied ID. Foreman
+// deletes the filters of the role along with it.
+func (c *Client) DeleteRole(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/role.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRole queries for a ForemanRole based on the attributes of the supplied
+// ForemanRole reference and returns a QueryResponse struct containing
+// query/response metadata and the matching roles.
+func (c *Client) QueryRole(ctx context.Context, r *ForemanRole, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/role.go#Search")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	name := `"` + r.Name + `"`
+	reqQuery.Set("search", "name="+name)
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanRole for
+	// the results
+	results := []ForemanRole{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanRole to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// Ensures the roles read with a user do not contain the default role Foreman
+// assigns to every user
+func TestReadUser_RoleIdsWithoutDefaultRole(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/users/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"login":"jdoe","roles":[`+
+			`{"id":1,"name":"Default role","origin":null},`+
+			`{"id":23,"name":"Host operators","origin":null}]}`)
+	})
+
+	user, err := client.ReadUser(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("ReadUser returned an error: [%s]", err)
+	}
+	if !reflect.DeepEqual(user.RoleIds, []int{23}) {
+		t.Errorf("ReadUser returned role IDs [%v], expected [[23]]", user.RoleIds)
+	}
+}
+
+// Ensures the roles of a usergroup are decoded into their IDs
+func TestReadUsergroup_RoleIds(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/usergroups/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"operators","admin":false,"roles":[`+
+			`{"id":23,"name":"Host operators"},{"id":24,"name":"Viewer"}]}`)
+	})
+
+	usergroup, err := client.ReadUsergroup(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ReadUsergroup returned an error: [%s]", err)
+	}
+	if !reflect.DeepEqual(usergroup.RoleIds, []int{23, 24}) {
+		t.Errorf("ReadUsergroup returned role IDs [%v], expected [[23 24]]", usergroup.RoleIds)
+	}
+}
@@ -86,7 +86,7 @@ func (c *Client) ReadSetting(ctx context.Context, id string) (*ForemanSetting, e
 // supplied ForemanSetting reference and returns a QueryResponse struct
 // containing query/response metadata and the matching settings.
 // TODO: Copied from QueryDomains.
-func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResponse, error) {
+func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/setting.go#Query")
 
 	queryResponse := QueryResponse{}
@@ -108,7 +108,7 @@ func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResp
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -66,7 +66,7 @@ func (c *Client) ReadSmartClassParameter(ctx context.Context, id int) (*ForemanS
 // QuerySmartClassParameter queries for a ForemanSmartClassParameter based on the attributes
 // of the supplied ForemanSmartClassParameter reference and returns a QueryResponse
 // struct containing query/response metadata
-func (c *Client) QuerySmartClassParameter(ctx context.Context, t *ForemanSmartClassParameter) (QueryResponse, error) {
+func (c *Client) QuerySmartClassParameter(ctx context.Context, t *ForemanSmartClassParameter, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/smartclassparameter.go#Search")
 
 	reqEndpoint := fmt.Sprintf(SmartClassParameterQueryEndpointPrefix, t.PuppetClassId)
@@ -89,7 +89,7 @@ func (c *Client) QuerySmartClassParameter(ctx context.Context, t *ForemanSmartCl
 	reqQuery.Set("search", "parameter="+param)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return QueryResponse{}, sendErr
 	}
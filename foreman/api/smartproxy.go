@@ -31,6 +31,8 @@ const (
 type ForemanSmartProxy struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
@@ -168,7 +170,7 @@ func (c *Client) DeleteSmartProxy(ctx context.Context, id int) error {
 // QuerySmartProxy queries for a ForemanSmartProxy based on the attributes of
 // the supplied ForemanSmartProxy reference and returns a QueryResponse struct
 // containing query/response metadata and the matching smart proxy.
-func (c *Client) QuerySmartProxy(ctx context.Context, s *ForemanSmartProxy) (QueryResponse, error) {
+func (c *Client) QuerySmartProxy(ctx context.Context, s *ForemanSmartProxy, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/smartproxy.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -190,7 +192,7 @@ func (c *Client) QuerySmartProxy(ctx context.Context, s *ForemanSmartProxy) (Que
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	SSHKeyEndpointPrefix = "ssh_keys"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanSSHKey API model represents a public SSH key of a user. The keys
+// are deployed to hosts for remote execution.
+type ForemanSSHKey struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the user the key belongs to
+	UserId int `json:"user_id"`
+	// The public key in OpenSSH format
+	Key string `json:"key"`
+	// Fingerprint of the key
+	Fingerprint string `json:"fingerprint"`
+	// Length of the key in bits
+	Length int `json:"length"`
+}
+
+// Implement the Marshaler interface
+func (k ForemanSSHKey) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/ssh_key.go#MarshalJSON")
+
+	kMap := map[string]interface{}{}
+
+	kMap["name"] = k.Name
+	// Foreman only accepts a single line, while public key files end with a
+	// newline
+	kMap["key"] = strings.TrimSpace(k.Key)
+
+	log.Debugf("kMap: [%v]", kMap)
+
+	return json.Marshal(kMap)
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateSSHKey adds a new SSH key to the user with the supplied ID and returns
+// the created ForemanSSHKey reference.
+func (c *Client) CreateSSHKey(ctx context.Context, userId int, k *ForemanSSHKey) (*ForemanSSHKey, error) {
+	log.Tracef("foreman/api/ssh_key.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", UserEndpointPrefix, userId, SSHKeyEndpointPrefix)
+
+	kJSONBytes, jsonEncErr := c.WrapJSON("ssh_key", k)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("sshKeyJSONBytes: [%s]", kJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(kJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdSSHKey ForemanSSHKey
+	sendErr := c.SendAndParse(req, &createdSSHKey)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdSSHKey: [%+v]", createdSSHKey)
+
+	return &createdSSHKey, nil
+}
+
+// ReadSSHKey reads the attributes of the SSH key identified by the supplied
+// user ID and key ID
+func (c *Client) ReadSSHKey(ctx context.Context, userId int, id int) (*ForemanSSHKey, error) {
+	log.Tracef("foreman/api/ssh_key.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%d", UserEndpointPrefix, userId, SSHKeyEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readSSHKey ForemanSSHKey
+	sendErr := c.SendAndParse(req, &readSSHKey)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readSSHKey: [%+v]", readSSHKey)
+
+	return &readSSHKey, nil
+}
+
+// DeleteSSHKey removes the SSH key identified by the supplied user ID and key
+// ID
+func (c *Client) DeleteSSHKey(ctx context.Context, userId int, id int) error {
+	log.Tracef("foreman/api/ssh_key.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%d", UserEndpointPrefix, userId, SSHKeyEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -28,6 +28,8 @@ type Domain struct {
 type ForemanSubnet struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the object is assigned to
+	ForemanTaxonomyIds
 
 	// Subnet network (ie: 192.168.100.0)
 	Network string `json:"network"`
@@ -212,7 +214,7 @@ func (c *Client) DeleteSubnet(ctx context.Context, id int) error {
 // QuerySubnet queries for a ForemanSubnet based on the attributes of the
 // supplied ForemanSubnet reference and returns a QueryResponse struct
 // containing query/response metadata and the matching subnets
-func (c *Client) QuerySubnet(ctx context.Context, s *ForemanSubnet) (QueryResponse, error) {
+func (c *Client) QuerySubnet(ctx context.Context, s *ForemanSubnet, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/subnet.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -239,7 +241,7 @@ func (c *Client) QuerySubnet(ctx context.Context, s *ForemanSubnet) (QueryRespon
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -175,7 +175,7 @@ func (c *Client) DeleteKatelloSyncPlan(ctx context.Context, id int) error {
 // QueryKatelloSyncPlan queries for a ForemanKatelloSyncPlan based on the attributes of
 // the supplied ForemanKatelloSyncPlan reference and returns a QueryResponse struct
 // containing query/response metadata and the matching sync plan.
-func (c *Client) QueryKatelloSyncPlan(ctx context.Context, sp *ForemanKatelloSyncPlan) (QueryResponse, error) {
+func (c *Client) QueryKatelloSyncPlan(ctx context.Context, sp *ForemanKatelloSyncPlan, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/sync_plan.go#Search")
 
 	reqEndpoint := fmt.Sprintf(KatelloSyncPlanEndpointPrefix, c.clientConfig.OrganizationID)
@@ -198,7 +198,7 @@ func (c *Client) QueryKatelloSyncPlan(ctx context.Context, sp *ForemanKatelloSyn
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
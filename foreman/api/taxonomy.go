@@ -0,0 +1,449 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	OrganizationEndpointPrefix = "organizations"
+	LocationEndpointPrefix     = "locations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanTaxonomy is the common API model of organizations and locations.
+// Both can be nested and carry their own parameters.
+type ForemanTaxonomy struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Title is the full name of the taxonomy including its parents,
+	// e.g. "Europe/Berlin"
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// ID of the parent taxonomy, 0 for top-level taxonomies
+	ParentId int `json:"parent_id"`
+	// Resource types that are not restricted by this taxonomy, i.e. all
+	// resources of these types are automatically part of it
+	IgnoreTypes []string `json:"ignore_types"`
+	// Parameters of the taxonomy. Read from "parameters", see taxonomyJSON
+	// for how they are sent
+	Parameters []ForemanKVParameter `json:"parameters"`
+}
+
+// ForemanOrganization API model representing an organization
+type ForemanOrganization struct {
+	ForemanTaxonomy
+}
+
+// ForemanLocation API model representing a location
+type ForemanLocation struct {
+	ForemanTaxonomy
+}
+
+// ForemanTaxonomyIds holds the organizations and locations a taxonomy-aware
+// object is assigned to. Objects embedding it are sent with their own
+// organization_ids and location_ids instead of the provider's default
+// organization and location, see WrapJSONWithTaxonomy.
+type ForemanTaxonomyIds struct {
+	// IDs of the locations to assign the object to, only sent
+	LocationIds []int `json:"location_ids,omitempty"`
+	// IDs of the organizations to assign the object to, only sent
+	OrganizationIds []int `json:"organization_ids,omitempty"`
+	// Locations of the object, only read
+	Locations []EntityResponse `json:"locations,omitempty"`
+	// Organizations of the object, only read
+	Organizations []EntityResponse `json:"organizations,omitempty"`
+}
+
+// overridesLocation reports whether the object sets its own locations,
+// including none at all
+func (t ForemanTaxonomyIds) overridesLocation() bool {
+	return t.LocationIds != nil
+}
+
+// overridesOrganization reports whether the object sets its own
+// organizations, including none at all
+func (t ForemanTaxonomyIds) overridesOrganization() bool {
+	return t.OrganizationIds != nil
+}
+
+// clearedTaxonomyIds returns the emptied location_ids and organization_ids of
+// the object as empty lists. They are dropped by omitempty otherwise, which
+// would leave the object's organizations and locations unchanged.
+func (t ForemanTaxonomyIds) clearedTaxonomyIds() map[string]interface{} {
+	cleared := map[string]interface{}{}
+	if t.LocationIds != nil && len(t.LocationIds) == 0 {
+		cleared["location_ids"] = []int{}
+	}
+	if t.OrganizationIds != nil && len(t.OrganizationIds) == 0 {
+		cleared["organization_ids"] = []int{}
+	}
+	return cleared
+}
+
+// ReadLocationIds returns the IDs of the locations the object was read with.
+// The second return value is false if the response did not contain them.
+func (t ForemanTaxonomyIds) ReadLocationIds() ([]int, bool) {
+	return entityResponseIds(t.Locations)
+}
+
+// ReadOrganizationIds returns the IDs of the organizations the object was
+// read with. The second return value is false if the response did not
+// contain them.
+func (t ForemanTaxonomyIds) ReadOrganizationIds() ([]int, bool) {
+	return entityResponseIds(t.Organizations)
+}
+
+func entityResponseIds(entities []EntityResponse) ([]int, bool) {
+	if entities == nil {
+		return nil, false
+	}
+	ids := make([]int, 0, len(entities))
+	for _, e := range entities {
+		ids = append(ids, e.ID)
+	}
+	return ids, true
+}
+
+// taxonomyOverride is implemented by objects which can replace the provider's
+// default organization or location with their own
+type taxonomyOverride interface {
+	overridesLocation() bool
+	overridesOrganization() bool
+}
+
+// taxonomyClearer is implemented by objects which can remove all of their
+// organizations or locations
+type taxonomyClearer interface {
+	clearedTaxonomyIds() map[string]interface{}
+}
+
+// taxonomyJSON wraps the attributes of the taxonomy with the name of its type.
+// Parameters are sent as "<type>_parameters_attributes", Foreman replaces all
+// existing parameters of the taxonomy by name.
+func (c *Client) taxonomyJSON(taxonomyType string, t *ForemanTaxonomy) ([]byte, error) {
+	// Send an empty list instead of null to clear the ignored types
+	ignoreTypes := t.IgnoreTypes
+	if ignoreTypes == nil {
+		ignoreTypes = []string{}
+	}
+
+	m := map[string]interface{}{
+		"name":                                  t.Name,
+		"description":                           t.Description,
+		"ignore_types":                          ignoreTypes,
+		taxonomyType + "_parameters_attributes": t.Parameters,
+	}
+	if t.ParentId != 0 {
+		m["parent_id"] = t.ParentId
+	} else {
+		m["parent_id"] = nil
+	}
+
+	return c.WrapJSON(taxonomyType, m)
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+func (c *Client) createTaxonomy(ctx context.Context, endpointPrefix string, taxonomyType string, t *ForemanTaxonomy) (*ForemanTaxonomy, error) {
+	reqEndpoint := fmt.Sprintf("/%s", endpointPrefix)
+
+	taxonomyJSONBytes, jsonEncErr := c.taxonomyJSON(taxonomyType, t)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("taxonomyJSONBytes: [%s]", taxonomyJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(taxonomyJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdTaxonomy ForemanTaxonomy
+	sendErr := c.SendAndParse(req, &createdTaxonomy)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdTaxonomy: [%+v]", createdTaxonomy)
+
+	return &createdTaxonomy, nil
+}
+
+func (c *Client) readTaxonomy(ctx context.Context, endpointPrefix string, id int) (*ForemanTaxonomy, error) {
+	reqEndpoint := fmt.Sprintf("/%s/%d", endpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readTaxonomy ForemanTaxonomy
+	sendErr := c.SendAndParse(req, &readTaxonomy)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readTaxonomy: [%+v]", readTaxonomy)
+
+	return &readTaxonomy, nil
+}
+
+func (c *Client) updateTaxonomy(ctx context.Context, endpointPrefix string, taxonomyType string, t *ForemanTaxonomy) (*ForemanTaxonomy, error) {
+	reqEndpoint := fmt.Sprintf("/%s/%d", endpointPrefix, t.Id)
+
+	taxonomyJSONBytes, jsonEncErr := c.taxonomyJSON(taxonomyType, t)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("taxonomyJSONBytes: [%s]", taxonomyJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(taxonomyJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedTaxonomy ForemanTaxonomy
+	sendErr := c.SendAndParse(req, &updatedTaxonomy)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedTaxonomy: [%+v]", updatedTaxonomy)
+
+	return &updatedTaxonomy, nil
+}
+
+func (c *Client) deleteTaxonomy(ctx context.Context, endpointPrefix string, id int) error {
+	reqEndpoint := fmt.Sprintf("/%s/%d", endpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// queryTaxonomy searches for taxonomies by their title if set, otherwise by
+// their name, unless the QueryOptions replace the search. The results are
+// returned as []ForemanTaxonomy.
+func (c *Client) queryTaxonomy(ctx context.Context, endpointPrefix string, t *ForemanTaxonomy, opts []QueryOptions) (QueryResponse, []ForemanTaxonomy, error) {
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", endpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, nil, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	if t.Title != "" {
+		reqQuery.Set("search", `title="`+t.Title+`"`)
+	} else {
+		reqQuery.Set("search", `name="`+t.Name+`"`)
+	}
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
+	if sendErr != nil {
+		return queryResponse, nil, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanTaxonomy for
+	// the results
+	results := []ForemanTaxonomy{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, nil, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, nil, jsonDecErr
+	}
+
+	return queryResponse, results, nil
+}
+
+// -----------------------------------------------------------------------------
+// Organizations
+// -----------------------------------------------------------------------------
+
+// CreateOrganization creates a new ForemanOrganization with the attributes of
+// the supplied ForemanOrganization reference and returns the created
+// ForemanOrganization reference.
+func (c *Client) CreateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/taxonomy.go#CreateOrganization")
+
+	t, err := c.createTaxonomy(ctx, OrganizationEndpointPrefix, "organization", &o.ForemanTaxonomy)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanOrganization{*t}, nil
+}
+
+// ReadOrganization reads the attributes of a ForemanOrganization identified by
+// the supplied ID and returns a ForemanOrganization reference.
+func (c *Client) ReadOrganization(ctx context.Context, id int) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/taxonomy.go#ReadOrganization")
+
+	t, err := c.readTaxonomy(ctx, OrganizationEndpointPrefix, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanOrganization{*t}, nil
+}
+
+// UpdateOrganization updates a ForemanOrganization's attributes. The
+// organization with the ID of the supplied ForemanOrganization will be updated.
+func (c *Client) UpdateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/taxonomy.go#UpdateOrganization")
+
+	t, err := c.updateTaxonomy(ctx, OrganizationEndpointPrefix, "organization", &o.ForemanTaxonomy)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanOrganization{*t}, nil
+}
+
+// DeleteOrganization deletes the ForemanOrganization identified by the supplied ID
+func (c *Client) DeleteOrganization(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/taxonomy.go#DeleteOrganization")
+
+	return c.deleteTaxonomy(ctx, OrganizationEndpointPrefix, id)
+}
+
+// QueryOrganization queries for a ForemanOrganization by the title or the name
+// of the supplied ForemanOrganization reference and returns a QueryResponse
+// struct containing query/response metadata and the matching organizations.
+func (c *Client) QueryOrganization(ctx context.Context, o *ForemanOrganization, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/taxonomy.go#QueryOrganization")
+
+	queryResponse, results, err := c.queryTaxonomy(ctx, OrganizationEndpointPrefix, &o.ForemanTaxonomy, opts)
+	if err != nil {
+		return queryResponse, err
+	}
+
+	// convert the search results from []ForemanTaxonomy to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = ForemanOrganization{val}
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
+// -----------------------------------------------------------------------------
+// Locations
+// -----------------------------------------------------------------------------
+
+// CreateLocation creates a new ForemanLocation with the attributes of the
+// supplied ForemanLocation reference and returns the created ForemanLocation
+// reference.
+func (c *Client) CreateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/taxonomy.go#CreateLocation")
+
+	t, err := c.createTaxonomy(ctx, LocationEndpointPrefix, "location", &l.ForemanTaxonomy)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanLocation{*t}, nil
+}
+
+// ReadLocation reads the attributes of a ForemanLocation identified by the
+// supplied ID and returns a ForemanLocation reference.
+func (c *Client) ReadLocation(ctx context.Context, id int) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/taxonomy.go#ReadLocation")
+
+	t, err := c.readTaxonomy(ctx, LocationEndpointPrefix, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanLocation{*t}, nil
+}
+
+// UpdateLocation updates a ForemanLocation's attributes. The location with the
+// ID of the supplied ForemanLocation will be updated.
+func (c *Client) UpdateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/taxonomy.go#UpdateLocation")
+
+	t, err := c.updateTaxonomy(ctx, LocationEndpointPrefix, "location", &l.ForemanTaxonomy)
+	if err != nil {
+		return nil, err
+	}
+	return &ForemanLocation{*t}, nil
+}
+
+// DeleteLocation deletes the ForemanLocation identified by the supplied ID
+func (c *Client) DeleteLocation(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/taxonomy.go#DeleteLocation")
+
+	return c.deleteTaxonomy(ctx, LocationEndpointPrefix, id)
+}
+
+// QueryLocation queries for a ForemanLocation by the title or the name of the
+// supplied ForemanLocation reference and returns a QueryResponse struct
+// containing query/response metadata and the matching locations.
+func (c *Client) QueryLocation(ctx context.Context, l *ForemanLocation, opts ...QueryOptions) (QueryResponse, error) {
+	log.Tracef("foreman/api/taxonomy.go#QueryLocation")
+
+	queryResponse, results, err := c.queryTaxonomy(ctx, LocationEndpointPrefix, &l.ForemanTaxonomy, opts)
+	if err != nil {
+		return queryResponse, err
+	}
+
+	// convert the search results from []ForemanTaxonomy to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = ForemanLocation{val}
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
@@ -237,7 +237,7 @@ func (c *Client) CreateTemplateInput(ctx context.Context, tiObj *ForemanTemplate
 	return &created, nil
 }
 
-func (c *Client) QueryTemplateInput(ctx context.Context, tiObj *ForemanTemplateInput) (QueryResponse, error) {
+func (c *Client) QueryTemplateInput(ctx context.Context, tiObj *ForemanTemplateInput, opts ...QueryOptions) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
 	qresp := QueryResponse{}
@@ -255,7 +255,7 @@ func (c *Client) QueryTemplateInput(ctx context.Context, tiObj *ForemanTemplateI
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &qresp)
+	err = c.SendAndParseQuery(req, &qresp, opts...)
 	if err != nil {
 		return qresp, err
 	}
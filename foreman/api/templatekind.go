@@ -67,7 +67,7 @@ func (c *Client) ReadTemplateKind(ctx context.Context, id int) (*ForemanTemplate
 // QueryTemplateKind queries for a ForemanTemplateKind based on the attributes
 // of the supplied ForemanTemplateKind reference and returns a QueryResponse
 // struct containing query/response metadata and the matching template kinds
-func (c *Client) QueryTemplateKind(ctx context.Context, t *ForemanTemplateKind) (QueryResponse, error) {
+func (c *Client) QueryTemplateKind(ctx context.Context, t *ForemanTemplateKind, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/templatekind.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -89,7 +89,7 @@ func (c *Client) QueryTemplateKind(ctx context.Context, t *ForemanTemplateKind)
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+// rateLimiter spaces requests evenly to stay below a number of requests per
+// second. It is shared by all resources using the client.
+type rateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	// Earliest time the next request may be sent
+	next time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+	}
+}
+
+// reserve returns how long the caller has to wait before sending a request
+// and reserves the slot for it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return delay
+}
+
+// throttle blocks until the request may be sent according to the rate limit
+// and the maximum number of in-flight requests. The returned function
+// releases the in-flight slot and has to be called once the response has
+// been read.
+func (client *Client) throttle(request *http.Request) (func(), error) {
+	ctx := request.Context()
+
+	if client.rateLimiter != nil {
+		if delay := client.rateLimiter.reserve(); delay > 0 {
+			log.Infof(
+				"Throttling %s %s for %s to stay below %.2f requests per second",
+				request.Method,
+				request.URL,
+				delay,
+				client.clientConfig.RateLimit,
+			)
+			if sleepErr := SleepContext(ctx, delay); sleepErr != nil {
+				return nil, sleepErr
+			}
+		}
+	}
+
+	if client.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case client.inFlight <- struct{}{}:
+	default:
+		log.Infof(
+			"Throttling %s %s until one of %d in-flight requests finished",
+			request.Method,
+			request.URL,
+			cap(client.inFlight),
+		)
+		select {
+		case client.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return func() { <-client.inFlight }, nil
+}
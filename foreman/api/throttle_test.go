@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Ensures the rate limiter spaces the requests by the configured interval
+func TestRateLimiter_Reserve(t *testing.T) {
+	limiter := newRateLimiter(10)
+
+	if delay := limiter.reserve(); delay != 0 {
+		t.Errorf("First reservation has to wait [%s], expected [0s]", delay)
+	}
+	for i := 1; i <= 3; i++ {
+		expected := time.Duration(i) * 100 * time.Millisecond
+		delay := limiter.reserve()
+		if delay < expected-10*time.Millisecond || delay > expected {
+			t.Errorf("Reservation [%d] has to wait [%s], expected about [%s]", i, delay, expected)
+		}
+	}
+}
+
+// Ensures the client sends no more than the configured number of requests
+// at the same time
+func TestSend_MaxConcurrentRequests(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{MaxConcurrentRequests: 2})
+	defer server.Close()
+
+	var mutex sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mutex.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts", nil)
+			client.Send(req)
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("Server saw [%d] concurrent requests, expected [2]", maxInFlight)
+	}
+}
+
+// Ensures the rate limit delays the requests of the client
+func TestSend_RateLimit(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{RateLimit: 50})
+	defer server.Close()
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/hosts", func(w http.ResponseWriter, r *http.Request) {})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/hosts", nil)
+		client.Send(req)
+	}
+
+	// The first request is sent right away, the others 20ms apart
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 requests took [%s] with 50 requests per second, expected at least [80ms]", elapsed)
+	}
+}
+
+// Ensures a throttled request gives up when its context is cancelled
+func TestSend_ThrottleContextCancelled(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{MaxConcurrentRequests: 1})
+	defer server.Close()
+
+	// Occupy the only in-flight slot
+	client.inFlight <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := client.NewRequestWithContext(ctx, http.MethodGet, "/hosts", nil)
+	if _, _, err := client.Send(req); err == nil {
+		t.Errorf("Send() returned no error for a throttled request with a cancelled context")
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+// TLSConfig builds the TLS configuration of the REST client from the client
+// configuration. The CA bundle is added to the system's certificate pool,
+// the client certificate and key are presented to the server for mutual TLS.
+// An error is returned for PEM data which cannot be parsed or for a client
+// certificate without a key and vice versa.
+func (cfg ClientConfig) TLSConfig() (*tls.Config, error) {
+	log.Tracef("foreman/api/tls.go#TLSConfig")
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.TLSInsecureEnabled,
+		ServerName:         cfg.TLSServerName,
+	}
+
+	if len(cfg.TLSCABundle) > 0 {
+		rootCAs, poolErr := x509.SystemCertPool()
+		if poolErr != nil {
+			log.Warningf("Unable to load the system certificate pool: %s", poolErr)
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(cfg.TLSCABundle) {
+			return nil, fmt.Errorf("the CA bundle does not contain any PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(cfg.TLSClientCert) > 0 || len(cfg.TLSClientKey) > 0 {
+		if len(cfg.TLSClientCert) == 0 || len(cfg.TLSClientKey) == 0 {
+			return nil, fmt.Errorf("a client certificate requires both the certificate and its key")
+		}
+		clientCert, certErr := tls.X509KeyPair(cfg.TLSClientCert, cfg.TLSClientKey)
+		if certErr != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", certErr)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	stdlog "log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dpotapov/go-spnego"
+)
+
+// newTestClientCertificate creates a self-signed client certificate and
+// returns the PEM encoded certificate and key
+func newTestClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, keyErr := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if keyErr != nil {
+		t.Fatalf("Unable to generate key: %s", keyErr)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, certErr := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if certErr != nil {
+		t.Fatalf("Unable to create certificate: %s", certErr)
+	}
+	keyDER, marshalErr := x509.MarshalECPrivateKey(key)
+	if marshalErr != nil {
+		t.Fatalf("Unable to marshal key: %s", marshalErr)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// newTestTLSServer starts a TLS server which requires a client certificate
+// signed by clientCA and returns the server together with the PEM encoded
+// server certificate
+func newTestTLSServer(clientCA []byte) (*httptest.Server, []byte) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCA)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	// the handshake errors of the failing cases are expected
+	server.Config.ErrorLog = stdlog.New(io.Discard, "", 0)
+	server.StartTLS()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, serverCA
+}
+
+// Ensures the client trusts the CA bundle, presents the client certificate
+// and verifies the server against the configured server name
+func TestNewClient_TLS(t *testing.T) {
+	clientCert, clientKey := newTestClientCertificate(t)
+	server, serverCA := newTestTLSServer(clientCert)
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	cases := []struct {
+		name    string
+		conf    ClientConfig
+		success bool
+	}{
+		{
+			"mutual TLS",
+			ClientConfig{TLSCABundle: serverCA, TLSClientCert: clientCert, TLSClientKey: clientKey},
+			true,
+		},
+		{
+			"server name override",
+			ClientConfig{TLSCABundle: serverCA, TLSClientCert: clientCert, TLSClientKey: clientKey, TLSServerName: "example.com"},
+			true,
+		},
+		{
+			"wrong server name",
+			ClientConfig{TLSCABundle: serverCA, TLSClientCert: clientCert, TLSClientKey: clientKey, TLSServerName: "foreman.invalid"},
+			false,
+		},
+		{
+			"missing client certificate",
+			ClientConfig{TLSCABundle: serverCA},
+			false,
+		},
+		{
+			"untrusted server",
+			ClientConfig{TLSClientCert: clientCert, TLSClientKey: clientKey},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		client := NewClient(Server{URL: *serverURL}, ClientCredentials{}, c.conf)
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/status", nil)
+		sendErr := client.SendAndParse(req, nil)
+
+		if c.success && sendErr != nil {
+			t.Errorf("[%s] request failed: %s", c.name, sendErr)
+		}
+		if !c.success && sendErr == nil {
+			t.Errorf("[%s] request succeeded, expected a TLS error", c.name)
+		}
+	}
+}
+
+// Ensures the TLS options are applied to the spnego transport as well
+func TestNewClient_TLSNegotiateTransport(t *testing.T) {
+	clientCert, clientKey := newTestClientCertificate(t)
+	conf := ClientConfig{
+		NegotiateAuthEnabled: true,
+		TLSClientCert:        clientCert,
+		TLSClientKey:         clientKey,
+		TLSServerName:        "foreman.example.com",
+	}
+
+	client := NewClient(Server{}, ClientCredentials{}, conf)
+	transCfg, ok := client.httpClient.Transport.(*spnego.Transport)
+	if !ok {
+		t.Fatalf("Client transport is [%T], expected [*spnego.Transport]", client.httpClient.Transport)
+	}
+	tlsCfg := transCfg.TLSClientConfig
+	if tlsCfg.ServerName != conf.TLSServerName || len(tlsCfg.Certificates) != 1 {
+		t.Errorf(
+			"Client did not set TLS config on the negotiate transport, got server name [%s] and [%d] certificates",
+			tlsCfg.ServerName,
+			len(tlsCfg.Certificates),
+		)
+	}
+}
+
+// Ensures invalid PEM data and incomplete client certificates are rejected
+func TestClientConfig_TLSConfigErrors(t *testing.T) {
+	clientCert, clientKey := newTestClientCertificate(t)
+
+	cases := []struct {
+		name string
+		conf ClientConfig
+	}{
+		{"invalid CA bundle", ClientConfig{TLSCABundle: []byte("not a certificate")}},
+		{"certificate without key", ClientConfig{TLSClientCert: clientCert}},
+		{"key without certificate", ClientConfig{TLSClientKey: clientKey}},
+		{"mismatching key", ClientConfig{TLSClientCert: clientCert, TLSClientKey: clientCert}},
+	}
+
+	for _, c := range cases {
+		if _, err := c.conf.TLSConfig(); err == nil {
+			t.Errorf("[%s] ClientConfig.TLSConfig() returned no error", c.name)
+		}
+	}
+}
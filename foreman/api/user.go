@@ -22,6 +22,8 @@ const (
 type ForemanUser struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Organizations and locations the user has access to
+	ForemanTaxonomyIds
 
 	// login name (i.e: username)
 	Login string `json:"login"`
@@ -55,11 +57,69 @@ type ForemanUser struct {
 	// locale setting for user
 	Locale string `json:"locale,omitempty"`
 
-	// list of all locations for user
-	LocationIds []int `json:"location_ids,omitempty"`
+	// list of all roles assigned to the user, without the default role
+	RoleIds []int `json:"role_ids,omitempty"`
+}
+
+// Implement the Marshaler interface
+func (fu ForemanUser) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/user.go#MarshalJSON")
+
+	fuMap := map[string]interface{}{}
 
-	// list of all organisation for user
-	OrganizationIds []int `json:"organization_ids,omitempty"`
+	fuMap["login"] = fu.Login
+	fuMap["auth_source_id"] = fu.AuthSourceId
+	if fu.Admin {
+		fuMap["admin"] = fu.Admin
+	}
+	if fu.Firstname != "" {
+		fuMap["firstname"] = fu.Firstname
+	}
+	if fu.Lastname != "" {
+		fuMap["lastname"] = fu.Lastname
+	}
+	if fu.Mail != "" {
+		fuMap["mail"] = fu.Mail
+	}
+	if fu.Description != "" {
+		fuMap["description"] = fu.Description
+	}
+	if fu.Password != "" {
+		fuMap["password"] = fu.Password
+	}
+	if fu.DefaultLocationId != 0 {
+		fuMap["default_location_id"] = fu.DefaultLocationId
+	}
+	if fu.DefaultOrganizationId != 0 {
+		fuMap["default_organization_id"] = fu.DefaultOrganizationId
+	}
+	if fu.Locale != "" {
+		fuMap["locale"] = fu.Locale
+	}
+	// only replace the taxonomies of the user if they are set, an empty list
+	// removes all of them
+	if fu.LocationIds != nil {
+		fuMap["location_ids"] = fu.LocationIds
+	}
+	if fu.OrganizationIds != nil {
+		fuMap["organization_ids"] = fu.OrganizationIds
+	}
+
+	// only replace the roles of the user if they are set, an empty list
+	// removes all of them
+	if fu.RoleIds != nil {
+		fuMap["role_ids"] = fu.RoleIds
+	}
+
+	log.Debugf("fuMap: [%v]", fuMap)
+
+	return json.Marshal(fuMap)
+}
+
+// foremanUserDecode struct used for JSON decode.
+type foremanUserDecode struct {
+	ForemanUser
+	RolesDecode []EntityResponse `json:"roles"`
 }
 
 // -----------------------------------------------------------------------------
@@ -92,15 +152,17 @@ func (c *Client) CreateUser(ctx context.Context, u *ForemanUser) (*ForemanUser,
 		return nil, reqErr
 	}
 
-	var createdUser ForemanUser
+	var createdUser foremanUserDecode
 	sendErr := c.SendAndParse(req, &createdUser)
 	if sendErr != nil {
 		return nil, sendErr
 	}
 
+	createdUser.RoleIds = roleIdsFromEntityResponse(createdUser.RolesDecode)
+
 	log.Debugf("createdUser: [%+v]", createdUser)
 
-	return &createdUser, nil
+	return &createdUser.ForemanUser, nil
 }
 
 // ReadUser reads the attributes of a ForemanUser identified by the
@@ -120,15 +182,17 @@ func (c *Client) ReadUser(ctx context.Context, id int) (*ForemanUser, error) {
 		return nil, reqErr
 	}
 
-	var readUser ForemanUser
+	var readUser foremanUserDecode
 	sendErr := c.SendAndParse(req, &readUser)
 	if sendErr != nil {
 		return nil, sendErr
 	}
 
+	readUser.RoleIds = roleIdsFromEntityResponse(readUser.RolesDecode)
+
 	log.Debugf("readUser: [%+v]", readUser)
 
-	return &readUser, nil
+	return &readUser.ForemanUser, nil
 }
 
 // UpdateUser updates a ForemanUser's attributes.  The user with
@@ -157,15 +221,17 @@ func (c *Client) UpdateUser(ctx context.Context, u *ForemanUser) (*ForemanUser,
 		return nil, reqErr
 	}
 
-	var updatedUser ForemanUser
+	var updatedUser foremanUserDecode
 	sendErr := c.SendAndParse(req, &updatedUser)
 	if sendErr != nil {
 		return nil, sendErr
 	}
 
+	updatedUser.RoleIds = roleIdsFromEntityResponse(updatedUser.RolesDecode)
+
 	log.Debugf("updatedUser: [%+v]", updatedUser)
 
-	return &updatedUser, nil
+	return &updatedUser.ForemanUser, nil
 }
 
 // DeleteUser deletes the ForemanUser identified by the supplied ID
@@ -190,7 +256,7 @@ func (c *Client) DeleteUser(ctx context.Context, id int) error {
 // QueryUser queries for a ForemanUser based on the attributes of the
 // supplied ForemanUser reference and returns a QueryResponse struct
 // containing query/response metadata and the matching subnets
-func (c *Client) QueryUser(ctx context.Context, s *ForemanUser) (QueryResponse, error) {
+func (c *Client) QueryUser(ctx context.Context, s *ForemanUser, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/user.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -227,7 +293,7 @@ func (c *Client) QueryUser(ctx context.Context, s *ForemanUser) (QueryResponse,
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -25,6 +25,19 @@ type ForemanUsergroup struct {
 
 	// enables or disables admin access for group members, Must be one of: true, false, 1, 0.
 	Admin bool `json:"admin"`
+
+	// IDs of the roles assigned to the usergroup
+	RoleIds []int `json:"role_ids"`
+
+	// IDs of the users that are members of the usergroup
+	UserIds []int `json:"user_ids"`
+
+	// IDs of the usergroups nested in the usergroup
+	UsergroupIds []int `json:"usergroup_ids"`
+
+	// External (LDAP) groups mapped to the usergroup. They are managed through
+	// their own endpoint and are not sent with the usergroup.
+	ExternalUsergroups []ForemanExternalUsergroup `json:"external_usergroups"`
 }
 
 // Implement the Marshaler interface
@@ -39,6 +52,21 @@ func (fh ForemanUsergroup) MarshalJSON() ([]byte, error) {
 	fhMap["name"] = fh.Name
 	fhMap["admin"] = fh.Admin
 
+	// only replace the roles of the usergroup if they are set, an empty list
+	// removes all of them
+	if fh.RoleIds != nil {
+		fhMap["role_ids"] = fh.RoleIds
+	}
+
+	// only replace the members of the usergroup if they are set, an empty
+	// list removes all of them
+	if fh.UserIds != nil {
+		fhMap["user_ids"] = fh.UserIds
+	}
+	if fh.UsergroupIds != nil {
+		fhMap["usergroup_ids"] = fh.UsergroupIds
+	}
+
 	log.Debugf("fhMap: [%v]", fhMap)
 
 	return json.Marshal(fhMap)
@@ -67,6 +95,26 @@ func (fh *ForemanUsergroup) UnmarshalJSON(b []byte) error {
 		fh.Admin = false
 	}
 
+	// Unmarshal the roles and members into their IDs
+	var fhRelations struct {
+		Roles              []EntityResponse                 `json:"roles"`
+		Users              []EntityResponse                 `json:"users"`
+		Usergroups         []EntityResponse                 `json:"usergroups"`
+		ExternalUsergroups []foremanExternalUsergroupDecode `json:"external_usergroups"`
+	}
+	jsonDecErr = json.Unmarshal(b, &fhRelations)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fh.RoleIds = roleIdsFromEntityResponse(fhRelations.Roles)
+	fh.UserIds, _ = entityResponseIds(fhRelations.Users)
+	fh.UsergroupIds, _ = entityResponseIds(fhRelations.Usergroups)
+
+	fh.ExternalUsergroups = nil
+	for _, eu := range fhRelations.ExternalUsergroups {
+		fh.ExternalUsergroups = append(fh.ExternalUsergroups, eu.toForemanExternalUsergroup())
+	}
+
 	return nil
 }
 
@@ -202,7 +250,7 @@ func (c *Client) DeleteUsergroup(ctx context.Context, id int) error {
 // QueryUsergroup queries for a ForemanUsergroup based on the attributes of the
 // supplied ForemanUsergroup reference and returns a QueryResponse struct
 // containing query/response metadata and the matching usergroups.
-func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup) (QueryResponse, error) {
+func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/usergroup.go#Search")
 
 	queryResponse := QueryResponse{}
@@ -224,7 +272,7 @@ func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup) (Query
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
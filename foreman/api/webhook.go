@@ -263,7 +263,7 @@ func (c *Client) DeleteWebhook(ctx context.Context, id int) error {
 // QueryWebhook queries for a ForemanWebhook based on the attributes of
 // the supplied ForemanWebhook reference and returns a QueryResponse struct
 // containing query/response metadata and the matching templates.
-func (c *Client) QueryWebhook(ctx context.Context, t *ForemanWebhook) (QueryResponse, error) {
+func (c *Client) QueryWebhook(ctx context.Context, t *ForemanWebhook, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/webhook.go#Query")
 
 	queryResponse := QueryResponse{}
@@ -285,7 +285,7 @@ func (c *Client) QueryWebhook(ctx context.Context, t *ForemanWebhook) (QueryResp
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
@@ -179,7 +179,7 @@ func (c *Client) DeleteWebhookTemplate(ctx context.Context, id int) error {
 // the attributes of the supplied ForemanWebhookTemplate reference and
 // returns a QueryResponse struct containing query/response metadata and the
 // matching templates.
-func (c *Client) QueryWebhookTemplate(ctx context.Context, t *ForemanWebhookTemplate) (QueryResponse, error) {
+func (c *Client) QueryWebhookTemplate(ctx context.Context, t *ForemanWebhookTemplate, opts ...QueryOptions) (QueryResponse, error) {
 	log.Tracef("foreman/api/webhooktemplate.go#Query")
 
 	queryResponse := QueryResponse{}
@@ -201,7 +201,7 @@ func (c *Client) QueryWebhookTemplate(ctx context.Context, t *ForemanWebhookTemp
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	sendErr := c.SendAndParseQuery(req, &queryResponse, opts...)
 	if sendErr != nil {
 		return queryResponse, sendErr
 	}
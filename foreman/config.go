@@ -1,6 +1,11 @@
 package foreman
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
@@ -18,6 +23,13 @@ type Config struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information.
 	ClientTLSInsecure bool
+	// CA bundle, client certificate and client key, each either a path to a
+	// PEM file or the inline PEM data
+	ClientTLSCABundle string
+	ClientTLSCert     string
+	ClientTLSKey      string
+	// Overrides the hostname the server's certificate is verified against
+	ClientTLSServerName string
 	// Whether or not the client should try to authenticate to foreman
 	// through the HTTP negotiate mechanism.
 	NegotiateAuthEnabled bool
@@ -27,6 +39,19 @@ type Config struct {
 	LocationID int
 	// Organization for all API Calls
 	OrganizationID int
+	// Polling behaviour for asynchronous Foreman tasks
+	TaskPollInterval      time.Duration
+	TaskPollMaxInterval   time.Duration
+	TaskPollBackoffFactor float64
+	// Retry behaviour for transient HTTP failures
+	RetryMaxAttempts   int
+	RetryMaxElapsed    time.Duration
+	RetryNonIdempotent bool
+	// Client-side throttling of the requests
+	RateLimit             float64
+	MaxConcurrentRequests int
+	// Page size when walking the pages of a query
+	QueryPerPage int
 }
 
 // Client creates a client reference for the Foreman REST API given the
@@ -36,18 +61,70 @@ type Config struct {
 func (c *Config) Client() (*api.Client, diag.Diagnostics) {
 	log.Tracef("config.go#Client")
 
+	clientConfig := api.ClientConfig{
+		TLSInsecureEnabled:   c.ClientTLSInsecure,
+		TLSServerName:        c.ClientTLSServerName,
+		LocationID:           c.LocationID,
+		OrganizationID:       c.OrganizationID,
+		NegotiateAuthEnabled: c.NegotiateAuthEnabled,
+
+		TaskPollInterval:      c.TaskPollInterval,
+		TaskPollMaxInterval:   c.TaskPollMaxInterval,
+		TaskPollBackoffFactor: c.TaskPollBackoffFactor,
+
+		RetryMaxAttempts:   c.RetryMaxAttempts,
+		RetryMaxElapsed:    c.RetryMaxElapsed,
+		RetryNonIdempotent: c.RetryNonIdempotent,
+
+		RateLimit:             c.RateLimit,
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+
+		QueryPerPage: c.QueryPerPage,
+	}
+
+	var pemErr error
+	if clientConfig.TLSCABundle, pemErr = readPEM(c.ClientTLSCABundle); pemErr != nil {
+		return nil, invalidTLSConfigDiagnostics(fmt.Errorf("client_tls_ca_bundle: %w", pemErr))
+	}
+	if clientConfig.TLSClientCert, pemErr = readPEM(c.ClientTLSCert); pemErr != nil {
+		return nil, invalidTLSConfigDiagnostics(fmt.Errorf("client_tls_cert: %w", pemErr))
+	}
+	if clientConfig.TLSClientKey, pemErr = readPEM(c.ClientTLSKey); pemErr != nil {
+		return nil, invalidTLSConfigDiagnostics(fmt.Errorf("client_tls_key: %w", pemErr))
+	}
+	if _, tlsErr := clientConfig.TLSConfig(); tlsErr != nil {
+		return nil, invalidTLSConfigDiagnostics(tlsErr)
+	}
+
 	client := api.NewClient(
 		c.Server,
 		c.ClientCredentials,
-		api.ClientConfig{
-			TLSInsecureEnabled:   c.ClientTLSInsecure,
-			LocationID:           c.LocationID,
-			OrganizationID:       c.OrganizationID,
-			NegotiateAuthEnabled: c.NegotiateAuthEnabled,
-		},
+		clientConfig,
 	)
 
 	log.Debugf("Rest Client configured")
 
 	return client, diag.Diagnostics{}
 }
+
+// readPEM returns the PEM data of a TLS option. Values containing a PEM
+// header are used as they are, any other value is read as a file path.
+func readPEM(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+func invalidTLSConfigDiagnostics(err error) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid TLS configuration",
+			Detail:   err.Error(),
+		},
+	}
+}
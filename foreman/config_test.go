@@ -0,0 +1,39 @@
+package foreman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Ensures TLS options are read from files unless they contain inline PEM data
+func TestReadPEM(t *testing.T) {
+	inline := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if writeErr := os.WriteFile(path, []byte(inline), 0600); writeErr != nil {
+		t.Fatalf("Unable to write PEM file: %s", writeErr)
+	}
+
+	for _, value := range []string{inline, path} {
+		data, readErr := readPEM(value)
+		if readErr != nil || string(data) != inline {
+			t.Errorf("readPEM(%q) returned [%s] and error [%v], expected [%s]", value, data, readErr, inline)
+		}
+	}
+
+	if data, _ := readPEM(""); data != nil {
+		t.Errorf("readPEM(\"\") returned [%s], expected nil", data)
+	}
+	if _, readErr := readPEM(filepath.Join(t.TempDir(), "missing.pem")); readErr == nil {
+		t.Errorf("readPEM() returned no error for a missing file")
+	}
+}
+
+// Ensures invalid TLS options fail the client creation
+func TestConfigClient_InvalidTLS(t *testing.T) {
+	config := Config{ClientTLSCABundle: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----\n"}
+	client, diags := config.Client()
+	if client != nil || !diags.HasError() {
+		t.Errorf("Config.Client() returned [%v] and [%+v], expected an error", client, diags)
+	}
+}
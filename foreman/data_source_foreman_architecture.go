@@ -29,6 +29,8 @@ func dataSourceForemanArchitecture() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanArchitectureRead,
@@ -46,15 +48,13 @@ func dataSourceForemanArchitectureRead(ctx context.Context, d *schema.ResourceDa
 
 	log.Debugf("ForemanArchitecture: [%+v]", arch)
 
-	queryResponse, queryErr := client.QueryArchitecture(ctx, arch)
+	queryResponse, queryErr := client.QueryArchitecture(ctx, arch, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source architecture returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source architecture returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "architecture", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryArch api.ForemanArchitecture
@@ -74,3 +74,24 @@ func dataSourceForemanArchitectureRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+func dataSourceForemanArchitectures() *schema.Resource {
+	return listDataSource{
+		object:        "architecture",
+		objects:       "architectures",
+		searchExample: `name ~ "x86"`,
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryArchitecture(ctx, &api.ForemanArchitecture{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanArchitecture)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,106 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanAuthSourceLDAP() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanAuthSourceLDAP()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// the bind password is never returned by Foreman
+	delete(ds, "account_password")
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the authentication source. "+
+				"%s \"Corporate LDAP\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearchSchema(ds, "name")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanAuthSourceLDAPRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanAuthSourceLDAPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	queryResponse, queryErr := client.QueryAuthSourceLDAP(ctx, a, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "auth_source_ldap", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryAuthSource api.ForemanAuthSourceLDAP
+	var ok bool
+	if queryAuthSource, ok = queryResponse.Results[0].(api.ForemanAuthSourceLDAP); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanAuthSourceLDAP], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	a = &queryAuthSource
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, a)
+
+	return nil
+}
+
+func dataSourceForemanAuthSourceLDAPs() *schema.Resource {
+	return listDataSource{
+		object:        "LDAP authentication source",
+		objects:       "auth_source_ldaps",
+		searchExample: `host ~ "example.com"`,
+		attributes: map[string]*schema.Schema{
+			"host": listAttribute(schema.TypeString, "Host name of the LDAP server."),
+			"port": listAttribute(schema.TypeInt, "Port of the LDAP server."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryAuthSourceLDAP(ctx, &api.ForemanAuthSourceLDAP{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanAuthSourceLDAP)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+				"host": o.Host,
+				"port": o.Port,
+			}, true
+		},
+	}.resource()
+}
@@ -29,6 +29,8 @@ func dataSourceForemanCommonParameter() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanCommonParameterRead,
@@ -46,15 +48,13 @@ func dataSourceForemanCommonParameterRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanCommonParameter: [%+v]", commonParameter)
 
-	queryResponse, queryErr := client.QueryCommonParameter(ctx, commonParameter)
+	queryResponse, queryErr := client.QueryCommonParameter(ctx, commonParameter, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source common_parameter returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source common_parameter returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "common_parameter", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryCommonParameter api.ForemanCommonParameter
@@ -74,3 +74,28 @@ func dataSourceForemanCommonParameterRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+func dataSourceForemanGlobalParameters() *schema.Resource {
+	return listDataSource{
+		object:        "global parameter",
+		objects:       "global_parameters",
+		searchExample: `name ~ "ntp"`,
+		attributes: map[string]*schema.Schema{
+			"value": listAttribute(schema.TypeString, "Value of the global parameter."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryCommonParameter(ctx, &api.ForemanCommonParameter{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanCommonParameter)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":    o.Id,
+				"name":  o.Name,
+				"value": o.Value,
+			}, true
+		},
+	}.resource()
+}
@@ -27,6 +27,8 @@ func dataSourceForemanComputeProfile() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanComputeProfileRead,
 		Schema:      ds,
@@ -45,15 +47,13 @@ func dataSourceForemanComputeProfileRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanComputeProfile: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryComputeProfile(ctx, t)
+	queryResponse, queryErr := client.QueryComputeProfile(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source template kind returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source template kind returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "template kind", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryComputeProfile api.ForemanComputeProfile
@@ -73,3 +73,24 @@ func dataSourceForemanComputeProfileRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+func dataSourceForemanComputeProfiles() *schema.Resource {
+	return listDataSource{
+		object:        "compute profile",
+		objects:       "computeprofiles",
+		searchExample: `name ~ "small"`,
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryComputeProfile(ctx, &api.ForemanComputeProfile{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanComputeProfile)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			}, true
+		},
+	}.resource()
+}
@@ -25,6 +25,8 @@ func dataSourceForemanComputeResource() *schema.Resource {
 		Description: fmt.Sprintf("The name of the compute resource. %s", autodoc.MetaExample),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanComputeResourceRead,
@@ -42,15 +44,13 @@ func dataSourceForemanComputeResourceRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanComputeResource: [%+v]", computeresource)
 
-	queryResponse, queryErr := client.QueryComputeResource(ctx, computeresource)
+	queryResponse, queryErr := client.QueryComputeResource(ctx, computeresource, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source computeresource returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source computeresource returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "computeresource", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryComputeResource api.ForemanComputeResource
@@ -70,3 +70,30 @@ func dataSourceForemanComputeResourceRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+func dataSourceForemanComputeResources() *schema.Resource {
+	return listDataSource{
+		object:        "compute resource",
+		objects:       "computeresources",
+		searchExample: `name ~ "vmware"`,
+		attributes: map[string]*schema.Schema{
+			"provider": listAttribute(schema.TypeString, "Provider of the compute resource, e.g. \"Vmware\"."),
+			"url":      listAttribute(schema.TypeString, "URL of the compute resource."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryComputeResource(ctx, &api.ForemanComputeResource{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanComputeResource)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":       o.Id,
+				"name":     o.Name,
+				"provider": o.Provider,
+				"url":      o.URL,
+			}, true
+		},
+	}.resource()
+}
@@ -29,6 +29,8 @@ func dataSourceForemanDefaultTemplate() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanDefaultTemplateRead,
@@ -46,15 +48,13 @@ func dataSourceForemanDefaultTemplateRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanDefaultTemplate: [%+v]", defaultTemplate)
 
-	queryResponse, queryErr := client.QueryDefaultTemplate(ctx, defaultTemplate)
+	queryResponse, queryErr := client.QueryDefaultTemplate(ctx, defaultTemplate, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source defaultTemplate returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source defaultTemplate returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "defaultTemplate", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryDefaultTemplate api.ForemanDefaultTemplate
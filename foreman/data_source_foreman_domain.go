@@ -29,6 +29,8 @@ func dataSourceForemanDomain() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanDomainRead,
@@ -46,15 +48,13 @@ func dataSourceForemanDomainRead(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanDomain: [%+v]", domain)
 
-	queryResponse, queryErr := client.QueryDomain(ctx, domain)
+	queryResponse, queryErr := client.QueryDomain(ctx, domain, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source domain returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source domain returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "domain", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryDomain api.ForemanDomain
@@ -74,3 +74,28 @@ func dataSourceForemanDomainRead(ctx context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+func dataSourceForemanDomains() *schema.Resource {
+	return listDataSource{
+		object:        "domain",
+		objects:       "domains",
+		searchExample: `name ~ "example.com"`,
+		attributes: map[string]*schema.Schema{
+			"fullname": listAttribute(schema.TypeString, "Full name of the domain."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryDomain(ctx, &api.ForemanDomain{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanDomain)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":       o.Id,
+				"name":     o.Name,
+				"fullname": o.Fullname,
+			}, true
+		},
+	}.resource()
+}
@@ -29,6 +29,8 @@ func dataSourceForemanEnvironment() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanEnvironmentRead,
@@ -46,15 +48,13 @@ func dataSourceForemanEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 
 	log.Debugf("ForemanEnvironment: [%+v]", e)
 
-	queryResponse, queryErr := client.QueryEnvironment(ctx, e)
+	queryResponse, queryErr := client.QueryEnvironment(ctx, e, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source environment returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source environment returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "environment", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryEnvironment api.ForemanEnvironment
@@ -74,3 +74,24 @@ func dataSourceForemanEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+func dataSourceForemanEnvironments() *schema.Resource {
+	return listDataSource{
+		object:        "environment",
+		objects:       "environments",
+		searchExample: `name ~ "production"`,
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryEnvironment(ctx, &api.ForemanEnvironment{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanEnvironment)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			}, true
+		},
+	}.resource()
+}
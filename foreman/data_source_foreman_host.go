@@ -0,0 +1,212 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanHost() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanHost()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// Remove the attributes which only control how the resource manages the
+	// host, the power state which is only read by sending a power command and
+	// the root password which cannot be read
+	for _, attr := range []string{
+		"root_password",
+		"set_build_flag",
+		"manage_power_operations",
+		"power_state",
+		"power_actions",
+		"power_actions_on_rebuild",
+		"rebuild_triggers",
+		"rebuild_orchestration",
+		"wait_for_build",
+		"wait_for_config_report",
+		"retry_count",
+		"bmc_success",
+		"enable_bmc",
+	} {
+		delete(ds, attr)
+	}
+
+	ds[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Looks up a host in Foreman, including hosts which are not "+
+				"managed by Terraform, with its interfaces, parameters, facts and status.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "fqdn"},
+		Description: fmt.Sprintf(
+			"Name of the host as stored in Foreman. Can be the short name or "+
+				"the FQDN, depending on the Foreman setting 'append_domain_name_for_hosts'. "+
+				"%s \"compute01.dc1.company.com\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["fqdn"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "fqdn"},
+		Description: "Fully qualified domain name of the host. Matches the host " +
+			"regardless of whether Foreman stores its name as short name or FQDN.",
+	}
+
+	addDataSourceSearchSchema(ds, "name", "fqdn")
+
+	ds["ip"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "IP address of the primary interface of the host.",
+	}
+	ds["mac"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "MAC address of the primary interface of the host.",
+	}
+	ds["build"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the host is set to be built on its next boot.",
+	}
+	ds["build_status"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Build status of the host. BUILT = 0, PENDING = 1, TOKEN_EXPIRED = 2, BUILD_FAILED = 3.",
+	}
+	ds["build_status_label"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Label of the build status of the host, e.g. \"Installed\".",
+	}
+	ds["global_status"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Overall status of the host. OK = 0, WARN = 1, ERROR = 2.",
+	}
+	ds["global_status_label"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Label of the overall status of the host, e.g. \"OK\".",
+	}
+	ds["all_parameters"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "Parameters of the host including the ones inherited from its " +
+			"hostgroup, operating system, domain, subnet, location, organization and " +
+			"the global parameters. The parameters of the host itself are in `parameters`.",
+	}
+	ds["facts"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "Facts of the host, e.g. the ones reported by Puppet, Ansible or " +
+			"subscription-manager. Nested facts are flattened into names like " +
+			"`networking::ip`.",
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanHostRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+// hostFQDNSearch returns the Foreman scoped search matching the host with the
+// supplied FQDN, whether its name is stored as FQDN or as short name
+func hostFQDNSearch(fqdn string) string {
+	search := fmt.Sprintf("name = %q", fqdn)
+	if shortname, domain, found := strings.Cut(fqdn, "."); found {
+		search += fmt.Sprintf(" or (name = %q and domain = %q)", shortname, domain)
+	}
+	return search
+}
+
+func dataSourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_host.go#Read")
+
+	client := meta.(*api.Client)
+	h := &api.ForemanHost{}
+	h.Name = d.Get("name").(string)
+
+	queryOpts := dataSourceQueryOptions(d)
+	if fqdn, ok := d.GetOk("fqdn"); ok {
+		queryOpts = api.SearchQueryOptions(hostFQDNSearch(fqdn.(string)))
+	}
+
+	log.Debugf("ForemanHost: [%+v]", h)
+
+	queryResponse, queryErr := client.QueryHost(ctx, h, queryOpts)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "host", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryHost api.ForemanHost
+	var ok bool
+	if queryHost, ok = queryResponse.Results[0].(api.ForemanHost); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanHost], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// The search results do not contain the interfaces and parameters
+	readHost, readErr := client.ReadHost(ctx, queryHost.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	facts, factsErr := client.ReadHostFacts(ctx, queryHost.Id)
+	if factsErr != nil {
+		return diag.FromErr(factsErr)
+	}
+
+	log.Debugf("ForemanHost: [%+v]", readHost)
+
+	if err := setResourceDataFromForemanHost(d, readHost); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("ip", readHost.IP)
+	d.Set("mac", readHost.MAC)
+	d.Set("build", readHost.Build)
+	d.Set("build_status", readHost.BuildStatus)
+	d.Set("build_status_label", readHost.BuildStatusLabel)
+	d.Set("global_status", readHost.GlobalStatus)
+	d.Set("global_status_label", readHost.GlobalStatusLabel)
+	d.Set("all_parameters", api.FromKV(readHost.AllParameters))
+	d.Set("facts", facts)
+
+	return nil
+}
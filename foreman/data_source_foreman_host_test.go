@@ -0,0 +1,131 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures the host data source reads the host found by its search with its
+// interfaces, inherited parameters, facts and status
+func TestDataSourceForemanHostRead(t *testing.T) {
+	cases := []struct {
+		config map[string]interface{}
+		search string
+	}{
+		{
+			config: map[string]interface{}{"name": "foremanterraformtest.dev.company.com"},
+			search: `name="foremanterraformtest.dev.company.com"`,
+		},
+		{
+			config: map[string]interface{}{"fqdn": "foremanterraformtest.dev.company.com"},
+			search: `name = "foremanterraformtest.dev.company.com" or ` +
+				`(name = "foremanterraformtest" and domain = "dev.company.com")`,
+		},
+		{
+			config: map[string]interface{}{"search": "hostgroup_id = 98"},
+			search: "hostgroup_id = 98",
+		},
+	}
+
+	responses := map[string]string{
+		HostsURI:            HostsTestDataPath + "/query_response_single.json",
+		HostsURI + "/34068": HostsTestDataPath + "/read_response.json",
+		HostsURI + "/34068/vm_compute_attributes": TestDataPath + "/query_response_zero.json",
+		HostsURI + "/34068/facts":                 HostsTestDataPath + "/facts_response.json",
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var search string
+		for uri, file := range responses {
+			uri, file := uri, file
+			mux.HandleFunc(uri, func(w http.ResponseWriter, r *http.Request) {
+				if uri == HostsURI {
+					search = r.URL.Query().Get("search")
+				}
+				response, err := os.ReadFile(file)
+				if err != nil {
+					t.Errorf("Reading the response file %s failed: %s", file, err)
+					return
+				}
+				w.Write(response)
+			})
+		}
+
+		ds := dataSourceForemanHost()
+		d := schema.TestResourceDataRaw(t, ds.Schema, c.config)
+		diags := ds.ReadContext(context.TODO(), d, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Fatalf("Read of foreman_host with %v returned [%+v]", c.config, diags)
+		}
+		if search != c.search {
+			t.Errorf("Read of foreman_host with %v searched [%s], expected [%s]", c.config, search, c.search)
+		}
+
+		if d.Id() != "34068" || d.Get("fqdn") != "foremanterraformtest.dev.company.com" {
+			t.Errorf("Read of foreman_host set id [%s] and fqdn [%s]", d.Id(), d.Get("fqdn"))
+		}
+		if d.Get("ip") != "10.228.170.38" || d.Get("mac") != "c0:ff:ee:ba:be:00" {
+			t.Errorf("Read of foreman_host set ip [%s] and mac [%s]", d.Get("ip"), d.Get("mac"))
+		}
+		if d.Get("interfaces_attributes.0.id") != 46531 {
+			t.Errorf("Read of foreman_host set interfaces [%+v]", d.Get("interfaces_attributes"))
+		}
+		if d.Get("build_status_label") != "Pending installation" || d.Get("global_status_label") != "OK" {
+			t.Errorf(
+				"Read of foreman_host set build status [%s] and global status [%s]",
+				d.Get("build_status_label"),
+				d.Get("global_status_label"),
+			)
+		}
+
+		expectedParameters := map[string]interface{}{
+			"serial_console": "ttyS1,115200n8",
+			"puppetmaster":   "puppet.company.com",
+		}
+		if parameters := d.Get("all_parameters"); !reflect.DeepEqual(parameters, expectedParameters) {
+			t.Errorf("Read of foreman_host set all_parameters [%v], expected [%v]", parameters, expectedParameters)
+		}
+		expectedFacts := map[string]interface{}{
+			"networking::ip": "10.228.170.38",
+			"processorcount": "4",
+			"is_virtual":     "true",
+		}
+		if facts := d.Get("facts"); !reflect.DeepEqual(facts, expectedFacts) {
+			t.Errorf("Read of foreman_host set facts [%v], expected [%v]", facts, expectedFacts)
+		}
+	}
+}
+
+// Ensures the host data source is looked up by exactly one of name, fqdn
+// and search
+func TestDataSourceForemanHostValidate(t *testing.T) {
+	cases := []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"name": "host01"}, true},
+		{map[string]interface{}{"fqdn": "host01.example.com"}, true},
+		{map[string]interface{}{"search": "hostgroup_id = 1"}, true},
+		{map[string]interface{}{"name": "host01", "fqdn": "host01.example.com"}, false},
+		{map[string]interface{}{"fqdn": "host01.example.com", "search": "hostgroup_id = 1"}, false},
+		{map[string]interface{}{}, false},
+	}
+
+	for _, c := range cases {
+		diags := dataSourceForemanHost().Validate(terraform.NewResourceConfigRaw(c.config))
+		if diags.HasError() == c.valid {
+			t.Errorf("Validation of %v returned [%+v], expected valid [%t]", c.config, diags, c.valid)
+		}
+	}
+}
@@ -33,6 +33,8 @@ func dataSourceForemanHostgroup() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "title")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanHostgroupRead,
@@ -50,15 +52,13 @@ func dataSourceForemanHostgroupRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
-	queryResponse, queryErr := client.QueryHostgroup(ctx, h)
+	queryResponse, queryErr := client.QueryHostgroup(ctx, h, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source hostgroup returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source hostgroup returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "hostgroup", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryHostgroup api.ForemanHostgroup
@@ -78,3 +78,30 @@ func dataSourceForemanHostgroupRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func dataSourceForemanHostgroups() *schema.Resource {
+	return listDataSource{
+		object:        "hostgroup",
+		objects:       "hostgroups",
+		searchExample: `title ~ "web/%"`,
+		attributes: map[string]*schema.Schema{
+			"title":     listAttribute(schema.TypeString, "Title of the hostgroup, including the names of its parents."),
+			"parent_id": listAttribute(schema.TypeInt, "ID of the parent hostgroup, 0 for top-level hostgroups."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryHostgroup(ctx, &api.ForemanHostgroup{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanHostgroup)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":        o.Id,
+				"name":      o.Name,
+				"title":     o.Title,
+				"parent_id": o.ParentId,
+			}, true
+		},
+	}.resource()
+}
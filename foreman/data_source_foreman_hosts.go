@@ -0,0 +1,50 @@
+package foreman
+
+import (
+	"context"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanHosts() *schema.Resource {
+	return listDataSource{
+		object:        "host",
+		objects:       "hosts",
+		searchExample: `hostgroup_title ~ "web/%"`,
+		attributes: map[string]*schema.Schema{
+			"hostgroup_id":       listAttribute(schema.TypeInt, "ID of the hostgroup of the host."),
+			"operatingsystem_id": listAttribute(schema.TypeInt, "ID of the operating system of the host."),
+			"build_status_label": listAttribute(schema.TypeString, "Build status of the host, e.g. \"Installed\"."),
+			"ip":                 listAttribute(schema.TypeString, "IP address of the primary interface of the host."),
+			"mac":                listAttribute(schema.TypeString, "MAC address of the primary interface of the host."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryHost(ctx, &api.ForemanHost{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanHost)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":                 o.Id,
+				"name":               o.Name,
+				"hostgroup_id":       intValue(o.HostgroupId),
+				"operatingsystem_id": intValue(o.OperatingSystemId),
+				"build_status_label": o.BuildStatusLabel,
+				"ip":                 o.IP,
+				"mac":                o.MAC,
+			}, true
+		},
+	}.resource()
+}
+
+// intValue returns the value of an optional ID of the API, 0 if it is not set
+func intValue(id *int) int {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
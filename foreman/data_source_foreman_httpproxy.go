@@ -29,6 +29,8 @@ func dataSourceForemanHTTPProxy() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanHTTPProxyRead,
@@ -46,15 +48,13 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", s)
 
-	queryResponse, queryErr := client.QueryHTTPProxy(ctx, s)
+	queryResponse, queryErr := client.QueryHTTPProxy(ctx, s, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source smart proxy returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source smart proxy returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "smart proxy", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryHTTPProxy api.ForemanHTTPProxy
@@ -74,3 +74,28 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func dataSourceForemanHTTPProxies() *schema.Resource {
+	return listDataSource{
+		object:        "HTTP proxy",
+		objects:       "httpproxies",
+		searchExample: `name ~ "proxy"`,
+		attributes: map[string]*schema.Schema{
+			"url": listAttribute(schema.TypeString, "URL of the HTTP proxy."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryHTTPProxy(ctx, &api.ForemanHTTPProxy{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanHTTPProxy)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+				"url":  o.URL,
+			}, true
+		},
+	}.resource()
+}
@@ -30,6 +30,8 @@ func dataSourceForemanImage() *schema.Resource {
 		Description: "The id of the Compute Resource the image is associated with",
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanImageRead,
@@ -47,15 +49,13 @@ func dataSourceForemanImageRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanImage: [%+v]", image)
 
-	queryResponse, queryErr := client.QueryImage(ctx, image)
+	queryResponse, queryErr := client.QueryImage(ctx, image, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source image returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source image returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "image", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryImage api.ForemanImage
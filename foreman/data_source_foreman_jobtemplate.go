@@ -27,6 +27,8 @@ func dataSourceForemanJobTemplate() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanJobTemplateRead,
 		Schema:      ds,
@@ -39,15 +41,13 @@ func dataSourceForemanJobTemplateRead(ctx context.Context, d *schema.ResourceDat
 	client := meta.(*api.Client)
 	jt := buildForemanJobTemplate(d)
 
-	queryResponse, err := client.QueryJobTemplate(ctx, jt)
+	queryResponse, err := client.QueryJobTemplate(ctx, jt, dataSourceQueryOptions(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source job_template returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source job_template returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "job_template", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryJt api.ForemanJobTemplate
@@ -66,3 +66,30 @@ func dataSourceForemanJobTemplateRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+func dataSourceForemanJobTemplates() *schema.Resource {
+	return listDataSource{
+		object:        "job template",
+		objects:       "jobtemplates",
+		searchExample: `job_category = "Packages"`,
+		attributes: map[string]*schema.Schema{
+			"job_category":  listAttribute(schema.TypeString, "Category of the job template."),
+			"provider_type": listAttribute(schema.TypeString, "Provider of the job template, e.g. \"script\"."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryJobTemplate(ctx, &api.ForemanJobTemplate{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanJobTemplate)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":            o.Id,
+				"name":          o.Name,
+				"job_category":  o.JobCategory,
+				"provider_type": o.ProviderType,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,113 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+func dataSourceForemanKatelloActivationKey() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanKatelloActivationKey()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the activation key. "+
+				"%s \"rhel9-production\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["organization_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		Computed: true,
+		Description: "ID of the organization to search the activation key in. Defaults to the " +
+			"organization of the provider.",
+	}
+
+	addDataSourceSearchSchema(ds, "name")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanKatelloActivationKeyRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanKatelloActivationKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_katello_activation_key.go#Read")
+
+	client := meta.(*api.Client)
+	ak := buildForemanKatelloActivationKey(d)
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", ak)
+
+	queryResponse, queryErr := client.QueryKatelloActivationKey(ctx, ak, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "activation key", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	queryActivationKey, ok := queryResponse.Results[0].(api.ForemanKatelloActivationKey)
+	if !ok {
+		return diag.Errorf(
+			"data source results contain unexpected type. Expected "+
+				"[api.ForemanKatelloActivationKey], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// The search results do not contain all details of the key
+	readActivationKey, readErr := client.ReadKatelloActivationKey(ctx, queryActivationKey.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", readActivationKey)
+
+	setResourceDataFromForemanKatelloActivationKey(d, readActivationKey)
+
+	return nil
+}
+
+func dataSourceForemanKatelloActivationKeys() *schema.Resource {
+	return listDataSource{
+		object:        "activation key",
+		objects:       "katello_activation_keys",
+		searchExample: `name ~ "rhel"`,
+		attributes: map[string]*schema.Schema{
+			"content_view_id":          listAttribute(schema.TypeInt, "ID of the content view of the activation key."),
+			"lifecycle_environment_id": listAttribute(schema.TypeInt, "ID of the lifecycle environment of the activation key."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryKatelloActivationKey(ctx, &api.ForemanKatelloActivationKey{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanKatelloActivationKey)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":                       o.Id,
+				"name":                     o.Name,
+				"content_view_id":          o.ContentViewId,
+				"lifecycle_environment_id": o.EnvironmentId,
+			}, true
+		},
+	}.resource()
+}
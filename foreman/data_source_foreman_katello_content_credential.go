@@ -28,6 +28,8 @@ func dataSourceForemanKatelloContentCredential() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloContentCredentialRead,
@@ -45,15 +47,13 @@ func dataSourceForemanKatelloContentCredentialRead(ctx context.Context, d *schem
 
 	log.Debugf("ForemanKatelloContentCredential: [%+v]", contentCredential)
 
-	queryResponse, queryErr := client.QueryKatelloContentCredential(ctx, contentCredential)
+	queryResponse, queryErr := client.QueryKatelloContentCredential(ctx, contentCredential, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source smart proxy returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source smart proxy returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "smart proxy", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryKatelloContentCredential api.ForemanKatelloContentCredential
@@ -73,3 +73,24 @@ func dataSourceForemanKatelloContentCredentialRead(ctx context.Context, d *schem
 
 	return nil
 }
+
+func dataSourceForemanKatelloContentCredentials() *schema.Resource {
+	return listDataSource{
+		object:        "content credential",
+		objects:       "katello_content_credentials",
+		searchExample: `name ~ "gpg"`,
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryKatelloContentCredential(ctx, &api.ForemanKatelloContentCredential{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanKatelloContentCredential)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			}, true
+		},
+	}.resource()
+}
@@ -22,6 +22,8 @@ func dataSourceForemanKatelloContentView() *schema.Resource {
 		Description: fmt.Sprintf("Name of the content view. %s \"my content view\"", autodoc.MetaExample),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanKatelloContentViewRead,
 		Schema:      ds,
@@ -36,15 +38,13 @@ func dataSourceForemanKatelloContentViewRead(ctx context.Context, d *schema.Reso
 
 	utils.Debugf("cv: %+v", cv)
 
-	queryResponse, err := client.QueryContentView(ctx, cv)
+	queryResponse, err := client.QueryContentView(ctx, cv, dataSourceQueryOptions(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("data source content_view returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("data source content_view returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "content_view", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	if queryCv, ok := queryResponse.Results[0].(api.ContentView); !ok {
@@ -74,3 +74,32 @@ func dataSourceForemanKatelloContentViewRead(ctx context.Context, d *schema.Reso
 
 	return nil
 }
+
+func dataSourceForemanKatelloContentViews() *schema.Resource {
+	return listDataSource{
+		object:        "content view",
+		objects:       "katello_content_views",
+		searchExample: `composite = false`,
+		attributes: map[string]*schema.Schema{
+			"label":             listAttribute(schema.TypeString, "Label of the content view."),
+			"composite":         listAttribute(schema.TypeBool, "Whether the content view is a composite content view."),
+			"latest_version_id": listAttribute(schema.TypeInt, "ID of the latest version of the content view."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryContentView(ctx, &api.ContentView{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ContentView)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":                o.Id,
+				"name":              o.Name,
+				"label":             o.Label,
+				"composite":         o.Composite,
+				"latest_version_id": o.LatestVersionId,
+			}, true
+		},
+	}.resource()
+}
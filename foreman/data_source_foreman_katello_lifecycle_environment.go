@@ -22,6 +22,8 @@ func dataSourceForemanKatelloLifecycleEnvironment() *schema.Resource {
 		Description: fmt.Sprintf("Name of the lifecycle environment. %s \"Library\"", autodoc.MetaExample),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanKatelloLifecycleRead,
 		Schema:      ds,
@@ -36,15 +38,13 @@ func dataSourceForemanKatelloLifecycleRead(ctx context.Context, d *schema.Resour
 
 	utils.Debugf("lifecycle env: %+v", lce)
 
-	queryResponse, err := client.QueryLifecycleEnvironment(ctx, lce)
+	queryResponse, err := client.QueryLifecycleEnvironment(ctx, lce, dataSourceQueryOptions(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("data source lifecycle_environment returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("data source lifecycle_environment returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "lifecycle_environment", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	if queryLce, ok := queryResponse.Results[0].(api.LifecycleEnvironment); !ok {
@@ -63,3 +63,30 @@ func dataSourceForemanKatelloLifecycleRead(ctx context.Context, d *schema.Resour
 
 	return nil
 }
+
+func dataSourceForemanKatelloLifecycleEnvironments() *schema.Resource {
+	return listDataSource{
+		object:        "lifecycle environment",
+		objects:       "katello_lifecycle_environments",
+		searchExample: `library = false`,
+		attributes: map[string]*schema.Schema{
+			"label":    listAttribute(schema.TypeString, "Label of the lifecycle environment."),
+			"prior_id": listAttribute(schema.TypeInt, "ID of the prior lifecycle environment."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryLifecycleEnvironment(ctx, &api.LifecycleEnvironment{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.LifecycleEnvironment)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":       o.Id,
+				"name":     o.Name,
+				"label":    o.Label,
+				"prior_id": o.Prior.Id,
+			}, true
+		},
+	}.resource()
+}
@@ -28,6 +28,8 @@ func dataSourceForemanKatelloProduct() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloProductRead,
@@ -45,15 +47,13 @@ func dataSourceForemanKatelloProductRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanKatelloProduct: [%+v]", product)
 
-	queryResponse, queryErr := client.QueryKatelloProduct(ctx, product)
+	queryResponse, queryErr := client.QueryKatelloProduct(ctx, product, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("data source product returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("data source product returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "product", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryKatelloProduct api.ForemanKatelloProduct
@@ -73,3 +73,30 @@ func dataSourceForemanKatelloProductRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+func dataSourceForemanKatelloProducts() *schema.Resource {
+	return listDataSource{
+		object:        "product",
+		objects:       "katello_products",
+		searchExample: `name ~ "rhel"`,
+		attributes: map[string]*schema.Schema{
+			"label":        listAttribute(schema.TypeString, "Label of the product."),
+			"sync_plan_id": listAttribute(schema.TypeInt, "ID of the sync plan of the product."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryKatelloProduct(ctx, &api.ForemanKatelloProduct{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanKatelloProduct)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":           o.Id,
+				"name":         o.Name,
+				"label":        o.Label,
+				"sync_plan_id": o.SyncPlanId,
+			}, true
+		},
+	}.resource()
+}
@@ -28,6 +28,8 @@ func dataSourceForemanKatelloRepository() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloRepositoryRead,
@@ -45,15 +47,13 @@ func dataSourceForemanKatelloRepositoryRead(ctx context.Context, d *schema.Resou
 
 	log.Debugf("ForemanKatelloRepository: [%+v]", repository)
 
-	queryResponse, queryErr := client.QueryKatelloRepository(ctx, repository)
+	queryResponse, queryErr := client.QueryKatelloRepository(ctx, repository, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("data source repository returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("data source repository returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "repository", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryKatelloRepository api.ForemanKatelloRepository
@@ -73,3 +73,34 @@ func dataSourceForemanKatelloRepositoryRead(ctx context.Context, d *schema.Resou
 
 	return nil
 }
+
+func dataSourceForemanKatelloRepositories() *schema.Resource {
+	return listDataSource{
+		object:        "repository",
+		objects:       "katello_repositories",
+		searchExample: `product = "RHEL"`,
+		attributes: map[string]*schema.Schema{
+			"label":        listAttribute(schema.TypeString, "Label of the repository."),
+			"product_id":   listAttribute(schema.TypeInt, "ID of the product of the repository."),
+			"content_type": listAttribute(schema.TypeString, "Content type of the repository, e.g. \"yum\"."),
+			"url":          listAttribute(schema.TypeString, "Upstream URL of the repository."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryKatelloRepository(ctx, &api.ForemanKatelloRepository{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanKatelloRepository)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":           o.Id,
+				"name":         o.Name,
+				"label":        o.Label,
+				"product_id":   o.Product.Id,
+				"content_type": o.ContentType,
+				"url":          o.Url,
+			}, true
+		},
+	}.resource()
+}
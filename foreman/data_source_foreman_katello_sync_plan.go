@@ -28,6 +28,8 @@ func dataSourceForemanKatelloSyncPlan() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloSyncPlanRead,
@@ -45,15 +47,13 @@ func dataSourceForemanKatelloSyncPlanRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanKatelloSyncPlan: [%+v]", syncPlan)
 
-	queryResponse, queryErr := client.QueryKatelloSyncPlan(ctx, syncPlan)
+	queryResponse, queryErr := client.QueryKatelloSyncPlan(ctx, syncPlan, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("data source sync plan returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("data source sync plan returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "sync plan", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryKatelloSyncPlan api.ForemanKatelloSyncPlan
@@ -73,3 +73,30 @@ func dataSourceForemanKatelloSyncPlanRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+func dataSourceForemanKatelloSyncPlans() *schema.Resource {
+	return listDataSource{
+		object:        "sync plan",
+		objects:       "katello_sync_plans",
+		searchExample: `interval = daily`,
+		attributes: map[string]*schema.Schema{
+			"interval": listAttribute(schema.TypeString, "Interval of the sync plan."),
+			"enabled":  listAttribute(schema.TypeBool, "Whether the sync plan is enabled."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryKatelloSyncPlan(ctx, &api.ForemanKatelloSyncPlan{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanKatelloSyncPlan)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":       o.Id,
+				"name":     o.Name,
+				"interval": o.Interval,
+				"enabled":  o.Enabled,
+			}, true
+		},
+	}.resource()
+}
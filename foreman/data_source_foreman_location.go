@@ -0,0 +1,121 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanLocation() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanLocation()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "title"},
+		Description: fmt.Sprintf(
+			"Name of the location. "+
+				"%s \"Berlin\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "title"},
+		Description: fmt.Sprintf(
+			"Title of the location, including the names of its parents. Use it "+
+				"to look up nested locations. %s \"Europe/Berlin\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearchSchema(ds, "name", "title")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanLocationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", o)
+
+	queryResponse, queryErr := client.QueryLocation(ctx, o, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "location", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryLocation api.ForemanLocation
+	var ok bool
+	if queryLocation, ok = queryResponse.Results[0].(api.ForemanLocation); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanLocation], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// The search results do not contain the parameters
+	readLocation, readErr := client.ReadLocation(ctx, queryLocation.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanLocation: [%+v]", readLocation)
+
+	setResourceDataFromForemanLocation(d, readLocation)
+
+	return nil
+}
+
+func dataSourceForemanLocations() *schema.Resource {
+	return listDataSource{
+		object:        "location",
+		objects:       "locations",
+		searchExample: `title ~ "Europe/%"`,
+		attributes: map[string]*schema.Schema{
+			"title":     listAttribute(schema.TypeString, "Title of the location, including the names of its parents."),
+			"parent_id": listAttribute(schema.TypeInt, "ID of the parent location, 0 for top-level locations."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryLocation(ctx, &api.ForemanLocation{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanLocation)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":        o.Id,
+				"name":      o.Name,
+				"title":     o.Title,
+				"parent_id": o.ParentId,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,128 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanLocationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanLocationRead",
+				crudFunc:     dataSourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    LocationsURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanLocationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanLocationRead",
+			crudFunc:     dataSourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanLocationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanLocationRead",
+			crudFunc:     dataSourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanLocationEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanLocationRead",
+			crudFunc:     dataSourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanLocationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanLocationRead",
+				crudFunc:     dataSourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanLocationRead",
+				crudFunc:     dataSourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data
+		// source read, then the operation should succeed. The mock server
+		// answers the following read by ID with the search response as well,
+		// so the resulting attributes are not compared.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanLocationRead",
+				crudFunc:     dataSourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/query_response_single.json",
+			returnError:  false,
+		},
+	}
+
+}
@@ -29,6 +29,8 @@ func dataSourceForemanMedia() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanMediaRead,
@@ -46,15 +48,13 @@ func dataSourceForemanMediaRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanMedia: [%+v]", m)
 
-	queryResponse, queryErr := client.QueryMedia(ctx, m)
+	queryResponse, queryErr := client.QueryMedia(ctx, m, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source media returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source media returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "media", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryMedia api.ForemanMedia
@@ -74,3 +74,30 @@ func dataSourceForemanMediaRead(ctx context.Context, d *schema.ResourceData, met
 
 	return nil
 }
+
+func dataSourceForemanMediaList() *schema.Resource {
+	return listDataSource{
+		object:        "installation medium",
+		objects:       "media",
+		searchExample: `os_family = Redhat`,
+		attributes: map[string]*schema.Schema{
+			"path":      listAttribute(schema.TypeString, "Path of the installation medium."),
+			"os_family": listAttribute(schema.TypeString, "Operating system family of the installation medium."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryMedia(ctx, &api.ForemanMedia{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanMedia)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":        o.Id,
+				"name":      o.Name,
+				"path":      o.Path,
+				"os_family": o.OSFamily,
+			}, true
+		},
+	}.resource()
+}
@@ -29,6 +29,8 @@ func dataSourceForemanModel() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanModelRead,
@@ -46,15 +48,13 @@ func dataSourceForemanModelRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanModel: [%+v]", m)
 
-	queryResponse, queryErr := client.QueryModel(ctx, m)
+	queryResponse, queryErr := client.QueryModel(ctx, m, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source model returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source model returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "model", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryModel api.ForemanModel
@@ -74,3 +74,28 @@ func dataSourceForemanModelRead(ctx context.Context, d *schema.ResourceData, met
 
 	return nil
 }
+
+func dataSourceForemanModels() *schema.Resource {
+	return listDataSource{
+		object:        "hardware model",
+		objects:       "models",
+		searchExample: `name ~ "ProLiant"`,
+		attributes: map[string]*schema.Schema{
+			"hardware_model": listAttribute(schema.TypeString, "Hardware model name."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryModel(ctx, &api.ForemanModel{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanModel)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":             o.Id,
+				"name":           o.Name,
+				"hardware_model": o.HardwareModel,
+			}, true
+		},
+	}.resource()
+}
@@ -31,6 +31,8 @@ func dataSourceForemanOperatingSystem() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "title")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanOperatingSystemRead,
@@ -48,15 +50,13 @@ func dataSourceForemanOperatingSystemRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", o)
 
-	queryResponse, queryErr := client.QueryOperatingSystem(ctx, o)
+	queryResponse, queryErr := client.QueryOperatingSystem(ctx, o, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source operating system returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source operating system returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "operating system", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryOS api.ForemanOperatingSystem
@@ -76,3 +76,34 @@ func dataSourceForemanOperatingSystemRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+func dataSourceForemanOperatingSystems() *schema.Resource {
+	return listDataSource{
+		object:        "operating system",
+		objects:       "operatingsystems",
+		searchExample: `family = Redhat`,
+		attributes: map[string]*schema.Schema{
+			"title":  listAttribute(schema.TypeString, "Title of the operating system."),
+			"major":  listAttribute(schema.TypeString, "Major version of the operating system."),
+			"minor":  listAttribute(schema.TypeString, "Minor version of the operating system."),
+			"family": listAttribute(schema.TypeString, "Family of the operating system."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryOperatingSystem(ctx, &api.ForemanOperatingSystem{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanOperatingSystem)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":     o.Id,
+				"name":   o.Name,
+				"title":  o.Title,
+				"major":  o.Major,
+				"minor":  o.Minor,
+				"family": o.Family,
+			}, true
+		},
+	}.resource()
+}
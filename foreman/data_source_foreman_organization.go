@@ -0,0 +1,121 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanOrganization() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanOrganization()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "title"},
+		Description: fmt.Sprintf(
+			"Name of the organization. "+
+				"%s \"ACME\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "title"},
+		Description: fmt.Sprintf(
+			"Title of the organization, including the names of its parents. Use it "+
+				"to look up nested organizations. %s \"ACME/Engineering\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearchSchema(ds, "name", "title")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanOrganizationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	queryResponse, queryErr := client.QueryOrganization(ctx, o, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "organization", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryOrganization api.ForemanOrganization
+	var ok bool
+	if queryOrganization, ok = queryResponse.Results[0].(api.ForemanOrganization); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanOrganization], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// The search results do not contain the parameters
+	readOrganization, readErr := client.ReadOrganization(ctx, queryOrganization.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
+
+func dataSourceForemanOrganizations() *schema.Resource {
+	return listDataSource{
+		object:        "organization",
+		objects:       "organizations",
+		searchExample: `title ~ "ACME/%"`,
+		attributes: map[string]*schema.Schema{
+			"title":     listAttribute(schema.TypeString, "Title of the organization, including the names of its parents."),
+			"parent_id": listAttribute(schema.TypeInt, "ID of the parent organization, 0 for top-level organizations."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryOrganization(ctx, &api.ForemanOrganization{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanOrganization)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":        o.Id,
+				"name":      o.Name,
+				"title":     o.Title,
+				"parent_id": o.ParentId,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,128 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanOrganizationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanOrganizationRead",
+				crudFunc:     dataSourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    OrganizationsURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanOrganizationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanOrganizationRead",
+			crudFunc:     dataSourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanOrganizationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanOrganizationRead",
+			crudFunc:     dataSourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanOrganizationEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanOrganizationRead",
+			crudFunc:     dataSourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanOrganizationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanOrganizationRead",
+				crudFunc:     dataSourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanOrganizationRead",
+				crudFunc:     dataSourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data
+		// source read, then the operation should succeed. The mock server
+		// answers the following read by ID with the search response as well,
+		// so the resulting attributes are not compared.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanOrganizationRead",
+				crudFunc:     dataSourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/query_response_single.json",
+			returnError:  false,
+		},
+	}
+
+}
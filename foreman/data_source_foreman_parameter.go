@@ -29,6 +29,8 @@ func dataSourceForemanParameter() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanParameterRead,
@@ -46,15 +48,13 @@ func dataSourceForemanParameterRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanParameter: [%+v]", parameter)
 
-	queryResponse, queryErr := client.QueryParameter(ctx, parameter)
+	queryResponse, queryErr := client.QueryParameter(ctx, parameter, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source parameter returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source parameter returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "parameter", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryParameter api.ForemanParameter
@@ -29,6 +29,8 @@ func dataSourceForemanPartitionTable() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanPartitionTableRead,
@@ -46,15 +48,13 @@ func dataSourceForemanPartitionTableRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanPartitionTable: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryPartitionTable(ctx, t)
+	queryResponse, queryErr := client.QueryPartitionTable(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source partition table returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source partition table returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "partition table", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryPartitionTable api.ForemanPartitionTable
@@ -74,3 +74,30 @@ func dataSourceForemanPartitionTableRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+func dataSourceForemanPartitionTables() *schema.Resource {
+	return listDataSource{
+		object:        "partition table",
+		objects:       "partitiontables",
+		searchExample: `os_family = Redhat`,
+		attributes: map[string]*schema.Schema{
+			"os_family": listAttribute(schema.TypeString, "Operating system family of the partition table."),
+			"snippet":   listAttribute(schema.TypeBool, "Whether the partition table is a snippet."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryPartitionTable(ctx, &api.ForemanPartitionTable{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanPartitionTable)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":        o.Id,
+				"name":      o.Name,
+				"os_family": o.OSFamily,
+				"snippet":   o.Snippet,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,142 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanPermission() *schema.Resource {
+	r := &schema.Resource{
+
+		ReadContext: dataSourceForemanPermissionRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Permissions are predefined by Foreman and its plugins and "+
+						"granted to roles through filters, see `foreman_filter`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the permission. "+
+						"%s \"view_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"resource_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource type the permission applies to, e.g. `Host`.",
+			},
+		},
+	}
+
+	addDataSourceSearchSchema(r.Schema, "name")
+
+	return r
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanPermission constructs a ForemanPermission reference from a
+// resource data reference. The struct's members are populated from the data
+// populated in the resource data. Missing members will be left to the zero
+// value for that member's type.
+func buildForemanPermission(d *schema.ResourceData) *api.ForemanPermission {
+	p := api.ForemanPermission{}
+	obj := buildForemanObject(d)
+	p.ForemanObject = *obj
+	p.ResourceType = d.Get("resource_type").(string)
+	return &p
+}
+
+// setResourceDataFromForemanPermission sets a ResourceData's attributes from
+// the attributes of the supplied ForemanPermission reference
+func setResourceDataFromForemanPermission(d *schema.ResourceData, fp *api.ForemanPermission) {
+	d.SetId(strconv.Itoa(fp.Id))
+	d.Set("name", fp.Name)
+	d.Set("resource_type", fp.ResourceType)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanPermissionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_permission.go#Read")
+
+	client := meta.(*api.Client)
+	p := buildForemanPermission(d)
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	queryResponse, queryErr := client.QueryPermission(ctx, p, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "permission", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryPermission api.ForemanPermission
+	var ok bool
+	if queryPermission, ok = queryResponse.Results[0].(api.ForemanPermission); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanPermission], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	p = &queryPermission
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	setResourceDataFromForemanPermission(d, p)
+
+	return nil
+}
+
+func dataSourceForemanPermissions() *schema.Resource {
+	return listDataSource{
+		object:        "permission",
+		objects:       "permissions",
+		searchExample: `resource_type = Host`,
+		attributes: map[string]*schema.Schema{
+			"resource_type": listAttribute(schema.TypeString, "Resource type of the permission."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryPermission(ctx, &api.ForemanPermission{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanPermission)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":            o.Id,
+				"name":          o.Name,
+				"resource_type": o.ResourceType,
+			}, true
+		},
+	}.resource()
+}
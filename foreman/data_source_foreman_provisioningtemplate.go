@@ -29,6 +29,8 @@ func dataSourceForemanProvisioningTemplate() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanProvisioningTemplateRead,
@@ -46,15 +48,13 @@ func dataSourceForemanProvisioningTemplateRead(ctx context.Context, d *schema.Re
 
 	log.Debugf("ForemanProvisioningTemplate: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryProvisioningTemplate(ctx, t)
+	queryResponse, queryErr := client.QueryProvisioningTemplate(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source provisioning template returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source provisioning template returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "provisioning template", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryTemplate api.ForemanProvisioningTemplate
@@ -74,3 +74,30 @@ func dataSourceForemanProvisioningTemplateRead(ctx context.Context, d *schema.Re
 
 	return nil
 }
+
+func dataSourceForemanProvisioningTemplates() *schema.Resource {
+	return listDataSource{
+		object:        "provisioning template",
+		objects:       "provisioningtemplates",
+		searchExample: `kind = provision`,
+		attributes: map[string]*schema.Schema{
+			"template_kind_id": listAttribute(schema.TypeInt, "ID of the template kind."),
+			"snippet":          listAttribute(schema.TypeBool, "Whether the template is a snippet."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryProvisioningTemplate(ctx, &api.ForemanProvisioningTemplate{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanProvisioningTemplate)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":               o.Id,
+				"name":             o.Name,
+				"template_kind_id": o.TemplateKindId,
+				"snippet":          o.Snippet,
+			}, true
+		},
+	}.resource()
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func dataSourceForemanPuppetClass() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		ReadContext: dataSourceForemanPuppetClassRead,
 
@@ -39,6 +39,10 @@ func dataSourceForemanPuppetClass() *schema.Resource {
 			},
 		},
 	}
+
+	addDataSourceSearchSchema(r.Schema, "name")
+
+	return r
 }
 
 // -----------------------------------------------------------------------------
@@ -75,15 +79,13 @@ func dataSourceForemanPuppetClassRead(ctx context.Context, d *schema.ResourceDat
 
 	log.Debugf("ForemanPuppetClass: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryPuppetClass(ctx, t)
+	queryResponse, queryErr := client.QueryPuppetClass(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source puppet class returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source puppet class returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "puppet class", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryPuppetClass api.ForemanPuppetClass
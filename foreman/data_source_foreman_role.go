@@ -0,0 +1,101 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanRole() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanRole()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the role, can also be one of the builtin roles. "+
+				"%s \"Viewer\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearchSchema(ds, "name")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanRoleRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	queryResponse, queryErr := client.QueryRole(ctx, r, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "role", queryResponse); diags.HasError() {
+		return diags
+	}
+
+	var queryRole api.ForemanRole
+	var ok bool
+	if queryRole, ok = queryResponse.Results[0].(api.ForemanRole); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanRole], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	r = &queryRole
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	setResourceDataFromForemanRole(d, r)
+
+	return nil
+}
+
+func dataSourceForemanRoles() *schema.Resource {
+	return listDataSource{
+		object:        "role",
+		objects:       "roles",
+		searchExample: `builtin = false`,
+		attributes: map[string]*schema.Schema{
+			"description": listAttribute(schema.TypeString, "Description of the role."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryRole(ctx, &api.ForemanRole{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanRole)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":          o.Id,
+				"name":        o.Name,
+				"description": o.Description,
+			}, true
+		},
+	}.resource()
+}
@@ -81,6 +81,8 @@ func dataSourceForemanSetting() *schema.Resource {
 		},
 	}
 
+	addDataSourceSearchSchema(dataSourceSchema, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanSettingRead,
 		Schema:      dataSourceSchema,
@@ -101,15 +103,13 @@ func dataSourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, m
 
 	log.Debugf("ForemanSetting: [%+v]", setting)
 
-	queryResponse, queryErr := client.QuerySetting(ctx, setting)
+	queryResponse, queryErr := client.QuerySetting(ctx, setting, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source setting returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source setting returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "setting", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var querySetting api.ForemanSetting
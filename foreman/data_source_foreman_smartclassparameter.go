@@ -14,7 +14,7 @@ import (
 )
 
 func dataSourceForemanSmartClassParameter() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		ReadContext: dataSourceForemanSmartClassParameterRead,
 
@@ -50,6 +50,10 @@ func dataSourceForemanSmartClassParameter() *schema.Resource {
 			},
 		},
 	}
+
+	addDataSourceSearchSchema(r.Schema, "parameter")
+
+	return r
 }
 
 // -----------------------------------------------------------------------------
@@ -91,15 +95,13 @@ func dataSourceForemanSmartClassParameterRead(ctx context.Context, d *schema.Res
 
 	log.Debugf("ForemanSmartClassParameter: [%+v]", t)
 
-	queryResponse, queryErr := client.QuerySmartClassParameter(ctx, t)
+	queryResponse, queryErr := client.QuerySmartClassParameter(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source smart class parameter returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source smart class parameter returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "smart class parameter", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var querySmartClassParameter api.ForemanSmartClassParameter
@@ -29,6 +29,8 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanSmartProxyRead,
@@ -46,15 +48,13 @@ func dataSourceForemanSmartProxyRead(ctx context.Context, d *schema.ResourceData
 
 	log.Debugf("ForemanSmartProxy: [%+v]", s)
 
-	queryResponse, queryErr := client.QuerySmartProxy(ctx, s)
+	queryResponse, queryErr := client.QuerySmartProxy(ctx, s, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source smart proxy returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source smart proxy returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "smart proxy", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var querySmartProxy api.ForemanSmartProxy
@@ -74,3 +74,28 @@ func dataSourceForemanSmartProxyRead(ctx context.Context, d *schema.ResourceData
 
 	return nil
 }
+
+func dataSourceForemanSmartProxies() *schema.Resource {
+	return listDataSource{
+		object:        "smart proxy",
+		objects:       "smartproxies",
+		searchExample: `feature = DHCP`,
+		attributes: map[string]*schema.Schema{
+			"url": listAttribute(schema.TypeString, "URL of the smart proxy."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QuerySmartProxy(ctx, &api.ForemanSmartProxy{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanSmartProxy)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+				"url":  o.URL,
+			}, true
+		},
+	}.resource()
+}
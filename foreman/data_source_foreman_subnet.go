@@ -39,6 +39,8 @@ func dataSourceForemanSubnet() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name", "network")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanSubnetRead,
@@ -56,15 +58,13 @@ func dataSourceForemanSubnetRead(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
 
-	queryResponse, queryErr := client.QuerySubnet(ctx, s)
+	queryResponse, queryErr := client.QuerySubnet(ctx, s, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source subnet returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source subnet returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "subnet", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var querySubnet api.ForemanSubnet
@@ -84,3 +84,32 @@ func dataSourceForemanSubnetRead(ctx context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+func dataSourceForemanSubnets() *schema.Resource {
+	return listDataSource{
+		object:        "subnet",
+		objects:       "subnets",
+		searchExample: `name ~ "dmz"`,
+		attributes: map[string]*schema.Schema{
+			"network": listAttribute(schema.TypeString, "Network address of the subnet."),
+			"mask":    listAttribute(schema.TypeString, "Netmask of the subnet."),
+			"vlanid":  listAttribute(schema.TypeInt, "VLAN ID of the subnet."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QuerySubnet(ctx, &api.ForemanSubnet{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanSubnet)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":      o.Id,
+				"name":    o.Name,
+				"network": o.Network,
+				"mask":    o.Mask,
+				"vlanid":  o.VlanID,
+			}, true
+		},
+	}.resource()
+}
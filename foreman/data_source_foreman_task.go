@@ -0,0 +1,189 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanTask() *schema.Resource {
+	ds := foremanTaskSchema()
+
+	ds[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Looks up a Foreman task by its UUID or by searching for its label, state, "+
+				"result or the resource it belongs to.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	// The UUID identifies a single task, the search attributes may be combined
+	searchAttributes := []string{"uuid", "search_label", "search_state", "search_result", "resource_type", "resource_id", "search"}
+
+	// define searchable attributes for the data source
+	ds["uuid"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: searchAttributes[1:],
+		Description: fmt.Sprintf(
+			"UUID of the task. "+
+				"%s \"0b9c0a2e-5a6d-4d41-b0a5-0d2b3b9e3c17\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["search_label"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description: fmt.Sprintf(
+			"Search for tasks with this label. "+
+				"%s \"Actions::Katello::Repository::Sync\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["search_state"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description:   "Search for tasks in this state, e.g. `running` or `stopped`.",
+	}
+	ds["search_result"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description:   "Search for tasks with this result, e.g. `success` or `error`.",
+	}
+	ds["resource_type"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description: fmt.Sprintf(
+			"Search for tasks belonging to resources of this type. "+
+				"%s \"Katello::Repository\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["resource_id"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description:   "Search for tasks belonging to the resource with this ID.",
+	}
+	ds["search"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		AtLeastOneOf:  searchAttributes,
+		ConflictsWith: []string{"uuid"},
+		Description: "Additional scoped search query for tasks, combined with the other " +
+			"search attributes.",
+	}
+	ds["most_recent"] = &schema.Schema{
+		Type:          schema.TypeBool,
+		Optional:      true,
+		Default:       false,
+		ConflictsWith: []string{"uuid"},
+		Description: "If the search matches more than one task, use the most recently " +
+			"started one instead of failing. Defaults to `false`.",
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanTaskRead,
+
+		Schema: ds,
+	}
+}
+
+// buildForemanTaskSearch constructs the scoped search query for tasks from
+// the search attributes of the data source
+func buildForemanTaskSearch(d *schema.ResourceData) string {
+	var terms []string
+
+	if attr, ok := d.GetOk("search_label"); ok {
+		terms = append(terms, fmt.Sprintf("label = \"%s\"", attr.(string)))
+	}
+	if attr, ok := d.GetOk("search_state"); ok {
+		terms = append(terms, fmt.Sprintf("state = %s", attr.(string)))
+	}
+	if attr, ok := d.GetOk("search_result"); ok {
+		terms = append(terms, fmt.Sprintf("result = %s", attr.(string)))
+	}
+	if attr, ok := d.GetOk("resource_type"); ok {
+		terms = append(terms, fmt.Sprintf("resource_type = \"%s\"", attr.(string)))
+	}
+	if attr, ok := d.GetOk("resource_id"); ok {
+		terms = append(terms, fmt.Sprintf("resource_id = %d", attr.(int)))
+	}
+	if attr, ok := d.GetOk("search"); ok {
+		terms = append(terms, "("+attr.(string)+")")
+	}
+
+	return strings.Join(terms, " and ")
+}
+
+func dataSourceForemanTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_task.go#Read")
+
+	client := meta.(*api.Client)
+
+	if uuid, ok := d.GetOk("uuid"); ok {
+		task, readErr := client.ReadForemanTask(ctx, uuid.(string))
+		if readErr != nil {
+			return diag.FromErr(readErr)
+		}
+
+		log.Debugf("ForemanTask: [%+v]", task)
+
+		setResourceDataFromForemanTask(d, task)
+		return nil
+	}
+
+	search := buildForemanTaskSearch(d)
+	log.Debugf("search: [%s]", search)
+
+	queryResponse, queryErr := client.QueryForemanTask(ctx, search, d.Get("most_recent").(bool))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if len(queryResponse.Results) == 0 {
+		return diag.Errorf("Data source task returned no results")
+	} else if len(queryResponse.Results) > 1 && !d.Get("most_recent").(bool) {
+		return diag.Errorf(
+			"Data source task returned %d results. Refine the search or "+
+				"set most_recent to use the most recently started task",
+			len(queryResponse.Results),
+		)
+	}
+
+	// Results are ordered by their start time, newest first
+	queryTask, ok := queryResponse.Results[0].(api.ForemanTask)
+	if !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanTask], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	log.Debugf("ForemanTask: [%+v]", queryTask)
+
+	setResourceDataFromForemanTask(d, &queryTask)
+
+	return nil
+}
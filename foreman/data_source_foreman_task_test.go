@@ -0,0 +1,256 @@
+package foreman
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const ForemanTasksURI = api.ForemanTaskEndpointPrefix
+const ForemanTasksTestDataPath = "testdata/3.11/foreman_tasks"
+
+// Given a ForemanTask, create a mock instance state reference
+func ForemanTaskToInstanceState(obj api.ForemanTask) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = obj.Id
+	// Build the attribute map from ForemanTask
+	attr := map[string]string{}
+	attr["label"] = obj.Label
+	attr["action"] = obj.Action
+	attr["state"] = obj.State
+	attr["result"] = obj.Result
+	attr["pending"] = strconv.FormatBool(obj.Pending)
+	attr["progress"] = strconv.FormatFloat(obj.Progress, 'f', -1, 64)
+	attr["username"] = obj.Username
+	attr["started_at"] = obj.StartedAt
+	attr["ended_at"] = obj.EndedAt
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanTask data source, create a mock
+// ResourceData reference.
+func MockForemanTaskResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := dataSourceForemanTask()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a task ResourceData
+// reference
+func MockForemanTaskResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanTask
+	ParseJSONFile(t, path, &obj)
+	s := ForemanTaskToInstanceState(obj)
+	return MockForemanTaskResourceData(s)
+}
+
+// Creates a mock instance state searching for tasks by label
+func ForemanTaskSearchInstanceState() *terraform.InstanceState {
+	state := ForemanTaskToInstanceState(api.ForemanTask{})
+	state.Attributes["search_label"] = "Actions::Katello::Repository::Sync"
+	return state
+}
+
+// Creates a mock instance state looking up a task by its UUID
+func ForemanTaskUUIDInstanceState() *terraform.InstanceState {
+	state := ForemanTaskToInstanceState(api.ForemanTask{})
+	state.Attributes["uuid"] = "a3c6b1f4-0d4e-4b6f-9a73-4f3c2b8c1d55"
+	return state
+}
+
+// Compares two ResourceData references for a ForemanTask data source. If the
+// two references differ in their attributes, the test will raise a fatal.
+func ForemanTaskResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map - only the computed task attributes, the
+	// search attributes are not part of the API response
+	m := map[string]schema.ValueType{}
+	for key, value := range foremanTaskSchema() {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanTaskCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    ForemanTasksURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskUUIDInstanceState()),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    ForemanTasksURI + "/a3c6b1f4-0d4e-4b6f-9a73-4f3c2b8c1d55",
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanTaskRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanTaskRead",
+			crudFunc:     dataSourceForemanTaskRead,
+			resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanTaskStatusCodeTestCases(t *testing.T) []TestCase {
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanTaskRead",
+			crudFunc:     dataSourceForemanTaskRead,
+			resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+		},
+		{
+			funcName:     "dataSourceForemanTaskRead",
+			crudFunc:     dataSourceForemanTaskRead,
+			resourceData: MockForemanTaskResourceData(ForemanTaskUUIDInstanceState()),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanTaskEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanTaskRead",
+			crudFunc:     dataSourceForemanTaskRead,
+			resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+		},
+		{
+			funcName:     "dataSourceForemanTaskRead",
+			crudFunc:     dataSourceForemanTaskRead,
+			resourceData: MockForemanTaskResourceData(ForemanTaskUUIDInstanceState()),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanTaskMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	mostRecent := ForemanTaskSearchInstanceState()
+	mostRecent.Attributes["most_recent"] = "true"
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+			},
+			responseFile: ForemanTasksTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If more than one task matches and most_recent is set, the most
+		// recently started task is used
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(mostRecent),
+			},
+			responseFile: ForemanTasksTestDataPath + "/query_response_multi.json",
+			returnError:  false,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+			},
+			responseFile: ForemanTasksTestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskSearchInstanceState()),
+			},
+			responseFile: ForemanTasksTestDataPath + "/query_response_single.json",
+			returnError:  false,
+			expectedResourceData: MockForemanTaskResourceDataFromFile(
+				t,
+				ForemanTasksTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanTaskResourceDataCompare,
+		},
+		// Reading a task by its UUID sets the attributes from the task
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanTaskRead",
+				crudFunc:     dataSourceForemanTaskRead,
+				resourceData: MockForemanTaskResourceData(ForemanTaskUUIDInstanceState()),
+			},
+			responseFile: ForemanTasksTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanTaskResourceDataFromFile(
+				t,
+				ForemanTasksTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanTaskResourceDataCompare,
+		},
+	}
+
+}
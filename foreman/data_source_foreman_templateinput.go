@@ -2,7 +2,9 @@ package foreman
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
 	"github.com/HanseMerkur/terraform-provider-utils/helper"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -15,6 +17,35 @@ func dataSourceForemanTemplateInput() *schema.Resource {
 	r := resourceForemanTemplateInput()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
 
+	ds[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Looks up an input of a job template.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	// define searchable attributes for the data source
+	ds["template_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		Description: fmt.Sprintf(
+			"ID of the job template the input belongs to. %s 1",
+			autodoc.MetaExample,
+		),
+	}
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the template input. %s \"command\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanTemplateInputRead,
 		Schema:      ds,
@@ -27,16 +58,31 @@ func dataSourceForemanTemplateInputRead(ctx context.Context, d *schema.ResourceD
 	client := meta.(*api.Client)
 	built := buildForemanTemplateInput(d)
 
-	readResponse, readErr := client.ReadTemplateInput(ctx, built)
-	if readErr != nil {
-		return diag.FromErr(readErr)
+	log.Debugf("ForemanTemplateInput: [%+v]", built)
+
+	queryResponse, queryErr := client.QueryTemplateInput(ctx, built, dataSourceQueryOptions(d))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if diags := checkSingleQueryResult(d, "template input", queryResponse); diags.HasError() {
+		return diags
 	}
 
-	built.Name = readResponse.Name
+	queryTemplateInput, ok := queryResponse.Results[0].(api.ForemanTemplateInput)
+	if !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanTemplateInput], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	// The input belongs to the template it was looked up in
+	queryTemplateInput.TemplateId = built.TemplateId
 
-	log.Debugf("ForemanTemplateInput: [%+v]", built)
+	log.Debugf("ForemanTemplateInput: [%+v]", queryTemplateInput)
 
-	setResourceDataFromForemanTemplateInput(d, built)
+	setResourceDataFromForemanTemplateInput(d, &queryTemplateInput)
 
 	return nil
 }
@@ -0,0 +1,48 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures the template input is looked up in its template by name, or by the
+// search instead if one is set
+func TestDataSourceForemanTemplateInputRead(t *testing.T) {
+	cases := []struct {
+		config map[string]interface{}
+		search string
+	}{
+		{map[string]interface{}{"template_id": 3, "name": "command"}, `name="command"`},
+		{map[string]interface{}{"template_id": 3, "search": `name ~ "comm"`}, `name ~ "comm"`},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var search string
+		mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/templates/3/template_inputs", func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query().Get("search")
+			fmt.Fprint(w, `{"total":1,"subtotal":1,"page":1,"per_page":20,"results":[{"id":7,"name":"command","input_type":"user"}]}`)
+		})
+
+		d := schema.TestResourceDataRaw(t, dataSourceForemanTemplateInput().Schema, c.config)
+		diags := dataSourceForemanTemplateInputRead(context.TODO(), d, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Errorf("dataSourceForemanTemplateInputRead with %v returned [%+v]", c.config, diags)
+			continue
+		}
+		if search != c.search {
+			t.Errorf("dataSourceForemanTemplateInputRead with %v searched [%s], expected [%s]", c.config, search, c.search)
+		}
+		if d.Id() != "7" || d.Get("name") != "command" || d.Get("template_id") != 3 {
+			t.Errorf("dataSourceForemanTemplateInputRead with %v set id [%s], name [%v] and template_id [%v]", c.config, d.Id(), d.Get("name"), d.Get("template_id"))
+		}
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func dataSourceForemanTemplateKind() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		ReadContext: dataSourceForemanTemplateKindRead,
 
@@ -31,6 +31,10 @@ func dataSourceForemanTemplateKind() *schema.Resource {
 			},
 		},
 	}
+
+	addDataSourceSearchSchema(r.Schema, "name")
+
+	return r
 }
 
 // -----------------------------------------------------------------------------
@@ -67,15 +71,13 @@ func dataSourceForemanTemplateKindRead(ctx context.Context, d *schema.ResourceDa
 
 	log.Debugf("ForemanTemplateKind: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryTemplateKind(ctx, t)
+	queryResponse, queryErr := client.QueryTemplateKind(ctx, t, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source template kind returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source template kind returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "template kind", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryTemplateKind api.ForemanTemplateKind
@@ -95,3 +97,24 @@ func dataSourceForemanTemplateKindRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+func dataSourceForemanTemplateKinds() *schema.Resource {
+	return listDataSource{
+		object:        "template kind",
+		objects:       "templatekinds",
+		searchExample: `name ~ "PXE"`,
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryTemplateKind(ctx, &api.ForemanTemplateKind{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanTemplateKind)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":   o.Id,
+				"name": o.Name,
+			}, true
+		},
+	}.resource()
+}
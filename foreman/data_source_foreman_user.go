@@ -68,6 +68,8 @@ func dataSourceForemanUser() *schema.Resource {
 			autodoc.MetaExample,
 		),
 	}
+	addDataSourceSearchSchema(ds, "description", "firstname", "lastname", "login", "mail")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanUserRead,
@@ -85,15 +87,13 @@ func dataSourceForemanUserRead(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Debugf("ForemanUser: [%+v]", s)
 
-	queryResponse, queryErr := client.QueryUser(ctx, s)
+	queryResponse, queryErr := client.QueryUser(ctx, s, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source user returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source user returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "user", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryUser api.ForemanUser
@@ -113,3 +113,32 @@ func dataSourceForemanUserRead(ctx context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+func dataSourceForemanUsers() *schema.Resource {
+	return listDataSource{
+		object:        "user",
+		objects:       "users",
+		searchExample: `admin = false`,
+		attributes: map[string]*schema.Schema{
+			"login": listAttribute(schema.TypeString, "Login of the user."),
+			"mail":  listAttribute(schema.TypeString, "Email address of the user."),
+			"admin": listAttribute(schema.TypeBool, "Whether the user is an administrator."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryUser(ctx, &api.ForemanUser{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanUser)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":    o.Id,
+				"name":  o.Name,
+				"login": o.Login,
+				"mail":  o.Mail,
+				"admin": o.Admin,
+			}, true
+		},
+	}.resource()
+}
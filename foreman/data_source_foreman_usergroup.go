@@ -30,6 +30,8 @@ func dataSourceForemanUsergroup() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearchSchema(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanUsergroupRead,
@@ -47,15 +49,13 @@ func dataSourceForemanUsergroupRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanUsergroup: [%+v]", u)
 
-	queryResponse, queryErr := client.QueryUsergroup(ctx, u)
+	queryResponse, queryErr := client.QueryUsergroup(ctx, u, dataSourceQueryOptions(d))
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
 
-	if queryResponse.Subtotal == 0 {
-		return diag.Errorf("Data source usergroup returned no results")
-	} else if queryResponse.Subtotal > 1 {
-		return diag.Errorf("Data source usergroup returned more than 1 result")
+	if diags := checkSingleQueryResult(d, "usergroup", queryResponse); diags.HasError() {
+		return diags
 	}
 
 	var queryUsergroup api.ForemanUsergroup
@@ -75,3 +75,28 @@ func dataSourceForemanUsergroupRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func dataSourceForemanUsergroups() *schema.Resource {
+	return listDataSource{
+		object:        "user group",
+		objects:       "usergroups",
+		searchExample: `name ~ "ops"`,
+		attributes: map[string]*schema.Schema{
+			"admin": listAttribute(schema.TypeBool, "Whether the members of the user group are administrators."),
+		},
+		query: func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error) {
+			return client.QueryUsergroup(ctx, &api.ForemanUsergroup{}, opts)
+		},
+		flatten: func(result interface{}) (map[string]interface{}, bool) {
+			o, ok := result.(api.ForemanUsergroup)
+			if !ok {
+				return nil, false
+			}
+			return map[string]interface{}{
+				"id":    o.Id,
+				"name":  o.Name,
+				"admin": o.Admin,
+			}, true
+		},
+	}.resource()
+}
@@ -0,0 +1,77 @@
+package foreman
+
+import (
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// addDataSourceSearchSchema adds the search and first arguments to the
+// schema of a data source.  The matchAttributes are the attributes the data
+// source is looked up by otherwise, e.g. "name".  They become optional and
+// computed, the search replaces them.
+func addDataSourceSearchSchema(ds map[string]*schema.Schema, matchAttributes ...string) {
+	for _, attr := range matchAttributes {
+		s := ds[attr]
+		switch {
+		case s.Required:
+			s.ExactlyOneOf = []string{attr, "search"}
+		case len(s.ExactlyOneOf) > 0:
+			s.ExactlyOneOf = append(s.ExactlyOneOf, "search")
+		default:
+			s.ConflictsWith = append(s.ConflictsWith, "search")
+		}
+		s.Required = false
+		s.Optional = true
+		s.Computed = true
+	}
+
+	ds["search"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Foreman scoped search query to look up the object with instead of " +
+			"exact matches, e.g. `title ~ \"web/%\"`. The search has to match exactly " +
+			"1 object unless `first` is set.",
+	}
+	ds["first"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Use the first result if the lookup matches more than 1 object " +
+			"instead of failing. Defaults to `false`.",
+	}
+}
+
+// dataSourceQueryOptions returns the options to query the object of a data
+// source with, replacing the search of the query if the data source's search
+// is set
+func dataSourceQueryOptions(d *schema.ResourceData) api.QueryOptions {
+	if search, ok := d.GetOk("search"); ok {
+		return api.SearchQueryOptions(search.(string))
+	}
+	return api.QueryOptions{}
+}
+
+// checkSingleQueryResult ensures the query of a data source matched exactly
+// one object, or at least one if the data source's first argument is set.
+// The results of all pages of the query are counted, so an ambiguous search
+// cannot be hidden by the page size.  The dataSource is the name used in the
+// error messages, e.g. "domain".
+func checkSingleQueryResult(d *schema.ResourceData, dataSource string, queryResponse api.QueryResponse) diag.Diagnostics {
+	first, _ := d.Get("first").(bool)
+
+	switch count := len(queryResponse.Results); {
+	case count == 0:
+		return diag.Errorf("Data source %s returned no results", dataSource)
+	case count > 1 && !first:
+		return diag.Errorf(
+			"Data source %s returned %d results, the search is ambiguous. Refine "+
+				"the arguments of the data source to match exactly 1 result or set "+
+				"first to use the first result",
+			dataSource,
+			count,
+		)
+	}
+	return nil
+}
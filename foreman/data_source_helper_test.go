@@ -0,0 +1,75 @@
+package foreman
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures data sources accept exactly one result, or the first one if first
+// is set, and report how many results an ambiguous search returned
+func TestCheckSingleQueryResult(t *testing.T) {
+	cases := []struct {
+		results  int
+		first    bool
+		expected string
+	}{
+		{0, false, "returned no results"},
+		{1, false, ""},
+		{3, false, "returned 3 results"},
+		{0, true, "returned no results"},
+		{3, true, ""},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceForemanDomain().Schema, map[string]interface{}{
+			"name":  "example.com",
+			"first": c.first,
+		})
+		queryResponse := api.QueryResponse{Results: make([]interface{}, c.results)}
+		diags := checkSingleQueryResult(d, "domain", queryResponse)
+
+		if c.expected == "" && diags.HasError() {
+			t.Errorf("checkSingleQueryResult returned [%+v] for [%d] results, expected no error", diags, c.results)
+		}
+		if c.expected != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, c.expected)) {
+			t.Errorf("checkSingleQueryResult returned [%+v] for [%d] results, expected [%s]", diags, c.results, c.expected)
+		}
+	}
+}
+
+// Ensures the attributes a data source is looked up by exclude the search
+func TestAddDataSourceSearchSchema(t *testing.T) {
+	for name, ds := range map[string]*schema.Resource{
+		"domain":        dataSourceForemanDomain(),
+		"location":      dataSourceForemanLocation(),
+		"subnet":        dataSourceForemanSubnet(),
+		"templatekind":  dataSourceForemanTemplateKind(),
+		"templateinput": dataSourceForemanTemplateInput(),
+	} {
+		if err := ds.InternalValidate(nil, false); err != nil {
+			t.Errorf("Schema of data source %s is invalid: %s", name, err)
+		}
+	}
+
+	cases := []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"name": "example.com"}, true},
+		{map[string]interface{}{"search": `name ~ "example"`}, true},
+		{map[string]interface{}{"name": "example.com", "search": `name ~ "example"`}, false},
+		{map[string]interface{}{}, false},
+	}
+
+	for _, c := range cases {
+		diags := dataSourceForemanDomain().Validate(terraform.NewResourceConfigRaw(c.config))
+		if diags.HasError() == c.valid {
+			t.Errorf("Validation of %v returned [%+v], expected valid [%t]", c.config, diags, c.valid)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listDataSource describes a plural data source, which lists all objects of
+// a type matching a Foreman scoped search, e.g. foreman_subnets.  Only the
+// attributes returned by the search of the Foreman API are listed, use the
+// singular data source or the resource to read all attributes of an object.
+type listDataSource struct {
+	// Name of the object type in descriptions and error messages,
+	// e.g. "subnet"
+	object string
+	// Name of the attribute holding the list of objects, e.g. "subnets"
+	objects string
+	// Example search for the documentation, e.g. `name ~ "dmz"`
+	searchExample string
+	// Key attributes of the listed objects in addition to their ID and
+	// name
+	attributes map[string]*schema.Schema
+	// Queries the objects with the Query* function of the object type,
+	// passing on the options which carry the search of the data source
+	query func(ctx context.Context, client *api.Client, opts api.QueryOptions) (api.QueryResponse, error)
+	// Converts a result of the query into the attributes of a listed object,
+	// including "id" and "name".  Returns false if the result is of an
+	// unexpected type.
+	flatten func(result interface{}) (map[string]interface{}, bool)
+}
+
+// listAttribute returns the schema of a key attribute of a listed object
+func listAttribute(valueType schema.ValueType, description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        valueType,
+		Computed:    true,
+		Description: description,
+	}
+}
+
+// resource returns the schema and read function of the list data source
+func (l listDataSource) resource() *schema.Resource {
+	objectSchema := map[string]*schema.Schema{
+		"id":   listAttribute(schema.TypeInt, fmt.Sprintf("ID of the %s.", l.object)),
+		"name": listAttribute(schema.TypeString, fmt.Sprintf("Name of the %s.", l.object)),
+	}
+	for attr, s := range l.attributes {
+		objectSchema[attr] = s
+	}
+
+	attrNames := make([]string, 0, len(objectSchema))
+	for attr := range objectSchema {
+		attrNames = append(attrNames, "`"+attr+"`")
+	}
+	sort.Strings(attrNames)
+
+	return &schema.Resource{
+		ReadContext: l.read,
+
+		Schema: map[string]*schema.Schema{
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Lists the IDs and key attributes of all %s matching a "+
+						"search, e.g. to create resources for each of them with for_each.",
+					autodoc.MetaSummary,
+					l.objects,
+				),
+			},
+
+			"search": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Foreman scoped search query to filter the %s with, e.g. `%s`. "+
+						"All %s are listed if it is not set.",
+					l.objects,
+					l.searchExample,
+					l.objects,
+				),
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: fmt.Sprintf("IDs of the matching %s.", l.objects),
+			},
+
+			l.objects: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: objectSchema,
+				},
+				Description: fmt.Sprintf(
+					"The matching %s. Each of them has the attributes %s.",
+					l.objects,
+					strings.Join(attrNames, ", "),
+				),
+			},
+		},
+	}
+}
+
+func (l listDataSource) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_list_helper.go#Read")
+
+	client := meta.(*api.Client)
+	search := d.Get("search").(string)
+
+	log.Debugf("%s search: [%s]", l.objects, search)
+
+	queryResponse, queryErr := l.query(ctx, client, api.SearchQueryOptions(search))
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	ids := make([]int, 0, len(queryResponse.Results))
+	objects := make([]map[string]interface{}, 0, len(queryResponse.Results))
+	for _, result := range queryResponse.Results {
+		object, ok := l.flatten(result)
+		if !ok {
+			return diag.Errorf(
+				"Data source results contain unexpected type, got [%T]",
+				result,
+			)
+		}
+		ids = append(ids, object["id"].(int))
+		objects = append(objects, object)
+	}
+
+	log.Debugf("%s: [%+v]", l.objects, objects)
+
+	// The list has no ID of its own, it is identified by its search
+	d.SetId(strconv.Itoa(schema.HashString(l.objects + "/" + search)))
+	d.Set("ids", ids)
+	if err := d.Set(l.objects, objects); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
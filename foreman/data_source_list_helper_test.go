@@ -0,0 +1,80 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures list data sources send their search, or none to list all objects,
+// and set the IDs and key attributes of the results
+func TestListDataSourceRead(t *testing.T) {
+	cases := []struct {
+		search string
+		ids    []interface{}
+	}{
+		{"", []interface{}{1, 2}},
+		{`name ~ "dmz"`, []interface{}{2}},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var search []string
+		mux.HandleFunc("/api/subnets", func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query()["search"]
+			if r.URL.Query().Get("search") != "" {
+				w.Write([]byte(`{"subtotal":1,"results":[{"id":2,"name":"dmz","network":"10.0.2.0","mask":"255.255.255.0","vlanid":2}]}`))
+				return
+			}
+			w.Write([]byte(`{"subtotal":2,"results":[` +
+				`{"id":1,"name":"lan","network":"10.0.1.0","mask":"255.255.255.0","vlanid":1},` +
+				`{"id":2,"name":"dmz","network":"10.0.2.0","mask":"255.255.255.0","vlanid":2}]}`))
+		})
+
+		ds := dataSourceForemanSubnets()
+		d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"search": c.search})
+		diags := ds.ReadContext(context.TODO(), d, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Fatalf("Read of foreman_subnets returned [%+v]", diags)
+		}
+		if c.search == "" && len(search) != 0 {
+			t.Errorf("Read of foreman_subnets sent the search %q, expected none", search)
+		}
+		if c.search != "" && (len(search) != 1 || search[0] != c.search) {
+			t.Errorf("Read of foreman_subnets sent the search %q, expected [%s]", search, c.search)
+		}
+
+		ids := d.Get("ids").([]interface{})
+		if len(ids) != len(c.ids) {
+			t.Fatalf("Read of foreman_subnets set ids %v, expected %v", ids, c.ids)
+		}
+		for idx := range c.ids {
+			if ids[idx] != c.ids[idx] {
+				t.Errorf("Read of foreman_subnets set ids %v, expected %v", ids, c.ids)
+			}
+		}
+		last := fmt.Sprintf("subnets.%d.", len(c.ids)-1)
+		if d.Get(last+"name") != "dmz" || d.Get(last+"network") != "10.0.2.0" || d.Get(last+"vlanid") != 2 {
+			t.Errorf("Read of foreman_subnets set subnets [%+v], expected dmz last", d.Get("subnets"))
+		}
+	}
+}
+
+// Ensures the schemas of all list data sources are valid
+func TestListDataSourceSchemas(t *testing.T) {
+	for name, ds := range Provider().DataSourcesMap {
+		if _, ok := ds.Schema["ids"]; !ok {
+			continue
+		}
+		if err := ds.InternalValidate(nil, false); err != nil {
+			t.Errorf("Schema of data source %s is invalid: %s", name, err)
+		}
+	}
+}
@@ -18,8 +18,10 @@ import (
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // ----------------------------------------------------------------------------
@@ -57,6 +59,30 @@ func NewForemanAPIAndClient(cred api.ClientCredentials, conf api.ClientConfig) (
 	return urlMux, server, client
 }
 
+// Given a resource, a mock instance state and a configuration, create a mock
+// ResourceData reference with the diff between state and configuration, as
+// passed to the update function of the resource
+func MockResourceDataDiff(t *testing.T, r *schema.Resource, s *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(config), nil)
+	if diffErr != nil {
+		t.Fatalf("Diff of the state and config failed: %s", diffErr)
+	}
+	if diff != nil {
+		// Terraform sends the configuration along with the diff
+		configJSON, _ := json.Marshal(config)
+		rawConfig, rawErr := ctyjson.Unmarshal(configJSON, r.CoreConfigSchema().ImpliedType())
+		if rawErr != nil {
+			t.Fatalf("Converting the config failed: %s", rawErr)
+		}
+		diff.RawConfig = rawConfig
+	}
+	d, dataErr := schema.InternalMap(r.Schema).Data(s, diff)
+	if dataErr != nil {
+		t.Fatalf("Creating the ResourceData failed: %s", dataErr)
+	}
+	return d
+}
+
 // ParseJSONFile reads the JSON file at the given path and unmarshals the
 // file's contents into the supplied obj.  If there was an error when reading
 // the file or during the JSON unmarshal, the test reference will error.
@@ -263,6 +289,21 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanWebhookTemplateCorrectURLAndMethodTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanTaskCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserSSHKeyCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanKatelloActivationKeyCorrectURLAndMethodTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -274,6 +315,7 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 		// expected handler to be called
 		for _, uri := range testCase.expectedURIs {
+			uri := uri
 			mux.HandleFunc(uri.expectedURI, func(w http.ResponseWriter, r *http.Request) {
 				// assert expected HTTP method
 				if !strings.EqualFold(uri.expectedMethod, r.Method) {
@@ -365,6 +407,20 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanWebhookTemplateRequestDataEmptyTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanTaskRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserSSHKeyRequestDataEmptyTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -522,6 +578,20 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanWebhookTemplateStatusCodeTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanTaskStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserSSHKeyStatusCodeTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -612,6 +682,20 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanWebhookTemplateEmptyResponseTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanTaskEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserSSHKeyEmptyResponseTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
@@ -739,6 +823,20 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanWebhookTemplateMockResponseTestCases(t)...)
 
+	testCases = append(testCases, DataSourceForemanTaskMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanUserSSHKeyMockResponseTestCases(t)...)
+
 	cred := api.ClientCredentials{}
 	conf := api.ClientConfig{}
 
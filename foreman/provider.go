@@ -2,9 +2,13 @@ package foreman
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	logger "github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
@@ -24,6 +28,30 @@ const (
 	ClientUsernameEnv string = "FOREMAN_CLIENT_USERNAME"
 	// Environment variable to configure the client_password attribute
 	ClientPasswordEnv string = "FOREMAN_CLIENT_PASSWORD"
+	// Environment variable to configure the client_token attribute
+	ClientTokenEnv string = "FOREMAN_CLIENT_TOKEN"
+	// Environment variable to configure the client_oauth_consumer_key attribute
+	ClientOAuthConsumerKeyEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_KEY"
+	// Environment variable to configure the client_oauth_consumer_secret attribute
+	ClientOAuthConsumerSecretEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET"
+	// Environment variable to configure the client_tls_ca_bundle attribute
+	ClientTLSCABundleEnv string = "FOREMAN_CLIENT_TLS_CA_BUNDLE"
+	// Environment variable to configure the client_tls_cert attribute
+	ClientTLSCertEnv string = "FOREMAN_CLIENT_TLS_CERT"
+	// Environment variable to configure the client_tls_key attribute
+	ClientTLSKeyEnv string = "FOREMAN_CLIENT_TLS_KEY"
+	// Environment variable to configure the client_validate_credentials attribute
+	ClientValidateCredentialsEnv string = "FOREMAN_CLIENT_VALIDATE_CREDENTIALS"
+	// Environment variable to configure the client_retry_max_attempts attribute
+	ClientRetryMaxAttemptsEnv string = "FOREMAN_CLIENT_RETRY_MAX_ATTEMPTS"
+	// Environment variable to configure the client_retry_max_elapsed_time attribute
+	ClientRetryMaxElapsedTimeEnv string = "FOREMAN_CLIENT_RETRY_MAX_ELAPSED_TIME"
+	// Environment variable to configure the client_retry_non_idempotent attribute
+	ClientRetryNonIdempotentEnv string = "FOREMAN_CLIENT_RETRY_NON_IDEMPOTENT"
+	// Environment variable to configure the client_rate_limit attribute
+	ClientRateLimitEnv string = "FOREMAN_CLIENT_RATE_LIMIT"
+	// Environment variable to configure the client_max_concurrent_requests attribute
+	ClientMaxConcurrentRequestsEnv string = "FOREMAN_CLIENT_MAX_CONCURRENT_REQUESTS"
 )
 
 // Provider configuration default values
@@ -122,6 +150,64 @@ func Provider() *schema.Provider {
 				Description: "Whether or not to verify the server's certificate. " +
 					"Defaults to `false`.",
 			},
+			"client_tls_ca_bundle": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCABundleEnv,
+					"",
+				),
+				Description: "Path to a PEM file or inline PEM data with CA certificates to " +
+					"verify the server's certificate with, in addition to the system's " +
+					"certificates. This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_TLS_CA_BUNDLE`. Defaults to `\"\"`.",
+			},
+			"client_tls_cert": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCertEnv,
+					"",
+				),
+				Description: "Path to a PEM file or inline PEM data with the client certificate " +
+					"for mutual TLS authentication. Requires `client_tls_key`. This can also be " +
+					"set through the environment variable `FOREMAN_CLIENT_TLS_CERT`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_tls_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSKeyEnv,
+					"",
+				),
+				Description: "Path to a PEM file or inline PEM data with the private key of " +
+					"`client_tls_cert`. This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_TLS_KEY`. Defaults to `\"\"`.",
+			},
+			"client_tls_server_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Overrides the hostname the server's certificate is verified " +
+					"against, e.g. when connecting through an IP address or a proxy. " +
+					"Defaults to `server_hostname`.",
+			},
+
+			"client_validate_credentials": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientValidateCredentialsEnv,
+					true,
+				),
+				Description: "Whether or not to validate the credentials with a request to Foreman " +
+					"when the provider is configured. Rejected credentials fail early, while an " +
+					"unreachable Foreman is only a warning. The request is sent once with a " +
+					"timeout of 10 seconds. Disable it for runs which do not reach Foreman, e.g. " +
+					"`terraform validate`. This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_VALIDATE_CREDENTIALS`. Defaults to `true`.",
+			},
 
 			"client_auth_negotiate": {
 				Type:     schema.TypeBool,
@@ -131,6 +217,105 @@ func Provider() *schema.Provider {
 					"through the HTTP negotiate mechanism. Defaults to `false`.",
 			},
 
+			"task_poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Initial number of seconds to wait between two polls of an " +
+					"asynchronous Foreman task (e.g. a content view publish) or of a host build. Defaults to `1`.",
+			},
+			"task_poll_max_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Maximum number of seconds to wait between two polls of an " +
+					"asynchronous Foreman task. Defaults to `30`.",
+			},
+			"task_poll_backoff_factor": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      2.0,
+				ValidateFunc: validation.FloatAtLeast(1.0),
+				Description: "Factor by which the interval between two task polls grows " +
+					"after every poll. How long to wait in total is determined by the " +
+					"timeouts of the resource. Defaults to `2.0`.",
+			},
+			"client_retry_max_attempts": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientRetryMaxAttemptsEnv,
+					1,
+				),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Maximum number of attempts for a request failing with a transient " +
+					"error: connection errors, `409` lock conflicts, `429` (honoring " +
+					"`Retry-After`), `502`, `503` and `504`. The wait between two attempts " +
+					"grows exponentially with random jitter. `1` disables retries. This can " +
+					"also be set through the environment variable " +
+					"`FOREMAN_CLIENT_RETRY_MAX_ATTEMPTS`. Defaults to `1`.",
+			},
+			"client_retry_max_elapsed_time": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientRetryMaxElapsedTimeEnv,
+					120,
+				),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of seconds after the first attempt of a request in " +
+					"which it is retried. `0` means no limit. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_RETRY_MAX_ELAPSED_TIME`. " +
+					"Defaults to `120`.",
+			},
+			"client_retry_non_idempotent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientRetryNonIdempotentEnv,
+					false,
+				),
+				Description: "Whether or not to retry requests which are not idempotent (`POST`, " +
+					"`PATCH`) as well. A retried create might result in duplicates. This can " +
+					"also be set through the environment variable " +
+					"`FOREMAN_CLIENT_RETRY_NON_IDEMPOTENT`. Defaults to `false`.",
+			},
+			"client_rate_limit": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientRateLimitEnv,
+					0.0,
+				),
+				ValidateFunc: validation.FloatAtLeast(0.0),
+				Description: "Maximum number of requests per second sent to Foreman by all " +
+					"resources together. `0` means no limit. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_RATE_LIMIT`. Defaults to `0`.",
+			},
+			"client_max_concurrent_requests": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientMaxConcurrentRequestsEnv,
+					0,
+				),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of requests to Foreman in flight at the same time, " +
+					"independent of Terraform's `-parallelism`. `0` means no limit. This can " +
+					"also be set through the environment variable " +
+					"`FOREMAN_CLIENT_MAX_CONCURRENT_REQUESTS`. Defaults to `0`.",
+			},
+			"client_query_per_page": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      api.DefaultQueryPerPage,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Number of results requested per page when querying Foreman, " +
+					"e.g. in data sources. All pages of a query are fetched. Defaults to `100`.",
+			},
+
 			// -- client credentials --
 
 			"client_username": {
@@ -155,6 +340,44 @@ func Provider() *schema.Provider {
 					"also be set through the environment variable `FOREMAN_CLIENT_PASSWORD`. " +
 					"Defaults to `\"\"`.",
 			},
+			"client_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTokenEnv,
+					"",
+				),
+				Description: "A personal access token to authenticate against Foreman instead " +
+					"of `client_password`. Requires `client_username` to be set to the user " +
+					"the token belongs to, the token is sent with HTTP basic authentication. " +
+					"This can also be set through the environment variable `FOREMAN_CLIENT_TOKEN`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_oauth_consumer_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerKeyEnv,
+					"",
+				),
+				Description: "The OAuth consumer key to sign the requests with instead of " +
+					"authenticating with a password or token. If `client_username` is set, " +
+					"Foreman maps the requests to that user. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_OAUTH_CONSUMER_KEY`. Defaults to `\"\"`.",
+			},
+			"client_oauth_consumer_secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerSecretEnv,
+					"",
+				),
+				Description: "The OAuth consumer secret belonging to `client_oauth_consumer_key`. " +
+					"This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET`. Defaults to `\"\"`.",
+			},
 
 			// -- provider organization and location --
 			"organization_id": {
@@ -163,7 +386,9 @@ func Provider() *schema.Provider {
 				Default:  0,
 				Description: "The organization for all resource requested and created by the Provider " +
 					"Defaults to \"0\". Set organization_id and location_id to a value < 0 if you need " +
-					"to disable Locations and Organizations on Foreman older than 1.21",
+					"to disable Locations and Organizations on Foreman older than 1.21" +
+					". Resources can be assigned to other organizations with their own " +
+					"`organization_ids` (`organization_id` for hosts).",
 			},
 			"location_id": {
 				Type:     schema.TypeInt,
@@ -171,7 +396,9 @@ func Provider() *schema.Provider {
 				Default:  0,
 				Description: "The location for all resources requested and created by the provider" +
 					"Defaults to \"0\". Set organization_id and location_id to a value < 0 if you need " +
-					"to disable Locations and Organizations on Foreman older than 1.21",
+					"to disable Locations and Organizations on Foreman older than 1.21" +
+					". Resources can be assigned to other locations with their own " +
+					"`location_ids` (`location_id` for hosts).",
 			},
 		},
 
@@ -199,7 +426,10 @@ func Provider() *schema.Provider {
 			"foreman_katello_lifecycle_environment": resourceForemanKatelloLifecycleEnvironment(),
 			"foreman_katello_product":               resourceForemanKatelloProduct(),
 			"foreman_katello_repository":            resourceForemanKatelloRepository(),
+			"foreman_katello_repository_sync":       resourceForemanKatelloRepositorySync(),
+			"foreman_katello_activation_key":        resourceForemanKatelloActivationKey(),
 			"foreman_katello_content_view":          resourceForemanKatelloContentView(),
+			"foreman_katello_content_view_version":  resourceForemanKatelloContentViewVersion(),
 			"foreman_katello_sync_plan":             resourceForemanKatelloSyncPlan(),
 			"foreman_user":                          resourceForemanUser(),
 			"foreman_usergroup":                     resourceForemanUsergroup(),
@@ -209,12 +439,21 @@ func Provider() *schema.Provider {
 			"foreman_templateinput":                 resourceForemanTemplateInput(),
 			"foreman_webhook":                       resourceForemanWebhook(),
 			"foreman_webhooktemplate":               resourceForemanWebhookTemplate(),
+			"foreman_task_wait":                     resourceForemanTaskWait(),
+			"foreman_organization":                  resourceForemanOrganization(),
+			"foreman_location":                      resourceForemanLocation(),
+			"foreman_role":                          resourceForemanRole(),
+			"foreman_filter":                        resourceForemanFilter(),
+			"foreman_auth_source_ldap":              resourceForemanAuthSourceLDAP(),
+			"foreman_personal_access_token":         resourceForemanPersonalAccessToken(),
+			"foreman_user_ssh_key":                  resourceForemanUserSSHKey(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"foreman_architecture":                  dataSourceForemanArchitecture(),
 			"foreman_domain":                        dataSourceForemanDomain(),
 			"foreman_environment":                   dataSourceForemanEnvironment(),
+			"foreman_host":                          dataSourceForemanHost(),
 			"foreman_hostgroup":                     dataSourceForemanHostgroup(),
 			"foreman_media":                         dataSourceForemanMedia(),
 			"foreman_model":                         dataSourceForemanModel(),
@@ -237,6 +476,7 @@ func Provider() *schema.Provider {
 			"foreman_katello_lifecycle_environment": dataSourceForemanKatelloLifecycleEnvironment(),
 			"foreman_katello_product":               dataSourceForemanKatelloProduct(),
 			"foreman_katello_repository":            dataSourceForemanKatelloRepository(),
+			"foreman_katello_activation_key":        dataSourceForemanKatelloActivationKey(),
 			"foreman_katello_content_view":          dataSourceForemanKatelloContentView(),
 			"foreman_katello_sync_plan":             dataSourceForemanKatelloSyncPlan(),
 			"foreman_user":                          dataSourceForemanUser(),
@@ -244,6 +484,46 @@ func Provider() *schema.Provider {
 			"foreman_setting":                       dataSourceForemanSetting(),
 			"foreman_jobtemplate":                   dataSourceForemanJobTemplate(),
 			"foreman_templateinput":                 dataSourceForemanTemplateInput(),
+			"foreman_task":                          dataSourceForemanTask(),
+			"foreman_organization":                  dataSourceForemanOrganization(),
+			"foreman_location":                      dataSourceForemanLocation(),
+			"foreman_role":                          dataSourceForemanRole(),
+			"foreman_permission":                    dataSourceForemanPermission(),
+			"foreman_auth_source_ldap":              dataSourceForemanAuthSourceLDAP(),
+
+			// list data sources
+			"foreman_architectures":                  dataSourceForemanArchitectures(),
+			"foreman_auth_source_ldaps":              dataSourceForemanAuthSourceLDAPs(),
+			"foreman_computeprofiles":                dataSourceForemanComputeProfiles(),
+			"foreman_computeresources":               dataSourceForemanComputeResources(),
+			"foreman_domains":                        dataSourceForemanDomains(),
+			"foreman_environments":                   dataSourceForemanEnvironments(),
+			"foreman_global_parameters":              dataSourceForemanGlobalParameters(),
+			"foreman_hostgroups":                     dataSourceForemanHostgroups(),
+			"foreman_hosts":                          dataSourceForemanHosts(),
+			"foreman_httpproxies":                    dataSourceForemanHTTPProxies(),
+			"foreman_jobtemplates":                   dataSourceForemanJobTemplates(),
+			"foreman_katello_activation_keys":        dataSourceForemanKatelloActivationKeys(),
+			"foreman_katello_content_credentials":    dataSourceForemanKatelloContentCredentials(),
+			"foreman_katello_content_views":          dataSourceForemanKatelloContentViews(),
+			"foreman_katello_lifecycle_environments": dataSourceForemanKatelloLifecycleEnvironments(),
+			"foreman_katello_products":               dataSourceForemanKatelloProducts(),
+			"foreman_katello_repositories":           dataSourceForemanKatelloRepositories(),
+			"foreman_katello_sync_plans":             dataSourceForemanKatelloSyncPlans(),
+			"foreman_locations":                      dataSourceForemanLocations(),
+			"foreman_media_list":                     dataSourceForemanMediaList(),
+			"foreman_models":                         dataSourceForemanModels(),
+			"foreman_operatingsystems":               dataSourceForemanOperatingSystems(),
+			"foreman_organizations":                  dataSourceForemanOrganizations(),
+			"foreman_partitiontables":                dataSourceForemanPartitionTables(),
+			"foreman_permissions":                    dataSourceForemanPermissions(),
+			"foreman_provisioningtemplates":          dataSourceForemanProvisioningTemplates(),
+			"foreman_roles":                          dataSourceForemanRoles(),
+			"foreman_smartproxies":                   dataSourceForemanSmartProxies(),
+			"foreman_subnets":                        dataSourceForemanSubnets(),
+			"foreman_templatekinds":                  dataSourceForemanTemplateKinds(),
+			"foreman_usergroups":                     dataSourceForemanUsergroups(),
+			"foreman_users":                          dataSourceForemanUsers(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -315,16 +595,93 @@ func providerConfigure(context context.Context, d *schema.ResourceData) (interfa
 		},
 		// -- client configuration --
 		ClientTLSInsecure:    d.Get("client_tls_insecure").(bool),
+		ClientTLSCABundle:    d.Get("client_tls_ca_bundle").(string),
+		ClientTLSCert:        d.Get("client_tls_cert").(string),
+		ClientTLSKey:         d.Get("client_tls_key").(string),
+		ClientTLSServerName:  d.Get("client_tls_server_name").(string),
 		NegotiateAuthEnabled: d.Get("client_auth_negotiate").(bool),
 		ClientCredentials: api.ClientCredentials{
-			Username: d.Get("client_username").(string),
-			Password: d.Get("client_password").(string),
+			Username:            d.Get("client_username").(string),
+			Password:            d.Get("client_password").(string),
+			Token:               d.Get("client_token").(string),
+			OAuthConsumerKey:    d.Get("client_oauth_consumer_key").(string),
+			OAuthConsumerSecret: d.Get("client_oauth_consumer_secret").(string),
 		},
 		LocationID:     d.Get("location_id").(int),
 		OrganizationID: d.Get("organization_id").(int),
+		// -- task polling configuration --
+		TaskPollInterval:      time.Duration(d.Get("task_poll_interval").(int)) * time.Second,
+		TaskPollMaxInterval:   time.Duration(d.Get("task_poll_max_interval").(int)) * time.Second,
+		TaskPollBackoffFactor: d.Get("task_poll_backoff_factor").(float64),
+		// -- retry configuration --
+		RetryMaxAttempts:   d.Get("client_retry_max_attempts").(int),
+		RetryMaxElapsed:    time.Duration(d.Get("client_retry_max_elapsed_time").(int)) * time.Second,
+		RetryNonIdempotent: d.Get("client_retry_non_idempotent").(bool),
+		// -- throttling configuration --
+		RateLimit:             d.Get("client_rate_limit").(float64),
+		MaxConcurrentRequests: d.Get("client_max_concurrent_requests").(int),
+		// -- query configuration --
+		QueryPerPage: d.Get("client_query_per_page").(int),
+	}
+
+	// Fail early on credentials which cannot work instead of with an error
+	// deep inside the first resource using the client
+	if credErr := config.ClientCredentials.Validate(); credErr != nil {
+		return nil, diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid Foreman credentials",
+				Detail:   credErr.Error(),
+			},
+		}
+	}
+
+	client, diags := config.Client()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if d.Get("client_validate_credentials").(bool) {
+		diags = append(diags, validateClientCredentials(context, client)...)
 	}
 
-	return config.Client()
+	return client, diags
+}
+
+// validateClientCredentials sends an authenticated request with the client.
+// Rejected credentials result in an error. Any other failure is only a
+// warning, Foreman might not be reachable yet when the provider is configured.
+func validateClientCredentials(ctx context.Context, client *api.Client) diag.Diagnostics {
+	logger.Tracef("provider.go#validateClientCredentials")
+
+	validateErr := client.ValidateCredentials(ctx)
+	if validateErr == nil {
+		return nil
+	}
+
+	var httpErr api.HTTPError
+	if errors.As(validateErr, &httpErr) && (httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Authentication against Foreman failed",
+				Detail: fmt.Sprintf(
+					"Foreman rejected the configured credentials with status code [%d]. "+
+						"Check client_username together with client_password, client_token or "+
+						"client_oauth_consumer_key and client_oauth_consumer_secret.",
+					httpErr.StatusCode,
+				),
+			},
+		}
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Could not validate the Foreman credentials",
+			Detail:   validateErr.Error(),
+		},
+	}
 }
 
 // InitLogger initialize the provider's shared logging instance. The shared
@@ -0,0 +1,122 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures rejected credentials fail the provider configuration while other
+// failures to validate them are only warnings
+func TestValidateClientCredentials(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		severity   diag.Severity
+	}{
+		{http.StatusUnauthorized, diag.Error},
+		{http.StatusForbidden, diag.Error},
+		{http.StatusInternalServerError, diag.Warning},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{Username: "admin", Token: "s3cr3t"}, api.ClientConfig{})
+		mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/status", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.statusCode)
+		})
+
+		diags := validateClientCredentials(context.Background(), client)
+		server.Close()
+
+		if len(diags) != 1 || diags[0].Severity != c.severity {
+			t.Errorf(
+				"validateClientCredentials returned [%+v] for status code [%d], expected severity [%d]",
+				diags,
+				c.statusCode,
+				c.severity,
+			)
+		}
+	}
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{Username: "admin", Token: "s3cr3t"}, api.ClientConfig{})
+	defer server.Close()
+	mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":"ok","status":200,"version":"3.11.0","api_version":2}`))
+	})
+	if diags := validateClientCredentials(context.Background(), client); len(diags) != 0 {
+		t.Errorf("validateClientCredentials returned [%+v] for accepted credentials", diags)
+	}
+}
+
+// Ensures the credentials are only validated when the provider is configured
+// if client_validate_credentials is enabled
+func TestProviderConfigure_ValidateCredentials(t *testing.T) {
+	for _, validate := range []bool{true, false} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		serverURL, _ := url.Parse(server.URL)
+
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"server_hostname":             serverURL.Host,
+			"server_protocol":             serverURL.Scheme,
+			"client_username":             "admin",
+			"client_password":             "changeme",
+			"client_validate_credentials": validate,
+		})
+		_, diags := providerConfigure(context.Background(), d)
+		server.Close()
+
+		if validate && (requests != 1 || !diags.HasError()) {
+			t.Errorf("Configuring the provider sent [%d] requests and returned [%+v], expected the rejected credentials", requests, diags)
+		}
+		if !validate && (requests != 0 || diags.HasError()) {
+			t.Errorf("Configuring the provider without validation sent [%d] requests and returned [%+v]", requests, diags)
+		}
+	}
+}
+
+// Ensures the retry and throttling settings default to their environment
+// variables
+func TestProvider_ClientRetryEnvDefaults(t *testing.T) {
+	t.Setenv(ClientRetryMaxAttemptsEnv, "3")
+	t.Setenv(ClientRetryMaxElapsedTimeEnv, "60")
+	t.Setenv(ClientRetryNonIdempotentEnv, "true")
+	t.Setenv(ClientRateLimitEnv, "2.5")
+	t.Setenv(ClientMaxConcurrentRequestsEnv, "8")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+
+	if v := d.Get("client_retry_max_attempts").(int); v != 3 {
+		t.Errorf("client_retry_max_attempts is [%d], expected [3]", v)
+	}
+	if v := d.Get("client_retry_max_elapsed_time").(int); v != 60 {
+		t.Errorf("client_retry_max_elapsed_time is [%d], expected [60]", v)
+	}
+	if v := d.Get("client_retry_non_idempotent").(bool); !v {
+		t.Errorf("client_retry_non_idempotent is [%t], expected [true]", v)
+	}
+	if v := d.Get("client_rate_limit").(float64); v != 2.5 {
+		t.Errorf("client_rate_limit is [%f], expected [2.5]", v)
+	}
+	if v := d.Get("client_max_concurrent_requests").(int); v != 8 {
+		t.Errorf("client_max_concurrent_requests is [%d], expected [8]", v)
+	}
+}
+
+// Ensures requests are not retried unless retries are enabled
+func TestProvider_ClientRetryDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+
+	if v := d.Get("client_retry_max_attempts").(int); v != 1 {
+		t.Errorf("client_retry_max_attempts is [%d], expected [1]", v)
+	}
+}
@@ -0,0 +1,339 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanAuthSourceLDAP() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanAuthSourceLDAPCreate,
+		ReadContext:   resourceForemanAuthSourceLDAPRead,
+		UpdateContext: resourceForemanAuthSourceLDAPUpdate,
+		DeleteContext: resourceForemanAuthSourceLDAPDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s LDAP authentication source. Users of the authentication "+
+						"source log in to Foreman with their directory credentials.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the authentication source. "+
+						"%s \"Corporate LDAP\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Hostname of the LDAP server. "+
+						"%s \"ldap.example.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the LDAP server. Defaults to 389, or 636 if `tls` is enabled.",
+			},
+
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to connect to the LDAP server with TLS (LDAPS).",
+			},
+
+			"server_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "posix",
+				ValidateFunc: validation.StringInSlice([]string{
+					"posix",
+					"free_ipa",
+					"active_directory",
+				}, false),
+				Description: "Type of the LDAP server. Valid values: `posix`, `free_ipa`, `active_directory`.",
+			},
+
+			"base_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN to search users in. "+
+						"%s \"ou=people,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"groups_base": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base DN to search groups in. Required for `usergroup_sync`.",
+			},
+
+			"ldap_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP filter restricting the users which can log in. "+
+						"%s \"(memberOf=cn=foreman,ou=groups,dc=example,dc=com)\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"use_netgroups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use netgroups instead of POSIX groups. Only used for `posix` and `free_ipa` servers.",
+			},
+
+			"account": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Account used to bind to the LDAP server. Use `$login` to bind with the "+
+						"credentials of the user logging in, leave empty for an anonymous bind. "+
+						"%s \"uid=foreman,ou=services,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"account_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the bind account. Foreman does not return it, changes made outside of Terraform are not detected.",
+			},
+
+			"attr_login": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute holding the login of the user. Required for `onthefly_register`. "+
+						"%s \"uid\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_firstname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute holding the first name of the user. Required for `onthefly_register`.",
+			},
+
+			"attr_lastname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute holding the last name of the user. Required for `onthefly_register`.",
+			},
+
+			"attr_mail": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute holding the email address of the user. Required for `onthefly_register`.",
+			},
+
+			"attr_photo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute holding the photo of the user.",
+			},
+
+			"onthefly_register": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether users of the authentication source are created in Foreman on their first login.",
+			},
+
+			"usergroup_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the members of external usergroups are synchronized when a user logs in.",
+			},
+
+			"location_ids":     foremanLocationIdsSchema("authentication source"),
+			"organization_ids": foremanOrganizationIdsSchema("authentication source"),
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanAuthSourceLDAP constructs a ForemanAuthSourceLDAP reference from
+// a resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanAuthSourceLDAP(d *schema.ResourceData) *api.ForemanAuthSourceLDAP {
+	log.Tracef("resource_foreman_auth_source_ldap.go#buildForemanAuthSourceLDAP")
+
+	authSource := api.ForemanAuthSourceLDAP{}
+
+	obj := buildForemanObject(d)
+	authSource.ForemanObject = *obj
+
+	authSource.Host = d.Get("host").(string)
+	authSource.Port = d.Get("port").(int)
+	authSource.TLS = d.Get("tls").(bool)
+	authSource.ServerType = d.Get("server_type").(string)
+
+	authSource.BaseDN = d.Get("base_dn").(string)
+	authSource.GroupsBase = d.Get("groups_base").(string)
+	authSource.LDAPFilter = d.Get("ldap_filter").(string)
+	authSource.UseNetgroups = d.Get("use_netgroups").(bool)
+
+	authSource.Account = d.Get("account").(string)
+	if attr, ok := d.GetOk("account_password"); ok {
+		authSource.AccountPassword = attr.(string)
+	}
+
+	authSource.AttrLogin = d.Get("attr_login").(string)
+	authSource.AttrFirstname = d.Get("attr_firstname").(string)
+	authSource.AttrLastname = d.Get("attr_lastname").(string)
+	authSource.AttrMail = d.Get("attr_mail").(string)
+	authSource.AttrPhoto = d.Get("attr_photo").(string)
+
+	authSource.OntheflyRegister = d.Get("onthefly_register").(bool)
+	authSource.UsergroupSync = d.Get("usergroup_sync").(bool)
+
+	authSource.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
+	return &authSource
+}
+
+// setResourceDataFromForemanAuthSourceLDAP sets a ResourceData's attributes
+// from the attributes of the supplied ForemanAuthSourceLDAP reference
+func setResourceDataFromForemanAuthSourceLDAP(d *schema.ResourceData, fa *api.ForemanAuthSourceLDAP) {
+	log.Tracef("resource_foreman_auth_source_ldap.go#setResourceDataFromForemanAuthSourceLDAP")
+
+	// NOTE(ALL): the account password is never returned by Foreman and is
+	//   kept as configured
+	d.SetId(strconv.Itoa(fa.Id))
+	d.Set("name", fa.Name)
+	d.Set("host", fa.Host)
+	d.Set("port", fa.Port)
+	d.Set("tls", fa.TLS)
+	d.Set("server_type", fa.ServerType)
+	d.Set("base_dn", fa.BaseDN)
+	d.Set("groups_base", fa.GroupsBase)
+	d.Set("ldap_filter", fa.LDAPFilter)
+	d.Set("use_netgroups", fa.UseNetgroups)
+	d.Set("account", fa.Account)
+	d.Set("attr_login", fa.AttrLogin)
+	d.Set("attr_firstname", fa.AttrFirstname)
+	d.Set("attr_lastname", fa.AttrLastname)
+	d.Set("attr_mail", fa.AttrMail)
+	d.Set("attr_photo", fa.AttrPhoto)
+	d.Set("onthefly_register", fa.OntheflyRegister)
+	d.Set("usergroup_sync", fa.UsergroupSync)
+	setResourceDataFromForemanTaxonomyIds(d, fa.ForemanTaxonomyIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanAuthSourceLDAPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Create")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	createdAuthSource, createErr := client.CreateAuthSourceLDAP(ctx, a)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanAuthSourceLDAP: [%+v]", createdAuthSource)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, createdAuthSource)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLDAPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	readAuthSource, readErr := client.ReadAuthSourceLDAP(ctx, a.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanAuthSourceLDAP: [%+v]", readAuthSource)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, readAuthSource)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLDAPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Update")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	updatedAuthSource, updateErr := client.UpdateAuthSourceLDAP(ctx, a, a.Id)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanAuthSourceLDAP: [%+v]", updatedAuthSource)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, updatedAuthSource)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLDAPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Delete")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", a)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteAuthSourceLDAP(ctx, a.Id)))
+}
@@ -0,0 +1,384 @@
+package foreman
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const AuthSourceLDAPsURI = api.FOREMAN_API_URL_PREFIX + "/auth_source_ldaps"
+const AuthSourceLDAPsTestDataPath = "testdata/3.11/auth_source_ldaps"
+
+// Given a ForemanAuthSourceLDAP, create a mock instance state reference
+func ForemanAuthSourceLDAPToInstanceState(obj api.ForemanAuthSourceLDAP) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanAuthSourceLDAP
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["host"] = obj.Host
+	attr["port"] = strconv.Itoa(obj.Port)
+	attr["tls"] = strconv.FormatBool(obj.TLS)
+	attr["server_type"] = obj.ServerType
+	attr["base_dn"] = obj.BaseDN
+	attr["groups_base"] = obj.GroupsBase
+	attr["ldap_filter"] = obj.LDAPFilter
+	attr["use_netgroups"] = strconv.FormatBool(obj.UseNetgroups)
+	attr["account"] = obj.Account
+	attr["attr_login"] = obj.AttrLogin
+	attr["attr_firstname"] = obj.AttrFirstname
+	attr["attr_lastname"] = obj.AttrLastname
+	attr["attr_mail"] = obj.AttrMail
+	attr["attr_photo"] = obj.AttrPhoto
+	attr["onthefly_register"] = strconv.FormatBool(obj.OntheflyRegister)
+	attr["usergroup_sync"] = strconv.FormatBool(obj.UsergroupSync)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanAuthSourceLDAP resource, create a
+// mock ResourceData reference.
+func MockForemanAuthSourceLDAPResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanAuthSourceLDAP()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an LDAP authentication source
+// ResourceData reference
+func MockForemanAuthSourceLDAPResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanAuthSourceLDAP
+	ParseJSONFile(t, path, &obj)
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+	return MockForemanAuthSourceLDAPResourceData(s)
+}
+
+// Creates a random ForemanAuthSourceLDAP struct
+func RandForemanAuthSourceLDAP() api.ForemanAuthSourceLDAP {
+	obj := api.ForemanAuthSourceLDAP{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Host = tfrand.String(10, tfrand.Lower) + ".example.com"
+	obj.Port = rand.Intn(65535) + 1
+	obj.TLS = rand.Intn(2) > 0
+	obj.ServerType = "posix"
+	obj.BaseDN = "ou=" + tfrand.String(10, tfrand.Lower) + ",dc=example,dc=com"
+	obj.Account = "uid=" + tfrand.String(10, tfrand.Lower) + ",dc=example,dc=com"
+	obj.AttrLogin = tfrand.String(5, tfrand.Lower)
+	obj.OntheflyRegister = rand.Intn(2) > 0
+	obj.UsergroupSync = rand.Intn(2) > 0
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanAuthSourceLDAP resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanAuthSourceLDAPResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanAuthSourceLDAP()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestAuthSourceLDAPUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanAuthSourceLDAP
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the attributes and taxonomies of a read response are decoded
+func TestAuthSourceLDAPUnmarshalJSON_ReadResponse(t *testing.T) {
+
+	var obj api.ForemanAuthSourceLDAP
+	ParseJSONFile(t, AuthSourceLDAPsTestDataPath+"/read_response.json", &obj)
+
+	if obj.Host != "ldap.example.com" || obj.Port != 636 || !obj.TLS || obj.ServerType != "free_ipa" {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON did not decode the server. Got [%+v]",
+			obj,
+		)
+	}
+
+	if obj.AccountPassword != "" {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON decoded an account password. Got [%s]",
+			obj.AccountPassword,
+		)
+	}
+
+	organizationIds, ok := obj.ReadOrganizationIds()
+	if !ok || !reflect.DeepEqual(organizationIds, []int{4}) {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON did not decode the organizations. "+
+				"Expected [[4]], got [%v]",
+			organizationIds,
+		)
+	}
+
+}
+
+// Ensures the account password is only sent if it is set
+func TestAuthSourceLDAPMarshalJSON_AccountPassword(t *testing.T) {
+
+	obj := RandForemanAuthSourceLDAP()
+	b, _ := json.Marshal(obj)
+	if strings.Contains(string(b), "account_password") {
+		t.Errorf(
+			"ForemanAuthSourceLDAP MarshalJSON sent an empty account password. Got [%s]",
+			b,
+		)
+	}
+
+	obj.AccountPassword = "secret"
+	b, _ = json.Marshal(obj)
+	if !strings.Contains(string(b), `"account_password":"secret"`) {
+		t.Errorf(
+			"ForemanAuthSourceLDAP MarshalJSON did not send the account password. Got [%s]",
+			b,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanAuthSourceLDAP
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanAuthSourceLDAP_Value(t *testing.T) {
+
+	expectedObj := RandForemanAuthSourceLDAP()
+	expectedState := ForemanAuthSourceLDAPToInstanceState(expectedObj)
+	expectedResourceData := MockForemanAuthSourceLDAPResourceData(expectedState)
+
+	actualObj := api.ForemanAuthSourceLDAP{}
+	actualState := ForemanAuthSourceLDAPToInstanceState(actualObj)
+	actualResourceData := MockForemanAuthSourceLDAPResourceData(actualState)
+
+	setResourceDataFromForemanAuthSourceLDAP(actualResourceData, &expectedObj)
+
+	ForemanAuthSourceLDAPResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanAuthSourceLDAP{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+	authSourceLDAPsURIById := AuthSourceLDAPsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPRead",
+				crudFunc:     resourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    authSourceLDAPsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPDelete",
+				crudFunc:     resourceForemanAuthSourceLDAPDelete,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    authSourceLDAPsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanAuthSourceLDAP{}
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPDelete",
+			crudFunc:     resourceForemanAuthSourceLDAPDelete,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanAuthSourceLDAPStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPCreate",
+			crudFunc:     resourceForemanAuthSourceLDAPCreate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPUpdate",
+			crudFunc:     resourceForemanAuthSourceLDAPUpdate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPDelete",
+			crudFunc:     resourceForemanAuthSourceLDAPDelete,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanAuthSourceLDAPEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPCreate",
+			crudFunc:     resourceForemanAuthSourceLDAPCreate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPUpdate",
+			crudFunc:     resourceForemanAuthSourceLDAPUpdate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanAuthSourceLDAPMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPCreate",
+				crudFunc:     resourceForemanAuthSourceLDAPCreate,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile: AuthSourceLDAPsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLDAPResourceDataFromFile(
+				t,
+				AuthSourceLDAPsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanAuthSourceLDAPResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPRead",
+				crudFunc:     resourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile: AuthSourceLDAPsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLDAPResourceDataFromFile(
+				t,
+				AuthSourceLDAPsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanAuthSourceLDAPResourceDataCompare,
+		},
+	}
+
+}
@@ -95,6 +95,9 @@ func resourceForemanComputeResource() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the compute resource",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("compute resource"),
+			"organization_ids": foremanOrganizationIdsSchema("compute resource"),
 		},
 	}
 }
@@ -152,6 +155,8 @@ func buildForemanComputeResource(d *schema.ResourceData) *api.ForemanComputeReso
 		computeresource.Description = attr.(string)
 	}
 
+	computeresource.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &computeresource
 }
 
@@ -172,6 +177,7 @@ func setResourceDataFromForemanComputeResource(d *schema.ResourceData, fd *api.F
 	d.Set("setconsolepassword", fd.SetConsolePassword)
 	d.Set("cachingenabled", fd.CachingEnabled)
 	d.Set("description", fd.Description)
+	setResourceDataFromForemanTaxonomyIds(d, fd.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -64,6 +64,9 @@ func resourceForemanDomain() *schema.Resource {
 				Description: "A map of parameters that will be saved as domain parameters " +
 					"in the domain config.",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("domain"),
+			"organization_ids": foremanOrganizationIdsSchema("domain"),
 		},
 	}
 }
@@ -95,6 +98,8 @@ func buildForemanDomain(d *schema.ResourceData) *api.ForemanDomain {
 		domain.DomainParameters = api.ToKV(attr.(map[string]interface{}))
 	}
 
+	domain.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &domain
 }
 
@@ -107,6 +112,7 @@ func setResourceDataFromForemanDomain(d *schema.ResourceData, fd *api.ForemanDom
 	d.Set("name", fd.Name)
 	d.Set("fullname", fd.Fullname)
 	d.Set("parameters", api.FromKV(fd.DomainParameters))
+	setResourceDataFromForemanTaxonomyIds(d, fd.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
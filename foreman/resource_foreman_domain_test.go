@@ -1,7 +1,9 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -141,6 +143,58 @@ func TestSetResourceDataFromForemanDomain_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// buildForemanTaxonomyIds
+// -----------------------------------------------------------------------------
+
+// Ensures emptied locations are sent as an empty list to remove the domain
+// from all locations, while organizations which are not configured are kept
+func TestResourceForemanDomainUpdate_EmptiedTaxonomies(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{
+		LocationID:     2,
+		OrganizationID: 1,
+	})
+	defer server.Close()
+
+	var body map[string]interface{}
+	mux.HandleFunc(DomainsURI+"/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		fmt.Fprint(w, `{"id":3,"name":"example.com","locations":[],"organizations":[{"id":4}]}`)
+	})
+
+	current := resourceForemanDomain().TestResourceData()
+	current.SetId("3")
+	current.Set("name", "example.com")
+	current.Set("location_ids", []interface{}{5, 6})
+	current.Set("organization_ids", []interface{}{4})
+	state := current.State()
+
+	config := map[string]interface{}{
+		"name":         "example.com",
+		"location_ids": []interface{}{},
+	}
+	d := MockResourceDataDiff(t, resourceForemanDomain(), state, config)
+	if diags := resourceForemanDomainUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the domain returned [%+v]", diags)
+	}
+
+	domain, _ := body["domain"].(map[string]interface{})
+	if locationIds, ok := domain["location_ids"]; !ok || !reflect.DeepEqual(locationIds, []interface{}{}) {
+		t.Errorf("Update of the domain sent location_ids [%v], expected []", locationIds)
+	}
+	if organizationIds := domain["organization_ids"]; !reflect.DeepEqual(organizationIds, []interface{}{4.0}) {
+		t.Errorf("Update of the domain sent organization_ids [%v], expected [4]", organizationIds)
+	}
+	if _, ok := body["location_id"]; ok {
+		t.Errorf("Update of the domain without locations was scoped to the default location: [%v]", body)
+	}
+	if d.Get("location_ids").(*schema.Set).Len() != 0 {
+		t.Errorf("Update of the domain set location_ids [%v], expected none", d.Get("location_ids"))
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
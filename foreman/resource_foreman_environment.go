@@ -46,6 +46,9 @@ func resourceForemanEnvironment() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"location_ids":     foremanLocationIdsSchema("environment"),
+			"organization_ids": foremanOrganizationIdsSchema("environment"),
 		},
 	}
 }
@@ -73,6 +76,8 @@ func buildForemanEnvironment(d *schema.ResourceData) *api.ForemanEnvironment {
 		environment.Name = attr.(string)
 	}
 
+	environment.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &environment
 }
 
@@ -83,6 +88,7 @@ func setResourceDataFromForemanEnvironment(d *schema.ResourceData, fe *api.Forem
 
 	d.SetId(strconv.Itoa(fe.Id))
 	d.Set("name", fe.Name)
+	setResourceDataFromForemanTaxonomyIds(d, fe.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
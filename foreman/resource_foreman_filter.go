@@ -0,0 +1,251 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanFilter() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanFilterCreate,
+		ReadContext:   resourceForemanFilterRead,
+		UpdateContext: resourceForemanFilterUpdate,
+		DeleteContext: resourceForemanFilterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Filters grant the permissions of a role. All permissions of a "+
+						"filter must belong to the same resource type. The search of a "+
+						"filter limits them to the matching objects.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"role_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: fmt.Sprintf(
+					"ID of the role the filter belongs to. "+
+						"%s foreman_role.example.id",
+					autodoc.MetaExample,
+				),
+			},
+
+			"permission_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: fmt.Sprintf(
+					"IDs of the permissions granted by the filter, see the "+
+						"`foreman_permission` data source. "+
+						"%s [data.foreman_permission.view_hosts.id]",
+					autodoc.MetaExample,
+				),
+			},
+
+			"search": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Search query limiting the objects the permissions apply to. "+
+						"The permissions apply to all objects of the resource type if "+
+						"not set. "+
+						"%s \"hostgroup = web\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"override": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the filter is limited to its own `organization_ids` " +
+					"and `location_ids` instead of the ones of its role. Defaults to `false`.",
+			},
+
+			"location_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the filter is limited to. Only used if " +
+					"`override` is `true`, otherwise the filter inherits the locations of " +
+					"its role. An empty list removes all locations of the filter.",
+			},
+
+			"organization_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the filter is limited to. Only used " +
+					"if `override` is `true`, otherwise the filter inherits the " +
+					"organizations of its role. An empty list removes all organizations " +
+					"of the filter.",
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Resource type of the permissions of the filter, e.g. " +
+					"`Host`.",
+			},
+
+			"unlimited": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether the permissions apply to all objects of the " +
+					"resource type, i.e. the filter has no search.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanFilter constructs a ForemanFilter reference from a resource
+// data reference. The struct's members are populated from the data populated
+// in the resource data. Missing members will be left to the zero value for
+// that member's type.
+func buildForemanFilter(d *schema.ResourceData) *api.ForemanFilter {
+	log.Tracef("resource_foreman_filter.go#buildForemanFilter")
+
+	filter := api.ForemanFilter{}
+
+	obj := buildForemanObject(d)
+	filter.ForemanObject = *obj
+
+	filter.RoleId = d.Get("role_id").(int)
+	filter.Search = d.Get("search").(string)
+	filter.Override = d.Get("override").(bool)
+
+	if attr, ok := d.GetOk("permission_ids"); ok {
+		filter.PermissionIds = conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+
+	filter.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
+	return &filter
+}
+
+// setResourceDataFromForemanFilter sets a ResourceData's attributes from the
+// attributes of the supplied ForemanFilter reference
+func setResourceDataFromForemanFilter(d *schema.ResourceData, ff *api.ForemanFilter) {
+	log.Tracef("resource_foreman_filter.go#setResourceDataFromForemanFilter")
+
+	d.SetId(strconv.Itoa(ff.Id))
+	d.Set("role_id", ff.RoleId)
+	d.Set("permission_ids", ff.PermissionIds)
+	d.Set("search", ff.Search)
+	d.Set("override", ff.Override)
+	d.Set("resource_type", ff.ResourceType)
+	d.Set("unlimited", ff.Unlimited)
+	setResourceDataFromForemanTaxonomyIds(d, ff.ForemanTaxonomyIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanFilterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Create")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	createdFilter, createErr := client.CreateFilter(ctx, f)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanFilter: [%+v]", createdFilter)
+
+	setResourceDataFromForemanFilter(d, createdFilter)
+
+	return nil
+}
+
+func resourceForemanFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Read")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	readFilter, readErr := client.ReadFilter(ctx, f.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanFilter: [%+v]", readFilter)
+
+	setResourceDataFromForemanFilter(d, readFilter)
+
+	return nil
+}
+
+func resourceForemanFilterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Update")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	updatedFilter, updateErr := client.UpdateFilter(ctx, f)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanFilter: [%+v]", updatedFilter)
+
+	setResourceDataFromForemanFilter(d, updatedFilter)
+
+	return nil
+}
+
+func resourceForemanFilterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Delete")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteFilter(ctx, f.Id)))
+}
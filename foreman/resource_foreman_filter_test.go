@@ -0,0 +1,312 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const FiltersURI = api.FOREMAN_API_URL_PREFIX + "/filters"
+const FiltersTestDataPath = "testdata/3.11/filters"
+
+// Given a ForemanFilter, create a mock instance state reference
+func ForemanFilterToInstanceState(obj api.ForemanFilter) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanFilter
+	attr := map[string]string{}
+	attr["role_id"] = strconv.Itoa(obj.RoleId)
+	attr["search"] = obj.Search
+	attr["override"] = strconv.FormatBool(obj.Override)
+	attr["resource_type"] = obj.ResourceType
+	attr["unlimited"] = strconv.FormatBool(obj.Unlimited)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanFilter resource, create a
+// mock ResourceData reference.
+func MockForemanFilterResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanFilter()
+	return r.Data(s)
+}
+
+// Creates a random ForemanFilter struct
+func RandForemanFilter() api.ForemanFilter {
+	obj := api.ForemanFilter{}
+
+	obj.Id = rand.Intn(1000)
+	obj.RoleId = rand.Intn(100) + 1
+	obj.Search = "hostgroup = " + tfrand.String(10, tfrand.Lower)
+	obj.Override = rand.Intn(2) > 0
+	obj.ResourceType = tfrand.String(10, tfrand.Lower)
+	obj.PermissionIds = []int{rand.Intn(100) + 1}
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanFilter resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanFilterResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanFilter()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanFilter
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanFilter_Value(t *testing.T) {
+
+	expectedObj := RandForemanFilter()
+	expectedState := ForemanFilterToInstanceState(expectedObj)
+	expectedResourceData := MockForemanFilterResourceData(expectedState)
+
+	actualObj := api.ForemanFilter{}
+	actualState := ForemanFilterToInstanceState(actualObj)
+	actualResourceData := MockForemanFilterResourceData(actualState)
+
+	setResourceDataFromForemanFilter(actualResourceData, &expectedObj)
+
+	ForemanFilterResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanFilter
+// -----------------------------------------------------------------------------
+
+// Ensures the organizations and locations of an overriding filter can be
+// removed by emptying them
+func TestResourceForemanFilterUpdate_EmptiedTaxonomies(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var body map[string]map[string]interface{}
+	mux.HandleFunc(FiltersURI+"/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		fmt.Fprint(w, `{"id":7,"role":{"id":2},"override":true,"permissions":[{"id":9}],`+
+			`"locations":[],"organizations":[]}`)
+	})
+
+	current := resourceForemanFilter().TestResourceData()
+	current.SetId("7")
+	current.Set("role_id", 2)
+	current.Set("override", true)
+	current.Set("permission_ids", []interface{}{9})
+	current.Set("location_ids", []interface{}{5})
+	current.Set("organization_ids", []interface{}{1, 4})
+	state := current.State()
+
+	config := map[string]interface{}{
+		"role_id":          2,
+		"override":         true,
+		"permission_ids":   []interface{}{9},
+		"location_ids":     []interface{}{},
+		"organization_ids": []interface{}{},
+	}
+	d := MockResourceDataDiff(t, resourceForemanFilter(), state, config)
+	if diags := resourceForemanFilterUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the filter returned [%+v]", diags)
+	}
+
+	filter := body["filter"]
+	for _, key := range []string{"location_ids", "organization_ids"} {
+		if ids, ok := filter[key]; !ok || !reflect.DeepEqual(ids, []interface{}{}) {
+			t.Errorf("Update of the filter sent %s [%v], expected []", key, ids)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanFilterCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+	filtersURIById := FiltersURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    filtersURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterDelete",
+				crudFunc:     resourceForemanFilterDelete,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    filtersURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanFilterRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanFilter{}
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanFilterStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanFilterEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanFilterMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	// The role of the filter is nested in the read response, build the
+	// expected state from the decoded values
+	expectedObj := api.ForemanFilter{
+		RoleId:       23,
+		Search:       "hostgroup = web",
+		Override:     true,
+		Unlimited:    false,
+		ResourceType: "Host",
+	}
+	expectedObj.Id = 212
+	expectedState := ForemanFilterToInstanceState(expectedObj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			responseFile:         FiltersTestDataPath + "/read_response.json",
+			returnError:          false,
+			expectedResourceData: MockForemanFilterResourceData(expectedState),
+			compareFunc:          ForemanFilterResourceDataCompare,
+		},
+	}
+
+}
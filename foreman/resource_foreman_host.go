@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -304,12 +305,17 @@ func resourceForemanHost() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceForemanHostCustomizeDiffComputeAttributes,
+			resourceForemanHostCustomizeDiffPowerActions,
 		),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -457,6 +463,133 @@ func resourceForemanHost() *schema.Resource {
 				Description: "Manage power operations, e.g. power on, if host's build flag will be enabled.",
 			},
 
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.PowerOn, api.PowerOff}, false),
+				Description: "Power state of the host, either `on` or `off`. Changing it powers the host " +
+					"on or off through Foreman, and a host powered on or off outside of Terraform shows up " +
+					"in the plan. If not set, the power state is only read for hosts with `enable_bmc`. " +
+					"Requires a BMC interface or a compute resource.",
+			},
+
+			"power_actions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"power_action": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								api.PowerOn,
+								api.PowerOff,
+								api.PowerSoft,
+								api.PowerCycle,
+							}, false),
+							Description: "Power action to send to the host, one of `on`, `off`, `soft` " +
+								"(soft power off or reboot, depending on the power management) and `cycle`. " +
+								"Either `power_action` or `boot_device` must be set.",
+						},
+						"boot_device": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								api.BootDisk,
+								api.BootCdrom,
+								api.BootPxe,
+								api.PowerBios,
+							}, false),
+							Description: "Device to boot the host from on its next boot through the BMC, " +
+								"one of `disk`, `cdrom`, `pxe` and `bios`. Either `power_action` or " +
+								"`boot_device` must be set.",
+						},
+						"delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Seconds to wait after the action before running the next one. Defaults to `3`.",
+						},
+						"retry_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of times to retry the action if it fails. Defaults to `0`.",
+						},
+						"retry_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Seconds to wait before retrying the action. Defaults to `5`.",
+						},
+						"ignore_failure": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Go on with the next action if the action still fails after its " +
+								"retries, e.g. for a soft power off of a host which is already off. " +
+								"Defaults to `false`.",
+						},
+					},
+				},
+				Description: "Ordered list of power and boot actions to run on the host after it is " +
+					"created or rebuilt due to `rebuild_triggers`, instead of the default ones. By default, a host with `enable_bmc` is set " +
+					"to boot from PXE and power cycled, and other managed hosts are powered on. Only " +
+					"runs if `manage_power_operations` is enabled and `power_state` is not `off`.",
+			},
+
+			"power_actions_on_rebuild": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Also run the power actions when the host is set to be rebuilt, i.e. when " +
+					"`set_build_flag` is changed to `true`. Defaults to `false`.",
+			},
+
+			"rebuild_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values which rebuild the host in place when any of them " +
+					"changes, e.g. the name of its partition table or operating system version. The " +
+					"build flag of the host is set and its power actions are run, the host keeps its ID " +
+					"and history in Foreman. Nothing is rebuilt on create, when the map is added to an " +
+					"existing host or when the map is emptied.",
+			},
+
+			"rebuild_orchestration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Rebuild the DNS records, DHCP reservations and TFTP boot files of the host " +
+					"with Foreman's `rebuild_config` before it is rebuilt due to `rebuild_triggers`. " +
+					"Defaults to `false`.",
+			},
+
+			"wait_for_build": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait on create until Foreman reports the build of the host as finished. " +
+					"Fails if the build fails or the build token expires. Waiting is limited by the " +
+					"`create` timeout. Only applies to hosts which are built by Foreman. Defaults to `false`.",
+			},
+
+			"wait_for_config_report": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait on create until the host sends its first successful config report, " +
+					"e.g. of a Puppet or Ansible run. Failed reports are ignored until the `create` " +
+					"timeout is exceeded. Defaults to `false`.",
+			},
+
 			"retry_count": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -500,6 +633,24 @@ func resourceForemanHost() *schema.Resource {
 				Description:  "ID of the user or usergroup that owns the host.",
 			},
 
+			"location_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the location of the host. If set, the provider's " +
+					"`location_id` is not used for the host.",
+			},
+
+			"organization_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the organization of the host. If set, the provider's " +
+					"`organization_id` is not used for the host.",
+			},
+
 			"domain_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -636,11 +787,14 @@ func resourceForemanHost() *schema.Resource {
 
 			// -- Key Components --
 			"interfaces_attributes": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Computed:    true,
-				Elem:        resourceForemanInterfacesAttributes(),
-				Description: "Host interface information.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     resourceForemanInterfacesAttributes(),
+				Description: "Host interface information. Interfaces are matched with the ones " +
+					"in Foreman by their `mac` or `identifier`, so they can be added, removed and " +
+					"reordered without changing the others. Interfaces without either of them are " +
+					"matched by their position.",
 			},
 		},
 	}
@@ -823,6 +977,14 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	if ownerId != 0 {
 		host.OwnerId = &ownerId
 	}
+	locationId := d.Get("location_id").(int)
+	if locationId != 0 {
+		host.LocationId = &locationId
+	}
+	organizationId := d.Get("organization_id").(int)
+	if organizationId != 0 {
+		host.OrganizationId = &organizationId
+	}
 	domainId := d.Get("domain_id").(int)
 	if domainId != 0 {
 		host.DomainId = &domainId
@@ -1043,6 +1205,177 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 	return tempIntAttr
 }
 
+// interfaceComputedAttributes are the optional attributes of an interface
+// which Foreman computes if they are not configured
+var interfaceComputedAttributes = []string{"ip", "mac", "name", "subnet_id"}
+
+// interfaceConfiguredAttributes returns for each interface in the
+// configuration which of its attributes are set.  Optional computed
+// attributes which are not set carry the value of the interface at the same
+// position in the state, so they must not be used to identify the interface.
+// Returns nil if the configuration is not available.
+func interfaceConfiguredAttributes(d *schema.ResourceData) []map[string]bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() ||
+		!raw.Type().HasAttribute("interfaces_attributes") {
+		return nil
+	}
+	ifaces := raw.GetAttr("interfaces_attributes")
+	if ifaces.IsNull() || !ifaces.IsKnown() {
+		return nil
+	}
+
+	configured := []map[string]bool{}
+	for it := ifaces.ElementIterator(); it.Next(); {
+		_, iface := it.Element()
+		attrs := map[string]bool{}
+		if !iface.IsNull() && iface.IsKnown() {
+			for name := range iface.Type().AttributeTypes() {
+				attrs[name] = !iface.GetAttr(name).IsNull()
+			}
+		}
+		configured = append(configured, attrs)
+	}
+	return configured
+}
+
+// matchForemanInterfacesAttributes matches each of the entries with one of
+// the candidates and returns the index of the matched candidate per entry,
+// or -1 if there is none.  Interfaces are matched by their Foreman ID if
+// matchId is set, then by their MAC address and then by their identifier.
+// The remaining entries are matched with the remaining candidates in order.
+func matchForemanInterfacesAttributes(entries []api.ForemanInterfacesAttribute, candidates []api.ForemanInterfacesAttribute, matchId bool) []int {
+	matches := make([]int, len(entries))
+	used := make([]bool, len(candidates))
+
+	find := func(equal func(c api.ForemanInterfacesAttribute) bool) int {
+		for idx, c := range candidates {
+			if !used[idx] && equal(c) {
+				used[idx] = true
+				return idx
+			}
+		}
+		return -1
+	}
+
+	for idx, e := range entries {
+		matches[idx] = -1
+		if matchId && e.Id != 0 {
+			matches[idx] = find(func(c api.ForemanInterfacesAttribute) bool { return c.Id == e.Id })
+		}
+		if matches[idx] == -1 && e.MAC != "" {
+			matches[idx] = find(func(c api.ForemanInterfacesAttribute) bool { return strings.EqualFold(c.MAC, e.MAC) })
+		}
+		if matches[idx] == -1 && e.Identifier != "" {
+			matches[idx] = find(func(c api.ForemanInterfacesAttribute) bool { return c.Identifier == e.Identifier })
+		}
+	}
+
+	// Interfaces without any key keep their position among the others
+	for idx, e := range entries {
+		if matches[idx] == -1 && (!matchId || e.Id == 0) && e.MAC == "" && e.Identifier == "" {
+			matches[idx] = find(func(c api.ForemanInterfacesAttribute) bool { return true })
+		}
+	}
+
+	return matches
+}
+
+// sortForemanInterfacesAttributes orders the interfaces of the supplied host
+// like the supplied entries, usually the interfaces in the state or the
+// configuration, so that the order in which Foreman returns them does not
+// show up as a change.  Interfaces unknown to the entries are put last.
+func sortForemanInterfacesAttributes(fh *api.ForemanHost, entries []api.ForemanInterfacesAttribute) {
+	matches := matchForemanInterfacesAttributes(entries, fh.InterfacesAttributes, true)
+
+	sorted := make([]api.ForemanInterfacesAttribute, 0, len(fh.InterfacesAttributes))
+	used := make([]bool, len(fh.InterfacesAttributes))
+	for _, match := range matches {
+		if match != -1 {
+			sorted = append(sorted, fh.InterfacesAttributes[match])
+			used[match] = true
+		}
+	}
+	for idx, iface := range fh.InterfacesAttributes {
+		if !used[idx] {
+			sorted = append(sorted, iface)
+		}
+	}
+	fh.InterfacesAttributes = sorted
+}
+
+// changedForemanInterfacesAttributes compares the configured interfaces with
+// the interfaces in the state by their identity instead of their position.
+// It returns the configured interfaces with the Foreman IDs and computed
+// attributes of the interfaces they match, and the interfaces to send to
+// Foreman: new and changed interfaces, and removed ones tagged for removal.
+func changedForemanInterfacesAttributes(d *schema.ResourceData) ([]api.ForemanInterfacesAttribute, []api.ForemanInterfacesAttribute) {
+	oldVal, newVal := d.GetChange("interfaces_attributes")
+	oldValList, newValList := oldVal.([]interface{}), newVal.([]interface{})
+	configured := interfaceConfiguredAttributes(d)
+
+	current := make([]api.ForemanInterfacesAttribute, len(oldValList))
+	for idx, val := range oldValList {
+		current[idx] = mapToForemanInterfacesAttribute(val.(map[string]interface{}))
+	}
+
+	// The ID of a configured interface and its computed attributes which
+	// are not set are those of the interface at the same position in the
+	// state, clear them to match the interface by its own attributes
+	planned := make([]api.ForemanInterfacesAttribute, len(newValList))
+	for idx, val := range newValList {
+		m := val.(map[string]interface{})
+		if configured != nil {
+			m["id"] = 0
+			for _, attr := range interfaceComputedAttributes {
+				if idx >= len(configured) || !configured[idx][attr] {
+					delete(m, attr)
+				}
+			}
+		}
+		planned[idx] = mapToForemanInterfacesAttribute(m)
+	}
+
+	changed := []api.ForemanInterfacesAttribute{}
+	used := make([]bool, len(current))
+	for idx, match := range matchForemanInterfacesAttributes(planned, current, configured == nil) {
+		iface := &planned[idx]
+		if match == -1 {
+			iface.Id = 0
+			changed = append(changed, *iface)
+			continue
+		}
+		used[match] = true
+		old := current[match]
+		iface.Id = old.Id
+		if iface.IP == "" {
+			iface.IP = old.IP
+		}
+		if iface.MAC == "" {
+			iface.MAC = old.MAC
+		}
+		if iface.Name == "" {
+			iface.Name = old.Name
+		}
+		if iface.SubnetId == 0 {
+			iface.SubnetId = old.SubnetId
+		}
+		if !reflect.DeepEqual(*iface, old) {
+			changed = append(changed, *iface)
+		}
+	}
+
+	// tag the interfaces which are no longer configured for removal
+	for idx, old := range current {
+		if !used[idx] && old.Id != 0 {
+			old.Destroy = true
+			changed = append(changed, old)
+		}
+	}
+
+	return planned, changed
+}
+
 // setResourceDataFromForemanHost sets a ResourceData's attributes from the
 // attributes of the supplied ForemanHost struct
 func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost) error {
@@ -1080,6 +1413,8 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("managed", fh.Managed)
 	d.Set("provision_method", fh.ProvisionMethod)
 
+	d.Set("location_id", fh.LocationId)
+	d.Set("organization_id", fh.OrganizationId)
 	d.Set("domain_id", fh.DomainId)
 	d.Set("domain_name", fh.DomainName)
 	d.Set("environment_id", fh.EnvironmentId)
@@ -1233,51 +1568,62 @@ func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta
 	// Only changes enabled with SetPartial are merged in.
 	d.Partial(true)
 
+	sortForemanInterfacesAttributes(createdHost, buildForemanInterfacesAttributes(d))
 	err := setResourceDataFromForemanHost(d, createdHost)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Remember the latest config report before the host boots, only reports
+	// sent after it count when waiting for the config report
+	waitForConfigReport := d.Get("wait_for_config_report").(bool)
+	lastReportId := 0
+	if waitForConfigReport {
+		lastReport, reportErr := client.ReadLastHostConfigReport(ctx, createdHost.Id)
+		if reportErr != nil {
+			return diag.FromErr(reportErr)
+		}
+		if lastReport != nil {
+			lastReportId = lastReport.Id
+		}
+	}
+
 	ManagePowerOperations := d.Get("manage_power_operations").(bool)
 
-	// Manage power operations only if needed, default is true
-	if ManagePowerOperations {
-		var powerCmds []interface{}
-		// If enable_bmc is true, perform required power off, pxe boot and power on BMC functions
-		// Don't modify power state at all if we're not managing the build
-		if h.EnableBMC {
-			log.Debugf("Calling BMC Reboot/PXE Functions")
-			// List of BMC Actions to perform
-			powerCmds = []interface{}{
-				api.BMCBoot{
-					Device: api.BootPxe,
-				},
-				api.Power{
-					PowerAction: api.PowerCycle,
-				},
-			}
-		} else if h.Managed {
-			log.Debugf("Using default Foreman behaviour for startup")
-			powerCmds = []interface{}{
-				api.Power{
-					PowerAction: api.PowerOn,
-				},
-			}
+	// Manage power operations only if needed, default is true. Hosts which
+	// should stay powered off are not booted.
+	if ManagePowerOperations && d.Get("power_state").(string) != api.PowerOff {
+		if err := runHostPowerActions(ctx, client, createdHost, hostPowerActions(d, h)); err != nil {
+			return diag.FromErr(err)
 		}
+	}
 
-		// Loop through each of the above BMC Operations and execute.
-		// In the event fo any failure, exit with error
-		for _, cmd := range powerCmds {
-			sendErr := client.SendPowerCommand(ctx, createdHost, cmd, hostRetryCount)
-			if sendErr != nil {
-				return diag.FromErr(sendErr)
-			}
-			// Sleep for 3 seconds between chained BMC calls
-			duration := time.Duration(3) * time.Second
-			time.Sleep(duration)
+	if err := convergeHostPowerState(ctx, client, d, createdHost, hostRetryCount); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("wait_for_build").(bool) && h.Build {
+		builtHost, waitErr := client.WaitForHostBuild(ctx, createdHost.Id)
+		if waitErr != nil {
+			return diag.FromErr(waitErr)
+		}
+		log.Debugf("Built ForemanHost: [%+v]", builtHost)
+		sortForemanInterfacesAttributes(builtHost, buildForemanInterfacesAttributes(d))
+		if err := setResourceDataFromForemanHost(d, builtHost); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if waitForConfigReport {
+		report, waitErr := client.WaitForHostConfigReport(ctx, createdHost.Id, lastReportId)
+		if waitErr != nil {
+			return diag.FromErr(waitErr)
 		}
+		log.Debugf("ForemanConfigReport: [%+v]", report)
 	}
 
+	setHostPowerState(ctx, client, d, createdHost.Id)
+
 	// Disable partial mode
 	d.Partial(false)
 
@@ -1299,11 +1645,14 @@ func resourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	log.Debugf("Read ForemanHost: [%+v]", readHost)
 
+	sortForemanInterfacesAttributes(readHost, buildForemanInterfacesAttributes(d))
 	err := setResourceDataFromForemanHost(d, readHost)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	setHostPowerState(ctx, client, d, readHost.Id)
+
 	if d.Get("retry_count").(int) == 0 {
 		d.Set("retry_count", DEFAULT_RETRY_COUNT)
 	}
@@ -1328,26 +1677,25 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// NOTE(ALL): Handling the removal of a Interfaces.  See the note
-	//   in ForemanInterfacesAttribute's Destroy property
+	//   in ForemanInterfacesAttribute's Destroy property.  Interfaces are
+	//   matched by their identity, not their position in the list, and only
+	//   new, changed and removed interfaces are sent.
+	plannedInterfaces := buildForemanInterfacesAttributes(d)
 	if d.HasChange("interfaces_attributes") {
-		oldVal, newVal := d.GetChange("interfaces_attributes")
-		oldValList, newValList := oldVal.([]interface{}), newVal.([]interface{})
-
-		// iterate over the removed items, add them back to the interface's
-		// array, but tag them for removal.
-		for idx, rmVal := range oldValList {
-			if idx+1 > len(newValList) {
-				// construct, tag for deletion from list of interfaces
-				rmValMap := rmVal.(map[string]interface{})
-				rmInterface := mapToForemanInterfacesAttribute(rmValMap)
-				rmInterface.Destroy = true
-				// append back to interface's list
-				h.InterfacesAttributes = append(h.InterfacesAttributes, rmInterface)
-			}
-		}
-
+		plannedInterfaces, h.InterfacesAttributes = changedForemanInterfacesAttributes(d)
 	} // end HasChange("interfaces_attributes")
 
+	// A change of the rebuild triggers sets the build flag of the host.  Adding
+	// the triggers to an existing host or emptying them does not rebuild it.
+	oldTriggers, newTriggers := d.GetChange("rebuild_triggers")
+	rebuild := d.HasChange("rebuild_triggers") &&
+		len(oldTriggers.(map[string]interface{})) > 0 &&
+		len(newTriggers.(map[string]interface{})) > 0
+	if rebuild {
+		log.Infof("Rebuild triggers of host %s changed, setting its build flag", h.Name)
+		h.Build = true
+	}
+
 	hostRetryCount := d.Get("retry_count").(int)
 
 	// We need to test whether a call to update the host is necessary based on what has changed.
@@ -1357,6 +1705,8 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		d.HasChange("comment") ||
 		d.HasChange("parameters") ||
 		d.HasChange("compute_attributes") ||
+		d.HasChange("location_id") ||
+		d.HasChange("organization_id") ||
 		d.HasChange("domain_id") ||
 		d.HasChange("environment_id") ||
 		d.HasChange("owner_id") ||
@@ -1367,6 +1717,8 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		d.HasChange("operatingsystem_id") ||
 		d.HasChange("interfaces_attributes") ||
 		d.HasChange("build") ||
+		d.HasChange("set_build_flag") ||
+		rebuild ||
 		d.HasChange("puppet_class_ids") ||
 		d.HasChange("config_group_ids") ||
 		d.Get("managed") == false {
@@ -1380,18 +1732,173 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 		log.Debugf("Updated FormanHost: [%+v]", updatedHost)
 
+		sortForemanInterfacesAttributes(updatedHost, plannedInterfaces)
 		err := setResourceDataFromForemanHost(d, updatedHost)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} // end HasChange("name")
 
+	if rebuild && d.Get("rebuild_orchestration").(bool) {
+		if err := client.RebuildHostConfig(ctx, h.Id); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Boot the host into its rebuild
+	bootRebuild := rebuild ||
+		(d.HasChange("set_build_flag") && h.Build && d.Get("power_actions_on_rebuild").(bool))
+	if bootRebuild &&
+		d.Get("manage_power_operations").(bool) &&
+		d.Get("power_state").(string) != api.PowerOff {
+		if err := runHostPowerActions(ctx, client, h, hostPowerActions(d, h)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("power_state") {
+		if err := convergeHostPowerState(ctx, client, d, h, hostRetryCount); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Use partial state mode in the event of failure of one of API calls required for host creation
 	d.Partial(false)
 
 	return nil
 }
 
+// hostPowerAction is one step of the power actions run when a host is
+// created or rebuilt
+type hostPowerAction struct {
+	// Either an api.Power or an api.BMCBoot command
+	command       interface{}
+	delay         time.Duration
+	retryCount    int
+	retryDelay    time.Duration
+	ignoreFailure bool
+}
+
+// hostPowerActions returns the power actions configured for the host, or the
+// default ones if none are configured: a host with enable_bmc is booted from
+// PXE and power cycled, other managed hosts are powered on.
+func hostPowerActions(d *schema.ResourceData, h *api.ForemanHost) []hostPowerAction {
+	var actions []hostPowerAction
+
+	for _, item := range d.Get("power_actions").([]interface{}) {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		action := hostPowerAction{
+			delay:         time.Duration(m["delay"].(int)) * time.Second,
+			retryCount:    m["retry_count"].(int),
+			retryDelay:    time.Duration(m["retry_delay"].(int)) * time.Second,
+			ignoreFailure: m["ignore_failure"].(bool),
+		}
+		if device := m["boot_device"].(string); device != "" {
+			action.command = api.BMCBoot{Device: device}
+		} else {
+			action.command = api.Power{PowerAction: m["power_action"].(string)}
+		}
+		actions = append(actions, action)
+	}
+	if len(actions) > 0 {
+		return actions
+	}
+
+	// The default actions are retried like the other requests of the host
+	retryCount := d.Get("retry_count").(int) - 1
+	if h.EnableBMC {
+		log.Debugf("Calling BMC Reboot/PXE Functions")
+		return []hostPowerAction{
+			{command: api.BMCBoot{Device: api.BootPxe}, delay: 3 * time.Second, retryCount: retryCount},
+			{command: api.Power{PowerAction: api.PowerCycle}, delay: 3 * time.Second, retryCount: retryCount},
+		}
+	}
+	if h.Managed {
+		log.Debugf("Using default Foreman behaviour for startup")
+		return []hostPowerAction{
+			{command: api.Power{PowerAction: api.PowerOn}, delay: 3 * time.Second, retryCount: retryCount},
+		}
+	}
+	return nil
+}
+
+// runHostPowerActions runs the supplied power actions on the host in order.
+// A failed action is retried and then either ignored or returned as error.
+// Waiting between actions stops as soon as the context ends.
+func runHostPowerActions(ctx context.Context, client *api.Client, h *api.ForemanHost, actions []hostPowerAction) error {
+	for idx, action := range actions {
+		log.Debugf("Power action #%d of host %s: [%+v]", idx, h.Name, action.command)
+
+		var sendErr error
+		for attempt := 0; attempt <= action.retryCount; attempt++ {
+			if attempt > 0 {
+				log.Infof("Retrying power action #%d of host %s in %s: %s", idx, h.Name, action.retryDelay, sendErr)
+				if err := api.SleepContext(ctx, action.retryDelay); err != nil {
+					return err
+				}
+			}
+			if sendErr = client.SendPowerCommand(ctx, h, action.command, 1); sendErr == nil {
+				break
+			}
+		}
+		if sendErr != nil {
+			if !action.ignoreFailure {
+				return fmt.Errorf("power action #%d [%+v] of host %s failed: %w", idx, action.command, h.Name, sendErr)
+			}
+			log.Warningf("Ignoring failed power action #%d of host %s: %s", idx, h.Name, sendErr)
+		}
+
+		if err := api.SleepContext(ctx, action.delay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setHostPowerState sets the power_state attribute to the power state of the
+// host with the supplied ID.  Reading the power state sends a command to the
+// BMC or compute resource of the host, so it is only read if power_state is
+// set or enable_bmc is enabled.  Errors only leave the attribute unchanged.
+func setHostPowerState(ctx context.Context, client *api.Client, d *schema.ResourceData, id int) {
+	if d.Get("power_state").(string) == "" && !d.Get("enable_bmc").(bool) {
+		return
+	}
+	state, err := client.ReadHostPowerState(ctx, id)
+	if err != nil {
+		log.Warningf("Could not read the power state of host %d: %s", id, err)
+		return
+	}
+	d.Set("power_state", state)
+}
+
+// convergeHostPowerState powers the host on or off if its power state differs
+// from the configured power_state
+func convergeHostPowerState(ctx context.Context, client *api.Client, d *schema.ResourceData, h *api.ForemanHost, retryCount int) error {
+	desired := d.Get("power_state").(string)
+	if desired == "" {
+		return nil
+	}
+
+	current, err := client.ReadHostPowerState(ctx, h.Id)
+	if err != nil {
+		return err
+	}
+	if current == desired {
+		return nil
+	}
+
+	log.Infof("Powering %s host %s, its power state is [%s]", desired, h.Name, current)
+	if err := client.SendPowerCommand(ctx, h, api.Power{PowerAction: desired}, retryCount); err != nil {
+		return err
+	}
+	d.Set("power_state", desired)
+
+	return nil
+}
+
 func resourceForemanHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Tracef("resource_foreman_host.go#Delete")
 
@@ -1465,6 +1972,25 @@ func resourceForemanHostCustomizeDiffComputeAttributes(ctx context.Context, d *s
 	return nil
 }
 
+// resourceForemanHostCustomizeDiffPowerActions ensures each of the power
+// actions either sends a power action or sets a boot device
+func resourceForemanHostCustomizeDiffPowerActions(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	if !d.NewValueKnown("power_actions") {
+		return nil
+	}
+	for idx, item := range d.Get("power_actions").([]interface{}) {
+		m, ok := item.(map[string]interface{})
+		prefix := fmt.Sprintf("power_actions.%d.", idx)
+		if !ok || !d.NewValueKnown(prefix+"power_action") || !d.NewValueKnown(prefix+"boot_device") {
+			continue
+		}
+		if (m["power_action"] == "") == (m["boot_device"] == "") {
+			return fmt.Errorf("power_actions.%d: exactly one of power_action and boot_device must be set", idx)
+		}
+	}
+	return nil
+}
+
 func resourceForemanHostNameDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
 	domainName := d.Get("domain_name").(string)
 	if domainName == "" {
@@ -109,6 +109,13 @@ func MockForemanHostResourceData(s *terraform.InstanceState) *schema.ResourceDat
 	return r.Data(s)
 }
 
+// Given a mock instance state for a ForemanHost resource and a configuration,
+// create a mock ResourceData reference with the diff between both, as passed
+// to the update function
+func MockForemanHostResourceDataDiff(t *testing.T, s *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	return MockResourceDataDiff(t, resourceForemanHost(), s, config)
+}
+
 // Reads the JSON for the file at the path and creates a host
 // ResourceData reference
 func MockForemanHostResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
@@ -320,6 +327,516 @@ func TestSetResourceDataFromForemanHost_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// convergeHostPowerState
+// -----------------------------------------------------------------------------
+
+// Ensures the host is only powered on or off if its power state differs from
+// the configured power_state
+func TestConvergeHostPowerState(t *testing.T) {
+	cases := []struct {
+		desired string
+		current string
+		actions []string
+	}{
+		{"", "on", nil},
+		{"on", "on", []string{"state"}},
+		{"off", "on", []string{"state", "off"}},
+		{"on", "off", []string{"state", "on"}},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var actions []string
+		mux.HandleFunc(HostsURI+"/7/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			if err := json.NewDecoder(r.Body).Decode(&power); err != nil {
+				t.Errorf("Decoding the power command failed: %s", err)
+				return
+			}
+			actions = append(actions, power.PowerAction)
+			if power.PowerAction == api.PowerState {
+				fmt.Fprintf(w, `{"power":%q}`, c.current)
+				return
+			}
+			fmt.Fprint(w, `{"power":true}`)
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{"power_state": c.desired})
+		h := &api.ForemanHost{}
+		h.Id = 7
+		err := convergeHostPowerState(context.TODO(), client, d, h, 1)
+		server.Close()
+
+		if err != nil {
+			t.Errorf("convergeHostPowerState to [%s] returned [%s]", c.desired, err)
+			continue
+		}
+		if !reflect.DeepEqual(actions, c.actions) {
+			t.Errorf("convergeHostPowerState from [%s] to [%s] sent %v, expected %v", c.current, c.desired, actions, c.actions)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// setHostPowerState
+// -----------------------------------------------------------------------------
+
+// Ensures the power state is only read if power_state is set or the host has
+// a BMC, since reading it sends a power command
+func TestSetHostPowerState(t *testing.T) {
+	cases := []struct {
+		config   map[string]interface{}
+		requests int
+		expected string
+	}{
+		{map[string]interface{}{}, 0, ""},
+		{map[string]interface{}{"power_state": "off"}, 1, "on"},
+		{map[string]interface{}{"enable_bmc": true}, 1, "on"},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		requests := 0
+		mux.HandleFunc(HostsURI+"/7/power", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			fmt.Fprint(w, `{"power":"on"}`)
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, c.config)
+		setHostPowerState(context.TODO(), client, d, 7)
+		server.Close()
+
+		if requests != c.requests {
+			t.Errorf("setHostPowerState with %v sent [%d] power commands, expected [%d]", c.config, requests, c.requests)
+		}
+		if state := d.Get("power_state").(string); state != c.expected {
+			t.Errorf("setHostPowerState with %v set power_state [%s], expected [%s]", c.config, state, c.expected)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// hostPowerActions
+// -----------------------------------------------------------------------------
+
+// Ensures the configured power actions replace the default ones
+func TestHostPowerActions(t *testing.T) {
+	bmcHost := &api.ForemanHost{EnableBMC: true, Managed: true}
+	managedHost := &api.ForemanHost{Managed: true}
+	cases := []struct {
+		config   map[string]interface{}
+		host     *api.ForemanHost
+		commands []interface{}
+	}{
+		{
+			config: map[string]interface{}{},
+			host:   bmcHost,
+			commands: []interface{}{
+				api.BMCBoot{Device: api.BootPxe},
+				api.Power{PowerAction: api.PowerCycle},
+			},
+		},
+		{
+			config:   map[string]interface{}{},
+			host:     managedHost,
+			commands: []interface{}{api.Power{PowerAction: api.PowerOn}},
+		},
+		{
+			config:   map[string]interface{}{},
+			host:     &api.ForemanHost{},
+			commands: nil,
+		},
+		{
+			config: map[string]interface{}{
+				"power_actions": []interface{}{
+					map[string]interface{}{"power_action": "soft", "ignore_failure": true},
+					map[string]interface{}{"boot_device": "bios"},
+					map[string]interface{}{"power_action": "on"},
+				},
+			},
+			host: bmcHost,
+			commands: []interface{}{
+				api.Power{PowerAction: api.PowerSoft},
+				api.BMCBoot{Device: api.PowerBios},
+				api.Power{PowerAction: api.PowerOn},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, c.config)
+		actions := hostPowerActions(d, c.host)
+
+		var commands []interface{}
+		for _, action := range actions {
+			commands = append(commands, action.command)
+		}
+		if !reflect.DeepEqual(commands, c.commands) {
+			t.Errorf("hostPowerActions with %v returned %v, expected %v", c.config, commands, c.commands)
+		}
+	}
+}
+
+// Ensures power actions run in order, failed actions are retried, and
+// failures stop the actions unless they are ignored
+func TestRunHostPowerActions(t *testing.T) {
+	cases := []struct {
+		actions  []hostPowerAction
+		failures int
+		requests []string
+		failed   bool
+	}{
+		{
+			actions: []hostPowerAction{
+				{command: api.Power{PowerAction: api.PowerOff}},
+				{command: api.BMCBoot{Device: api.BootPxe}},
+				{command: api.Power{PowerAction: api.PowerOn}},
+			},
+			requests: []string{"power off", "boot pxe", "power on"},
+		},
+		{
+			actions: []hostPowerAction{
+				{command: api.Power{PowerAction: api.PowerSoft}, retryCount: 2},
+				{command: api.Power{PowerAction: api.PowerOn}},
+			},
+			failures: 2,
+			requests: []string{"power soft", "power soft", "power soft", "power on"},
+		},
+		{
+			actions: []hostPowerAction{
+				{command: api.Power{PowerAction: api.PowerSoft}, retryCount: 1},
+				{command: api.Power{PowerAction: api.PowerOn}},
+			},
+			failures: 2,
+			requests: []string{"power soft", "power soft"},
+			failed:   true,
+		},
+		{
+			actions: []hostPowerAction{
+				{command: api.Power{PowerAction: api.PowerSoft}, ignoreFailure: true},
+				{command: api.Power{PowerAction: api.PowerOn}},
+			},
+			failures: 1,
+			requests: []string{"power soft", "power on"},
+		},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var requests []string
+		failures := c.failures
+		mux.HandleFunc(HostsURI+"/7/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			json.NewDecoder(r.Body).Decode(&power)
+			requests = append(requests, "power "+power.PowerAction)
+			if failures > 0 {
+				failures--
+				fmt.Fprint(w, `{"power":false}`)
+				return
+			}
+			fmt.Fprint(w, `{"power":true}`)
+		})
+		mux.HandleFunc(HostsURI+"/7/boot", func(w http.ResponseWriter, r *http.Request) {
+			var boot api.BMCBoot
+			json.NewDecoder(r.Body).Decode(&boot)
+			requests = append(requests, "boot "+boot.Device)
+			fmt.Fprint(w, `{"boot":{"action":"pxe","result":true}}`)
+		})
+
+		h := &api.ForemanHost{}
+		h.Id = 7
+		err := runHostPowerActions(context.TODO(), client, h, c.actions)
+		server.Close()
+
+		if (err != nil) != c.failed {
+			t.Errorf("runHostPowerActions returned [%v], expected failure [%t]", err, c.failed)
+		}
+		if !reflect.DeepEqual(requests, c.requests) {
+			t.Errorf("runHostPowerActions sent %v, expected %v", requests, c.requests)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// interfaces_attributes
+// -----------------------------------------------------------------------------
+
+// Ensures interfaces are matched by their MAC address or identifier instead
+// of their position, so that adding, removing and reordering interfaces only
+// sends the new, changed and removed interfaces, and the state keeps the
+// order of the configuration
+func TestResourceForemanHostUpdate_InterfacesIdentity(t *testing.T) {
+	// Interfaces of the host in Foreman and the state
+	eth0 := map[string]interface{}{"id": 1, "identifier": "eth0", "mac": "c0:ff:ee:00:00:01", "ip": "10.0.0.1", "primary": true}
+	eth1 := map[string]interface{}{"id": 2, "identifier": "eth1", "mac": "c0:ff:ee:00:00:02", "ip": "10.0.0.2"}
+	bmc := map[string]interface{}{"id": 3, "identifier": "ipmi", "mac": "c0:ff:ee:00:00:03", "ip": "10.0.1.3", "type": "bmc"}
+	bond0 := map[string]interface{}{"id": 4, "identifier": "bond0", "mac": "c0:ff:ee:00:00:04", "ip": "10.0.0.4", "type": "bond"}
+
+	// Interfaces in the configuration, the BMC is identified by its MAC
+	eth0Config := map[string]interface{}{"identifier": "eth0", "primary": true}
+	eth1Config := map[string]interface{}{"identifier": "eth1"}
+	bmcConfig := map[string]interface{}{"mac": "c0:ff:ee:00:00:03", "identifier": "ipmi", "type": "bmc"}
+	bond0Config := map[string]interface{}{"identifier": "bond0", "type": "bond", "attached_devices": "eth0,eth1"}
+
+	cases := []struct {
+		name       string
+		interfaces []interface{}
+		foreman    []map[string]interface{}
+		sent       []string
+	}{
+		{
+			name:       "add",
+			interfaces: []interface{}{bond0Config, eth0Config, eth1Config, bmcConfig},
+			foreman:    []map[string]interface{}{eth0, eth1, bmc, bond0},
+			sent:       []string{"id=<nil> bond0 mac= ip="},
+		},
+		{
+			name:       "remove",
+			interfaces: []interface{}{eth0Config, bmcConfig},
+			foreman:    []map[string]interface{}{eth0, bmc},
+			sent:       []string{"id=2 eth1 mac=c0:ff:ee:00:00:02 ip=10.0.0.2 destroy"},
+		},
+		{
+			name:       "reorder",
+			interfaces: []interface{}{bmcConfig, eth1Config, eth0Config},
+			foreman:    []map[string]interface{}{eth0, eth1, bmc},
+			sent:       nil,
+		},
+		{
+			name: "change and remove",
+			interfaces: []interface{}{
+				map[string]interface{}{"identifier": "eth1", "ip": "10.0.0.12"},
+				eth0Config,
+			},
+			foreman: []map[string]interface{}{eth0, eth1},
+			sent: []string{
+				"id=2 eth1 mac=c0:ff:ee:00:00:02 ip=10.0.0.12",
+				"id=3 ipmi mac=c0:ff:ee:00:00:03 ip=10.0.1.3 destroy",
+			},
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "7",
+		Attributes: map[string]string{
+			"name":                    "host01.example.com",
+			"managed":                 "true",
+			"retry_count":             "2",
+			"interfaces_attributes.#": "3",
+		},
+	}
+	for idx, iface := range []map[string]interface{}{eth0, eth1, bmc} {
+		prefix := fmt.Sprintf("interfaces_attributes.%d.", idx)
+		for _, attr := range []string{"identifier", "mac", "ip", "type"} {
+			state.Attributes[prefix+attr], _ = iface[attr].(string)
+		}
+		state.Attributes[prefix+"id"] = strconv.Itoa(iface["id"].(int))
+		state.Attributes[prefix+"primary"] = strconv.FormatBool(iface["primary"] == true)
+		state.Attributes[prefix+"managed"] = "false"
+		state.Attributes[prefix+"provision"] = "false"
+		state.Attributes[prefix+"virtual"] = "false"
+		state.Attributes[prefix+"subnet_id"] = "0"
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var sent []string
+		mux.HandleFunc(HostsURI+"/7", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Host struct {
+					InterfacesAttributes []map[string]interface{} `json:"interfaces_attributes"`
+				} `json:"host"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, iface := range body.Host.InterfacesAttributes {
+				summary := fmt.Sprintf("id=%v %s mac=%s ip=%s", iface["id"], iface["identifier"], iface["mac"], iface["ip"])
+				if iface["_destroy"] == true {
+					summary += " destroy"
+				}
+				sent = append(sent, summary)
+			}
+			response, _ := json.Marshal(map[string]interface{}{
+				"id":                    7,
+				"name":                  "host01.example.com",
+				"interfaces_attributes": c.foreman,
+			})
+			w.Write(response)
+		})
+		mux.HandleFunc(HostsURI+"/7/vm_compute_attributes", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		})
+
+		config := map[string]interface{}{
+			"name":                  "host01.example.com",
+			"interfaces_attributes": c.interfaces,
+		}
+		d := MockForemanHostResourceDataDiff(t, state, config)
+		diags := resourceForemanHostUpdate(context.TODO(), d, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Errorf("Update of host interfaces [%s] returned [%+v]", c.name, diags)
+			continue
+		}
+		if !reflect.DeepEqual(sent, c.sent) {
+			t.Errorf("Update of host interfaces [%s] sent %q, expected %q", c.name, sent, c.sent)
+		}
+
+		// The state lists the interfaces in the order of the configuration
+		for idx, iface := range c.interfaces {
+			identifier := iface.(map[string]interface{})["identifier"]
+			if actual := d.Get(fmt.Sprintf("interfaces_attributes.%d.identifier", idx)); actual != identifier {
+				t.Errorf("Update of host interfaces [%s] set interface %d to [%s], expected [%s]", c.name, idx, actual, identifier)
+			}
+		}
+	}
+}
+
+// Ensures interfaces are matched by ID, MAC address regardless of case and
+// identifier, and interfaces without any of them by their position
+func TestMatchForemanInterfacesAttributes(t *testing.T) {
+	candidates := []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "eth0", MAC: "c0:ff:ee:00:00:01"},
+		{Id: 2, Identifier: "eth1", MAC: "c0:ff:ee:00:00:02"},
+		{Id: 3, MAC: "c0:ff:ee:00:00:03"},
+		{Id: 4, MAC: "c0:ff:ee:00:00:04"},
+	}
+	entries := []api.ForemanInterfacesAttribute{
+		{},
+		{Identifier: "eth1"},
+		{MAC: "C0:FF:EE:00:00:01"},
+		{Identifier: "eth2"},
+		{Id: 4},
+	}
+
+	// Without IDs, the last entry has no key and takes the remaining interface
+	expected := []int{2, 1, 0, -1, 3}
+	if matches := matchForemanInterfacesAttributes(entries, candidates, false); !reflect.DeepEqual(matches, expected) {
+		t.Errorf("matchForemanInterfacesAttributes without IDs returned %v, expected %v", matches, expected)
+	}
+	expected = []int{2, 1, 0, -1, 3}
+	if matches := matchForemanInterfacesAttributes(entries, candidates, true); !reflect.DeepEqual(matches, expected) {
+		t.Errorf("matchForemanInterfacesAttributes with IDs returned %v, expected %v", matches, expected)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// rebuild_triggers
+// -----------------------------------------------------------------------------
+
+// Ensures a change of the rebuild triggers sets the build flag, rebuilds the
+// orchestration configuration and runs the power actions of the host, while
+// other changes, removed triggers or triggers added to an existing host do not
+// rebuild it
+func TestResourceForemanHostUpdate_RebuildTriggers(t *testing.T) {
+	cases := []struct {
+		stateTriggers map[string]string
+		triggers      map[string]interface{}
+		comment       string
+		requests      []string
+	}{
+		{
+			stateTriggers: map[string]string{"os": "8.7"},
+			triggers:      map[string]interface{}{"os": "8.8"},
+			requests:      []string{"update build=true", "rebuild_config", "power cycle"},
+		},
+		{
+			stateTriggers: map[string]string{"os": "8.7"},
+			triggers:      map[string]interface{}{"os": "8.7"},
+			comment:       "database",
+			requests:      []string{"update build=false"},
+		},
+		{
+			stateTriggers: map[string]string{"os": "8.7"},
+			triggers:      map[string]interface{}{},
+			requests:      nil,
+		},
+		{
+			stateTriggers: map[string]string{},
+			triggers:      map[string]interface{}{"os": "8.8"},
+			requests:      nil,
+		},
+	}
+
+	for _, c := range cases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		var requests []string
+		mux.HandleFunc(HostsURI+"/7", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Host struct {
+					Build bool `json:"build"`
+				} `json:"host"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			requests = append(requests, fmt.Sprintf("update build=%t", body.Host.Build))
+			fmt.Fprint(w, `{"id":7,"name":"host01.example.com"}`)
+		})
+		mux.HandleFunc(HostsURI+"/7/vm_compute_attributes", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		})
+		mux.HandleFunc(HostsURI+"/7/rebuild_config", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, "rebuild_config")
+			fmt.Fprint(w, `{"message":"Configuration successfully rebuilt"}`)
+		})
+		mux.HandleFunc(HostsURI+"/7/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			json.NewDecoder(r.Body).Decode(&power)
+			requests = append(requests, "power "+power.PowerAction)
+			fmt.Fprint(w, `{"power":true}`)
+		})
+
+		state := &terraform.InstanceState{
+			ID: "7",
+			Attributes: map[string]string{
+				"name":                           "host01.example.com",
+				"managed":                        "true",
+				"retry_count":                    "2",
+				"rebuild_orchestration":          "true",
+				"power_actions.#":                "1",
+				"power_actions.0.power_action":   "cycle",
+				"power_actions.0.delay":          "0",
+				"power_actions.0.retry_count":    "0",
+				"power_actions.0.retry_delay":    "0",
+				"power_actions.0.ignore_failure": "false",
+			},
+		}
+		state.Attributes["rebuild_triggers.%"] = strconv.Itoa(len(c.stateTriggers))
+		for key, value := range c.stateTriggers {
+			state.Attributes["rebuild_triggers."+key] = value
+		}
+		config := map[string]interface{}{
+			"name":                  "host01.example.com",
+			"comment":               c.comment,
+			"rebuild_triggers":      c.triggers,
+			"rebuild_orchestration": true,
+			"power_actions": []interface{}{
+				map[string]interface{}{"power_action": "cycle", "delay": 0, "retry_delay": 0},
+			},
+		}
+		d := MockForemanHostResourceDataDiff(t, state, config)
+		diags := resourceForemanHostUpdate(context.TODO(), d, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Errorf("Update of host with rebuild triggers %v returned [%+v]", c.triggers, diags)
+			continue
+		}
+		if !reflect.DeepEqual(requests, c.requests) {
+			t.Errorf(
+				"Update of host with rebuild triggers %v to %v sent %v, expected %v",
+				c.stateTriggers,
+				c.triggers,
+				requests,
+				c.requests,
+			)
+		}
+		if d.Id() != "7" {
+			t.Errorf("Update of host with rebuild triggers %v changed its ID to [%s]", c.triggers, d.Id())
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -237,6 +237,9 @@ func resourceForemanHostgroup() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the subnet associated with the hostgroup.",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("hostgroup"),
+			"organization_ids": foremanOrganizationIdsSchema("hostgroup"),
 		},
 	}
 }
@@ -349,6 +352,8 @@ func buildForemanHostgroup(d *schema.ResourceData) *api.ForemanHostgroup {
 		hostgroup.HostGroupParameters = api.ToKV(attr.(map[string]interface{}))
 	}
 
+	hostgroup.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &hostgroup
 }
 
@@ -380,6 +385,7 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("puppet_proxy_id", fh.PuppetProxyId)
 	d.Set("realm_id", fh.RealmId)
 	d.Set("subnet_id", fh.SubnetId)
+	setResourceDataFromForemanTaxonomyIds(d, fh.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -58,6 +58,9 @@ func resourceForemanHTTPProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"location_ids":     foremanLocationIdsSchema("HTTP proxy"),
+			"organization_ids": foremanOrganizationIdsSchema("HTTP proxy"),
 		},
 	}
 }
@@ -80,6 +83,8 @@ func buildForemanHTTPProxy(d *schema.ResourceData) *api.ForemanHTTPProxy {
 
 	proxy.URL = d.Get("url").(string)
 
+	proxy.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &proxy
 }
 
@@ -91,6 +96,7 @@ func setResourceDataFromForemanHTTPProxy(d *schema.ResourceData, fp *api.Foreman
 	d.SetId(strconv.Itoa(fp.Id))
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+	setResourceDataFromForemanTaxonomyIds(d, fp.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -93,6 +93,9 @@ func resourceForemanJobTemplate() *schema.Resource {
 				Type:     schema.TypeList,
 				Elem:     resourceForemanTemplateInput(),
 			},
+
+			"location_ids":     foremanLocationIdsSchema("job template"),
+			"organization_ids": foremanOrganizationIdsSchema("job template"),
 		},
 	}
 }
@@ -161,6 +164,8 @@ func buildForemanJobTemplate(d *schema.ResourceData) *api.ForemanJobTemplate {
 		jt.TemplateInputs = inputs
 	}
 
+	jt.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	utils.Debug("jt: %+v", jt)
 
 	return &jt
@@ -178,6 +183,7 @@ func setResourceDataFromForemanJobTemplate(resdata *schema.ResourceData, jt *api
 	resdata.Set("job_category", jt.JobCategory)
 	resdata.Set("provider_type", jt.ProviderType)
 	resdata.Set("snippet", jt.Snippet)
+	setResourceDataFromForemanTaxonomyIds(resdata, jt.ForemanTaxonomyIds)
 
 	utils.Debug("TemplateInputs: %+v", jt.TemplateInputs)
 
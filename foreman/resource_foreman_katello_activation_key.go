@@ -0,0 +1,380 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanKatelloActivationKey() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanKatelloActivationKeyCreate,
+		ReadContext:   resourceForemanKatelloActivationKeyRead,
+		UpdateContext: resourceForemanKatelloActivationKeyUpdate,
+		DeleteContext: resourceForemanKatelloActivationKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Activation keys define the content view, lifecycle environment, "+
+						"repositories and host collections of hosts registering with them.",
+					autodoc.MetaSummary,
+				),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the activation key. "+
+						"%s \"rhel9-production\"",
+					autodoc.MetaExample,
+				),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the activation key.",
+			},
+			"organization_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  fmt.Sprintf("ID of the organization of the activation key. %s 1", autodoc.MetaExample),
+			},
+			"content_view_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"lifecycle_environment_id"},
+				Description: "ID of the content view hosts registering with this key are assigned to. " +
+					"Requires lifecycle_environment_id.",
+			},
+			"lifecycle_environment_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"content_view_id"},
+				Description: "ID of the lifecycle environment hosts registering with this key are " +
+					"assigned to. Requires content_view_id.",
+			},
+			"max_hosts": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"unlimited_hosts"},
+				Description: "Maximum number of hosts that may register with this key. If not set, " +
+					"the number of hosts is unlimited.",
+			},
+			"unlimited_hosts": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether an unlimited number of hosts may register with this key, i.e. " +
+					"max_hosts is not set.",
+			},
+			"release_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Release version hosts registering with this key are pinned to. "+
+						"%s \"9.2\"",
+					autodoc.MetaExample,
+				),
+			},
+			"service_level": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Service level of the subscriptions attached by this key. "+
+						"%s \"Premium\"",
+					autodoc.MetaExample,
+				),
+			},
+			"auto_attach": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether subscriptions are attached automatically to hosts registering " +
+					"with this key. Defaults to `true`.",
+			},
+			"host_collection_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the host collections hosts registering with this key are added to.",
+			},
+			"content_override": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "Enables or disables repositories for hosts registering with this key, " +
+					"overriding the default of the repository.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_label": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"Content label of the repository. "+
+									"%s \"rhel-9-for-x86_64-appstream-rpms\"",
+								autodoc.MetaExample,
+							),
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether the repository is enabled.",
+						},
+					},
+				},
+			},
+			"usage_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of hosts registered with this key.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanKatelloActivationKey constructs a ForemanKatelloActivationKey reference
+// from a resource data reference. The struct's members are populated from the data
+// populated in the resource data. Missing members will be left to the zero value for
+// that member's type.
+func buildForemanKatelloActivationKey(d *schema.ResourceData) *api.ForemanKatelloActivationKey {
+	log.Tracef("resource_foreman_katello_activation_key.go#buildForemanKatelloActivationKey")
+
+	ak := api.ForemanKatelloActivationKey{}
+	ak.ForemanObject = *buildForemanObject(d)
+
+	ak.Description = d.Get("description").(string)
+	ak.OrganizationId = d.Get("organization_id").(int)
+	ak.ContentViewId = d.Get("content_view_id").(int)
+	ak.EnvironmentId = d.Get("lifecycle_environment_id").(int)
+	ak.MaxHosts = d.Get("max_hosts").(int)
+	ak.UnlimitedHosts = ak.MaxHosts == 0
+	ak.ReleaseVersion = d.Get("release_version").(string)
+	ak.ServiceLevel = d.Get("service_level").(string)
+	ak.AutoAttach = d.Get("auto_attach").(bool)
+
+	for _, id := range d.Get("host_collection_ids").(*schema.Set).List() {
+		ak.HostCollections = append(ak.HostCollections, api.ForemanKatelloActivationKeyReference{Id: id.(int)})
+	}
+
+	ak.ContentOverrides = buildForemanKatelloContentOverrides(d.Get("content_override").(*schema.Set))
+
+	return &ak
+}
+
+// buildForemanKatelloContentOverrides converts the content_override set into
+// content overrides
+func buildForemanKatelloContentOverrides(s *schema.Set) []api.ForemanKatelloContentOverride {
+	var overrides []api.ForemanKatelloContentOverride
+	for _, item := range s.List() {
+		m := item.(map[string]interface{})
+		value := "0"
+		if m["enabled"].(bool) {
+			value = "1"
+		}
+		overrides = append(overrides, api.ForemanKatelloContentOverride{
+			ContentLabel: m["content_label"].(string),
+			Name:         "enabled",
+			Value:        value,
+		})
+	}
+	return overrides
+}
+
+// setResourceDataFromForemanKatelloActivationKey sets a ResourceData's attributes
+// from the attributes of the supplied ForemanKatelloActivationKey reference
+func setResourceDataFromForemanKatelloActivationKey(d *schema.ResourceData, ak *api.ForemanKatelloActivationKey) {
+	log.Tracef("resource_foreman_katello_activation_key.go#setResourceDataFromForemanKatelloActivationKey")
+
+	d.SetId(strconv.Itoa(ak.Id))
+	d.Set("name", ak.Name)
+	d.Set("description", ak.Description)
+	d.Set("organization_id", ak.OrganizationId)
+	d.Set("content_view_id", ak.ContentViewId)
+	d.Set("lifecycle_environment_id", ak.EnvironmentId)
+	d.Set("unlimited_hosts", ak.UnlimitedHosts)
+	if ak.UnlimitedHosts {
+		d.Set("max_hosts", 0)
+	} else {
+		d.Set("max_hosts", ak.MaxHosts)
+	}
+	d.Set("release_version", ak.ReleaseVersion)
+	d.Set("service_level", ak.ServiceLevel)
+	d.Set("auto_attach", ak.AutoAttach)
+	d.Set("usage_count", ak.UsageCount)
+	d.Set("host_collection_ids", ak.HostCollectionIds())
+
+	var overrides []interface{}
+	for _, co := range ak.ContentOverrides {
+		// Only the enabled override is managed by this resource
+		if co.Name != "" && co.Name != "enabled" {
+			continue
+		}
+		overrides = append(overrides, map[string]interface{}{
+			"content_label": co.ContentLabel,
+			"enabled":       co.Enabled(),
+		})
+	}
+	d.Set("content_override", overrides)
+}
+
+// updateForemanKatelloActivationKeyAssociations converges the host collections and
+// content overrides of the activation key from the old to the new state
+func updateForemanKatelloActivationKeyAssociations(ctx context.Context, client *api.Client, d *schema.ResourceData, id int) error {
+	if d.HasChange("host_collection_ids") {
+		o, n := d.GetChange("host_collection_ids")
+		oldSet := o.(*schema.Set)
+		newSet := n.(*schema.Set)
+
+		var added, removed []int
+		for _, item := range newSet.Difference(oldSet).List() {
+			added = append(added, item.(int))
+		}
+		for _, item := range oldSet.Difference(newSet).List() {
+			removed = append(removed, item.(int))
+		}
+
+		log.Debugf("host collections added: [%v], removed: [%v]", added, removed)
+
+		if len(added) > 0 {
+			if err := client.AddKatelloActivationKeyHostCollections(ctx, id, added); err != nil {
+				return err
+			}
+		}
+		if len(removed) > 0 {
+			if err := client.RemoveKatelloActivationKeyHostCollections(ctx, id, removed); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("content_override") {
+		o, n := d.GetChange("content_override")
+		overrides := buildForemanKatelloContentOverrides(n.(*schema.Set))
+
+		var labels []string
+		for _, co := range overrides {
+			labels = append(labels, co.ContentLabel)
+		}
+
+		var removed []string
+		for _, co := range buildForemanKatelloContentOverrides(o.(*schema.Set)) {
+			if !slices.Contains(labels, co.ContentLabel) {
+				removed = append(removed, co.ContentLabel)
+			}
+		}
+
+		log.Debugf("content overrides: [%+v], removed: [%v]", overrides, removed)
+
+		if len(overrides) > 0 || len(removed) > 0 {
+			if err := client.SetKatelloActivationKeyContentOverrides(ctx, id, overrides, removed); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanKatelloActivationKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_activation_key.go#Create")
+
+	client := meta.(*api.Client)
+	ak := buildForemanKatelloActivationKey(d)
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", ak)
+
+	createdActivationKey, createErr := client.CreateKatelloActivationKey(ctx, ak)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanKatelloActivationKey: [%+v]", createdActivationKey)
+
+	d.SetId(strconv.Itoa(createdActivationKey.Id))
+
+	if err := updateForemanKatelloActivationKeyAssociations(ctx, client, d, createdActivationKey.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceForemanKatelloActivationKeyRead(ctx, d, meta)
+}
+
+func resourceForemanKatelloActivationKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_activation_key.go#Read")
+
+	client := meta.(*api.Client)
+	ak := buildForemanKatelloActivationKey(d)
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", ak)
+
+	readActivationKey, readErr := client.ReadKatelloActivationKey(ctx, ak.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanKatelloActivationKey: [%+v]", readActivationKey)
+
+	setResourceDataFromForemanKatelloActivationKey(d, readActivationKey)
+
+	return nil
+}
+
+func resourceForemanKatelloActivationKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_activation_key.go#Update")
+
+	client := meta.(*api.Client)
+	ak := buildForemanKatelloActivationKey(d)
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", ak)
+
+	updatedActivationKey, updateErr := client.UpdateKatelloActivationKey(ctx, ak)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanKatelloActivationKey: [%+v]", updatedActivationKey)
+
+	if err := updateForemanKatelloActivationKeyAssociations(ctx, client, d, ak.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceForemanKatelloActivationKeyRead(ctx, d, meta)
+}
+
+func resourceForemanKatelloActivationKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_activation_key.go#Delete")
+
+	client := meta.(*api.Client)
+	ak := buildForemanKatelloActivationKey(d)
+
+	log.Debugf("ForemanKatelloActivationKey: [%+v]", ak)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteKatelloActivationKey(ctx, ak.Id)))
+}
@@ -0,0 +1,344 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const KatelloActivationKeysURI = "/katello/api/activation_keys"
+
+// Given a ForemanKatelloActivationKey, create a mock instance state reference
+func ForemanKatelloActivationKeyToInstanceState(obj api.ForemanKatelloActivationKey) *terraform.InstanceState {
+	// Sets are stored under hashes of their elements, let the schema build
+	// the attribute map
+	d := resourceForemanKatelloActivationKey().TestResourceData()
+	d.SetId(strconv.Itoa(obj.Id))
+	d.Set("name", obj.Name)
+	d.Set("description", obj.Description)
+	d.Set("organization_id", obj.OrganizationId)
+	d.Set("content_view_id", obj.ContentViewId)
+	d.Set("lifecycle_environment_id", obj.EnvironmentId)
+	d.Set("max_hosts", obj.MaxHosts)
+	d.Set("unlimited_hosts", obj.UnlimitedHosts)
+	d.Set("release_version", obj.ReleaseVersion)
+	d.Set("service_level", obj.ServiceLevel)
+	d.Set("auto_attach", obj.AutoAttach)
+	d.Set("usage_count", obj.UsageCount)
+	d.Set("host_collection_ids", obj.HostCollectionIds())
+	var overrides []interface{}
+	for _, co := range obj.ContentOverrides {
+		overrides = append(overrides, map[string]interface{}{
+			"content_label": co.ContentLabel,
+			"enabled":       co.Enabled(),
+		})
+	}
+	d.Set("content_override", overrides)
+	return d.State()
+}
+
+// Given a mock instance state for a ForemanKatelloActivationKey resource,
+// create a mock ResourceData reference.
+func MockForemanKatelloActivationKeyResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanKatelloActivationKey()
+	return r.Data(s)
+}
+
+// Creates a random ForemanKatelloActivationKey struct
+func RandForemanKatelloActivationKey() api.ForemanKatelloActivationKey {
+	obj := api.ForemanKatelloActivationKey{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+	obj.OrganizationId = rand.Intn(100) + 1
+	obj.ContentViewId = rand.Intn(100) + 1
+	obj.EnvironmentId = rand.Intn(100) + 1
+	obj.UnlimitedHosts = rand.Intn(2) == 0
+	if !obj.UnlimitedHosts {
+		obj.MaxHosts = rand.Intn(100) + 1
+	}
+	obj.UsageCount = rand.Intn(100)
+	obj.ReleaseVersion = tfrand.String(3, tfrand.Digit)
+	obj.ServiceLevel = tfrand.String(10, tfrand.Lower)
+	obj.AutoAttach = rand.Intn(2) == 0
+
+	for i := 0; i < rand.Intn(5); i++ {
+		obj.HostCollections = append(obj.HostCollections, api.ForemanKatelloActivationKeyReference{
+			Id: rand.Intn(1000) + 1,
+		})
+	}
+	for i := 0; i < rand.Intn(5); i++ {
+		obj.ContentOverrides = append(obj.ContentOverrides, api.ForemanKatelloContentOverride{
+			ContentLabel: tfrand.String(20, tfrand.Lower+"-"),
+			Name:         "enabled",
+			Value:        strconv.Itoa(rand.Intn(2)),
+		})
+	}
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanKatelloActivationKey
+// resource. If the two references differ in their attributes, the test will
+// raise a fatal.
+func ForemanKatelloActivationKeyResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map, the sets are compared on their own
+	m := map[string]schema.ValueType{}
+	r := resourceForemanKatelloActivationKey()
+	for key, value := range r.Schema {
+		if value.Type == schema.TypeSet {
+			continue
+		}
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, key := range []string{"host_collection_ids", "content_override"} {
+		s1 := r1.Get(key).(*schema.Set)
+		s2 := r2.Get(key).(*schema.Set)
+		if !s1.Equal(s2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. [%v], [%v]",
+				key,
+				s1.List(),
+				s2.List(),
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanKatelloActivationKey
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanKatelloActivationKey_Value(t *testing.T) {
+
+	expectedObj := RandForemanKatelloActivationKey()
+	expectedState := ForemanKatelloActivationKeyToInstanceState(expectedObj)
+	expectedResourceData := MockForemanKatelloActivationKeyResourceData(expectedState)
+
+	actualObj := api.ForemanKatelloActivationKey{}
+	actualState := ForemanKatelloActivationKeyToInstanceState(actualObj)
+	actualResourceData := MockForemanKatelloActivationKeyResourceData(actualState)
+
+	setResourceDataFromForemanKatelloActivationKey(actualResourceData, &expectedObj)
+
+	ForemanKatelloActivationKeyResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// Ensures the ResourceData's attributes are built back into the activation
+// key they were set from
+func TestBuildForemanKatelloActivationKey_Value(t *testing.T) {
+
+	expectedObj := RandForemanKatelloActivationKey()
+	d := MockForemanKatelloActivationKeyResourceData(ForemanKatelloActivationKeyToInstanceState(expectedObj))
+
+	actualObj := buildForemanKatelloActivationKey(d)
+
+	// Only the IDs of the host collections and the attributes of the key
+	// are read back
+	expectedObj.CreatedAt, expectedObj.UpdatedAt = "", ""
+	expectedObj.UsageCount = 0
+	for idx := range expectedObj.HostCollections {
+		expectedObj.HostCollections[idx].Name = ""
+	}
+
+	actualJSON, _ := json.Marshal(actualObj)
+	expectedJSON, _ := json.Marshal(expectedObj)
+	if string(actualJSON) != string(expectedJSON) {
+		t.Errorf("buildForemanKatelloActivationKey built [%s], expected [%s]", actualJSON, expectedJSON)
+	}
+	// the random host collections may repeat an ID, compare them as sets
+	actualIds := schema.NewSet(schema.HashInt, nil)
+	for _, id := range actualObj.HostCollectionIds() {
+		actualIds.Add(id)
+	}
+	expectedIds := schema.NewSet(schema.HashInt, nil)
+	for _, id := range expectedObj.HostCollectionIds() {
+		expectedIds.Add(id)
+	}
+	if !actualIds.Equal(expectedIds) {
+		t.Errorf(
+			"buildForemanKatelloActivationKey built the host collections %v, expected %v",
+			actualObj.HostCollectionIds(),
+			expectedObj.HostCollectionIds(),
+		)
+	}
+	if len(actualObj.ContentOverrides) != len(expectedObj.ContentOverrides) {
+		t.Errorf(
+			"buildForemanKatelloActivationKey built the content overrides [%+v], expected [%+v]",
+			actualObj.ContentOverrides,
+			expectedObj.ContentOverrides,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// updateForemanKatelloActivationKeyAssociations
+// -----------------------------------------------------------------------------
+
+// Ensures an update adds and removes the changed host collections and sets
+// and removes the changed content overrides through their own endpoints
+func TestResourceForemanKatelloActivationKeyUpdate_Associations(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	akURIById := KatelloActivationKeysURI + "/5"
+	requests := map[string]map[string]interface{}{}
+
+	mux.HandleFunc(akURIById, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			t.Errorf("%s was requested with method [%s]", r.URL.Path, r.Method)
+		}
+		fmt.Fprint(w, `{"id":5,"name":"rhel9","organization_id":1,"unlimited_hosts":true}`)
+	})
+	for _, path := range []string{"/host_collections", "/content_override"} {
+		path := path
+		mux.HandleFunc(akURIById+path, func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			requests[r.Method+" "+path] = body
+			fmt.Fprint(w, `{}`)
+		})
+	}
+
+	current := resourceForemanKatelloActivationKey().TestResourceData()
+	current.SetId("5")
+	current.Set("name", "rhel9")
+	current.Set("organization_id", 1)
+	current.Set("host_collection_ids", []interface{}{1, 2})
+	current.Set("content_override", []interface{}{
+		map[string]interface{}{"content_label": "appstream", "enabled": true},
+		map[string]interface{}{"content_label": "codeready", "enabled": true},
+	})
+	state := current.State()
+
+	config := map[string]interface{}{
+		"name":                "rhel9",
+		"organization_id":     1,
+		"host_collection_ids": []interface{}{2, 3},
+		"content_override": []interface{}{
+			map[string]interface{}{"content_label": "appstream", "enabled": false},
+		},
+	}
+	d := MockResourceDataDiff(t, resourceForemanKatelloActivationKey(), state, config)
+	if diags := resourceForemanKatelloActivationKeyUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the activation key returned [%+v]", diags)
+	}
+
+	expected := map[string]map[string]interface{}{
+		"POST /host_collections": {"host_collection_ids": []interface{}{3.0}},
+		"PUT /host_collections":  {"host_collection_ids": []interface{}{1.0}},
+		"PUT /content_override": {"content_overrides": []interface{}{
+			map[string]interface{}{"content_label": "appstream", "name": "enabled", "value": "0"},
+			map[string]interface{}{"content_label": "codeready", "name": "enabled", "remove": true},
+		}},
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Update of the activation key requested [%v], expected [%v]", requests, expected)
+	}
+}
+
+// Ensures an update of the activation key's attributes does not touch the
+// unchanged host collections and content overrides
+func TestResourceForemanKatelloActivationKeyUpdate_UnchangedAssociations(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	akURIById := KatelloActivationKeysURI + "/5"
+	mux.HandleFunc(akURIById, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"name":"rhel9","organization_id":1,"unlimited_hosts":true}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Update of the activation key requested [%s %s]", r.Method, r.URL.Path)
+	})
+
+	current := resourceForemanKatelloActivationKey().TestResourceData()
+	current.SetId("5")
+	current.Set("name", "rhel9")
+	current.Set("organization_id", 1)
+	current.Set("host_collection_ids", []interface{}{1})
+	state := current.State()
+
+	config := map[string]interface{}{
+		"name":                "rhel9-production",
+		"organization_id":     1,
+		"host_collection_ids": []interface{}{1},
+	}
+	d := MockResourceDataDiff(t, resourceForemanKatelloActivationKey(), state, config)
+	if diags := resourceForemanKatelloActivationKeyUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the activation key returned [%+v]", diags)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanKatelloActivationKeyCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanKatelloActivationKey{}
+	obj.Id = rand.Intn(100)
+	s := ForemanKatelloActivationKeyToInstanceState(obj)
+	akURIById := KatelloActivationKeysURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanKatelloActivationKeyRead",
+				crudFunc:     resourceForemanKatelloActivationKeyRead,
+				resourceData: MockForemanKatelloActivationKeyResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    akURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanKatelloActivationKeyDelete",
+				crudFunc:     resourceForemanKatelloActivationKeyDelete,
+				resourceData: MockForemanKatelloActivationKeyResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    akURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
@@ -0,0 +1,332 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
+)
+
+// Label of the Library lifecycle environment. Every published version is
+// added to it automatically, so it is not managed through environment_ids.
+const katelloLibraryEnvironmentLabel = "Library"
+
+func resourceForemanKatelloContentViewVersion() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanKatelloContentViewVersionCreate,
+		ReadContext:   resourceForemanKatelloContentViewVersionRead,
+		UpdateContext: resourceForemanKatelloContentViewVersionUpdate,
+		DeleteContext: resourceForemanKatelloContentViewVersionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceForemanKatelloContentViewVersionImport,
+		},
+
+		// Publishing, promoting and removing versions are asynchronous Foreman
+		// tasks, the timeouts define how long to wait for them to finish.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A published version of a (composite) content view. Creating the resource publishes "+
+						"a new version, which is then promoted to the given lifecycle environments. Changing "+
+						"`triggers` publishes another version - combine it with `create_before_destroy` to "+
+						"keep the previous version until the new one is promoted.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"content_view_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  fmt.Sprintf("ID of the (composite) content view to publish. %s 3", autodoc.MetaExample),
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: fmt.Sprintf("Description of the published version. %s \"Patches 2024-05\"", autodoc.MetaExample),
+			},
+
+			"major": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"minor"},
+				Description:  "Major version of the published version. Katello picks the next version if not set.",
+			},
+
+			"minor": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				RequiredWith: []string{"major"},
+				Description:  "Minor version of the published version. Katello picks the next version if not set.",
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, publish a new version " +
+					"of the content view.",
+			},
+
+			"environment_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Description: fmt.Sprintf(
+					"IDs of the lifecycle environments to promote the version to, following the "+
+						"lifecycle path. The Library environment is always part of a new version and "+
+						"must not be listed. Removing an environment removes the content view from it. "+
+						"An environment a newer version is promoted to is kept in the list and not "+
+						"promoted again, i.e. changes of the environments outside of Terraform are "+
+						"not detected. %s [2, 3]",
+					autodoc.MetaExample,
+				),
+			},
+
+			"force_promote": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Promote to the environments even if the version was not promoted to " +
+					"their prior environment in the lifecycle path. Defaults to `false`.",
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the version, consisting of the content view name and the version.",
+			},
+
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version string, e.g. `3.0`.",
+			},
+
+			"published_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp of when the version was published.",
+			},
+
+			"library_environment_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "ID of the Library environment while this version is the latest " +
+					"published version, `0` otherwise.",
+			},
+		},
+	}
+}
+
+func buildForemanKatelloContentViewVersion(d *schema.ResourceData) *api.ContentViewVersion {
+	utils.TraceFunctionCall()
+
+	cvv := api.ContentViewVersion{}
+	cvv.ForemanObject = *buildForemanObject(d)
+
+	cvv.ContentViewId = d.Get("content_view_id").(int)
+	cvv.Description = d.Get("description").(string)
+	cvv.Major = d.Get("major").(int)
+	cvv.Minor = d.Get("minor").(int)
+
+	return &cvv
+}
+
+func setResourceDataFromForemanKatelloContentViewVersion(d *schema.ResourceData, cvv *api.ContentViewVersion) {
+	utils.TraceFunctionCall()
+
+	d.SetId(strconv.Itoa(cvv.Id))
+	d.Set("content_view_id", cvv.ContentViewId)
+	d.Set("description", cvv.Description)
+	d.Set("major", cvv.Major)
+	d.Set("minor", cvv.Minor)
+	d.Set("name", cvv.Name)
+	d.Set("version", cvv.Version)
+	d.Set("published_at", cvv.CreatedAt)
+
+	// environment_ids only holds the configured environments. Katello moves an
+	// environment to the newer version promoted to it, which must not show up
+	// as a change that promotes this version again.
+	libraryId := 0
+	for _, env := range cvv.Environments {
+		if env.Label == katelloLibraryEnvironmentLabel {
+			libraryId = env.Id
+		}
+	}
+	d.Set("library_environment_id", libraryId)
+}
+
+// katelloContentViewVersionEnvironmentIds returns the IDs of the environments
+// the version is promoted to, except for the Library environment
+func katelloContentViewVersionEnvironmentIds(cvv *api.ContentViewVersion) []int {
+	var envIds []int
+	for _, env := range cvv.Environments {
+		if env.Label != katelloLibraryEnvironmentLabel {
+			envIds = append(envIds, env.Id)
+		}
+	}
+	return envIds
+}
+
+func resourceForemanKatelloContentViewVersionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	utils.TraceFunctionCall()
+
+	client := meta.(*api.Client)
+	cvv := buildForemanKatelloContentViewVersion(d)
+	envIds := conv.InterfaceSliceToIntSlice(d.Get("environment_ids").(*schema.Set).List())
+	utils.Debugf("cvv: %+v, environments: %v", cvv, envIds)
+
+	publishedCvv, err := client.PublishKatelloContentViewVersion(ctx, cvv)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	utils.Debugf("publishedCvv: %+v", publishedCvv)
+
+	// Store the published version right away, it exists even if promoting fails
+	setResourceDataFromForemanKatelloContentViewVersion(d, publishedCvv)
+
+	if len(envIds) > 0 {
+		promotedCvv, err := client.PromoteKatelloContentViewVersion(ctx, publishedCvv, envIds, d.Get("force_promote").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		utils.Debugf("promotedCvv: %+v", promotedCvv)
+
+		setResourceDataFromForemanKatelloContentViewVersion(d, promotedCvv)
+	}
+
+	return nil
+}
+
+func resourceForemanKatelloContentViewVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	utils.TraceFunctionCall()
+
+	client := meta.(*api.Client)
+	cvv := buildForemanKatelloContentViewVersion(d)
+
+	readCvv, err := client.ReadKatelloContentViewVersion(ctx, cvv.Id)
+	if err != nil {
+		return diag.FromErr(api.CheckDeleted(d, err))
+	}
+	utils.Debugf("readCvv: %+v", readCvv)
+
+	setResourceDataFromForemanKatelloContentViewVersion(d, readCvv)
+	return nil
+}
+
+func resourceForemanKatelloContentViewVersionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	utils.TraceFunctionCall()
+
+	client := meta.(*api.Client)
+	cvv := buildForemanKatelloContentViewVersion(d)
+
+	if d.HasChange("environment_ids") {
+		readCvv, err := client.ReadKatelloContentViewVersion(ctx, cvv.Id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		o, n := d.GetChange("environment_ids")
+		oldSet := o.(*schema.Set)
+		newSet := n.(*schema.Set)
+
+		// Only promote the added environments, the others may have been
+		// superseded by a newer version in the meantime
+		added := conv.InterfaceSliceToIntSlice(newSet.Difference(oldSet).List())
+
+		// Only remove the content view from the environments this version is
+		// still promoted to, not from the ones holding a newer version
+		promoted := katelloContentViewVersionEnvironmentIds(readCvv)
+		var removed []int
+		for _, envId := range conv.InterfaceSliceToIntSlice(oldSet.Difference(newSet).List()) {
+			if slices.Contains(promoted, envId) {
+				removed = append(removed, envId)
+			}
+		}
+
+		if len(added) > 0 {
+			readCvv, err = client.PromoteKatelloContentViewVersion(ctx, readCvv, added, d.Get("force_promote").(bool))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if len(removed) > 0 {
+			utils.Debugf("removing content view %d from environments %v", cvv.ContentViewId, removed)
+			err = client.RemoveKatelloContentViewFromEnvironments(ctx, cvv.ContentViewId, removed)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceForemanKatelloContentViewVersionRead(ctx, d, meta)
+}
+
+// resourceForemanKatelloContentViewVersionImport sets the environment_ids of
+// the imported version to all environments it is promoted to
+func resourceForemanKatelloContentViewVersionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	utils.TraceFunctionCall()
+
+	client := meta.(*api.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Unexpected import ID [%s], expected the ID of the version: %s", d.Id(), err)
+	}
+
+	readCvv, err := client.ReadKatelloContentViewVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("environment_ids", katelloContentViewVersionEnvironmentIds(readCvv))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceForemanKatelloContentViewVersionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	utils.TraceFunctionCall()
+
+	client := meta.(*api.Client)
+	cvv := buildForemanKatelloContentViewVersion(d)
+
+	// Read the version to know which environments it is still promoted to
+	readCvv, err := client.ReadKatelloContentViewVersion(ctx, cvv.Id)
+	if err != nil {
+		return diag.FromErr(api.CheckDeleted(d, err))
+	}
+
+	utils.Debugf("cvv to be deleted: %+v", readCvv)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteKatelloContentViewVersion(ctx, readCvv)))
+}
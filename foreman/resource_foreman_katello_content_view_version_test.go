@@ -0,0 +1,183 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures creating a version publishes the content view, promotes the new
+// version to the configured environments and stores the promoted version
+func TestResourceForemanKatelloContentViewVersionCreate(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{
+		TaskPollInterval: time.Millisecond,
+	})
+	defer server.Close()
+
+	environments := `[{"id":1,"name":"Library","label":"Library"}]`
+	var publishBody, promoteBody map[string]interface{}
+
+	mux.HandleFunc("/katello/api/content_views/3/publish", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Publish was requested with method [%s], expected [POST]", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&publishBody)
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"publish","label":"Actions::Katello::ContentView::Publish","pending":true}`)
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/publish", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"publish","label":"Actions::Katello::ContentView::Publish",`+
+			`"pending":false,"state":"stopped","result":"success","output":{"content_view_id":3}}`)
+	})
+	mux.HandleFunc("/katello/api/content_views/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"base","latest_version_id":12}`)
+	})
+	mux.HandleFunc("/katello/api/content_views/3/filters", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"results":[]}`)
+	})
+	mux.HandleFunc("/katello/api/environments/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"name":"Test","prior":{"id":1}}`)
+	})
+	mux.HandleFunc("/katello/api/content_view_versions/12/promote", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Promote was requested with method [%s], expected [POST]", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&promoteBody)
+		environments = `[{"id":1,"name":"Library","label":"Library"},{"id":2,"name":"Test","label":"Test"}]`
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"promote","label":"Actions::Katello::ContentView::Promote","pending":true}`)
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/promote", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"promote","label":"Actions::Katello::ContentView::Promote",`+
+			`"pending":false,"state":"stopped","result":"success"}`)
+	})
+	mux.HandleFunc("/katello/api/content_view_versions/12", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":12,"name":"base 1.0","version":"1.0","major":1,"minor":0,`+
+			`"content_view":{"id":3,"name":"base"},"environments":%s}`, environments)
+	})
+
+	d := resourceForemanKatelloContentViewVersion().TestResourceData()
+	d.Set("content_view_id", 3)
+	d.Set("description", "Patches 2024-05")
+	d.Set("environment_ids", []interface{}{2})
+
+	if diags := resourceForemanKatelloContentViewVersionCreate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Create of the content view version returned [%+v]", diags)
+	}
+
+	if publishBody["description"] != "Patches 2024-05" {
+		t.Errorf("Publish was requested with [%v]", publishBody)
+	}
+	expectedPromote := map[string]interface{}{"environment_ids": []interface{}{2.0}, "force": false}
+	if !reflect.DeepEqual(promoteBody, expectedPromote) {
+		t.Errorf("Promote was requested with [%v], expected [%v]", promoteBody, expectedPromote)
+	}
+	if d.Id() != "12" || d.Get("version") != "1.0" || d.Get("library_environment_id") != 1 {
+		t.Errorf(
+			"Create stored the version [%s] %s with Library [%v]",
+			d.Id(),
+			d.Get("version"),
+			d.Get("library_environment_id"),
+		)
+	}
+	if envIds := d.Get("environment_ids").(*schema.Set).List(); !reflect.DeepEqual(envIds, []interface{}{2}) {
+		t.Errorf("Create stored the environments %v, expected [2]", envIds)
+	}
+}
+
+// Ensures an environment a newer version was promoted to is neither reported
+// as a change nor promoted again, and the content view is not removed from it
+func TestResourceForemanKatelloContentViewVersion_SupersededEnvironment(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{
+		TaskPollInterval: time.Millisecond,
+	})
+	defer server.Close()
+
+	// Version 12 was promoted to Test (2) and Prod (3), a newer version has
+	// replaced it in Test since
+	environments := `[{"id":1,"name":"Library","label":"Library"},{"id":3,"name":"Prod","label":"Prod"}]`
+	var promoteBody map[string]interface{}
+
+	mux.HandleFunc("/katello/api/content_view_versions/12", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":12,"name":"base 1.0","version":"1.0","major":1,"minor":0,`+
+			`"content_view":{"id":3,"name":"base"},"environments":%s}`, environments)
+	})
+	mux.HandleFunc("/katello/api/environments/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":4,"name":"DR","prior":{"id":3}}`)
+	})
+	mux.HandleFunc("/katello/api/content_view_versions/12/promote", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&promoteBody)
+		environments = `[{"id":1,"name":"Library","label":"Library"},{"id":3,"name":"Prod","label":"Prod"},` +
+			`{"id":4,"name":"DR","label":"DR"}]`
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"promote","label":"Actions::Katello::ContentView::Promote","pending":true}`)
+	})
+	mux.HandleFunc("/foreman_tasks/api/tasks/promote", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"promote","label":"Actions::Katello::ContentView::Promote",`+
+			`"pending":false,"state":"stopped","result":"success"}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request [%s %s]", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	current := resourceForemanKatelloContentViewVersion().TestResourceData()
+	current.SetId("12")
+	current.Set("content_view_id", 3)
+	current.Set("environment_ids", []interface{}{2, 3})
+	state := current.State()
+
+	// Reading the version keeps the superseded environment
+	d := resourceForemanKatelloContentViewVersion().Data(state)
+	if diags := resourceForemanKatelloContentViewVersionRead(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Read of the content view version returned [%+v]", diags)
+	}
+	envIds := d.Get("environment_ids").(*schema.Set)
+	if envIds.Len() != 2 || !envIds.Contains(2) || !envIds.Contains(3) {
+		t.Errorf("Read stored the environments %v, expected [2 3]", envIds.List())
+	}
+
+	// Replacing the superseded environment only promotes the added one
+	config := map[string]interface{}{
+		"content_view_id": 3,
+		"environment_ids": []interface{}{3, 4},
+	}
+	d = MockResourceDataDiff(t, resourceForemanKatelloContentViewVersion(), state, config)
+	if diags := resourceForemanKatelloContentViewVersionUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the content view version returned [%+v]", diags)
+	}
+	expectedPromote := map[string]interface{}{"environment_ids": []interface{}{4.0}, "force": false}
+	if !reflect.DeepEqual(promoteBody, expectedPromote) {
+		t.Errorf("Promote was requested with [%v], expected [%v]", promoteBody, expectedPromote)
+	}
+}
+
+// Ensures an imported version manages all environments it is promoted to
+func TestResourceForemanKatelloContentViewVersionImport(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc("/katello/api/content_view_versions/12", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":12,"name":"base 1.0","version":"1.0","content_view":{"id":3,"name":"base"},`+
+			`"environments":[{"id":1,"label":"Library"},{"id":2,"label":"Test"}]}`)
+	})
+
+	d := resourceForemanKatelloContentViewVersion().TestResourceData()
+	d.SetId("12")
+	imported, err := resourceForemanKatelloContentViewVersionImport(context.TODO(), d, client)
+	if err != nil {
+		t.Fatalf("Import of the content view version returned [%s]", err)
+	}
+	envIds := imported[0].Get("environment_ids").(*schema.Set).List()
+	if !reflect.DeepEqual(envIds, []interface{}{2}) {
+		t.Errorf("Import stored the environments %v, expected [2]", envIds)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
 	"slices"
 	"strconv"
+	"time"
 )
 
 func resourceForemanKatelloContentView() *schema.Resource {
@@ -21,10 +22,20 @@ func resourceForemanKatelloContentView() *schema.Resource {
 		UpdateContext: resourceForemanKatelloContentViewUpdate,
 		DeleteContext: resourceForemanKatelloContentViewDelete,
 
+		CustomizeDiff: resourceForemanKatelloContentViewCustomizeDiffFilterRules,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		// Publishing and removing content views are asynchronous Foreman tasks,
+		// the timeouts define how long to wait for them to finish.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			autodoc.MetaAttribute: {
 				Type:     schema.TypeBool,
@@ -159,6 +170,34 @@ func resourceForemanKatelloContentView() *schema.Resource {
 					"to be used as reference in CCVs",
 			},
 
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "History of the published versions of this content view. Use " +
+					"foreman_katello_content_view_version to publish and promote versions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"published": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+
 			"filter": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -191,6 +230,10 @@ func resourceForemanKatelloContentViewFilter() *schema.Resource {
 			"type": {
 				Type:     schema.TypeString,
 				Required: true,
+				// Katello reports erratum_id and erratum_date filters as erratum filters
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return oldValue == "erratum" && (newValue == "erratum_id" || newValue == "erratum_date")
+				},
 				ValidateFunc: validation.StringInSlice([]string{
 					"deb",
 					"rpm",
@@ -238,21 +281,219 @@ func resourceForemanKatelloContentViewFilterRule() *schema.Resource {
 				Computed: true,
 			},
 
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf("Package name pattern for rpm and deb filters, package group name "+
+					"for package_group filters or tag pattern for docker filters. %s apt*",
+					autodoc.MetaExample),
+			},
+
 			"architecture": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Package architecture for rpm and deb filters.",
+			},
+
+			"version": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Description: fmt.Sprintf("Exact package version for rpm and deb filters. Conflicts with "+
+					"min_version and max_version. %s \"1.2.3-1.el9\"", autodoc.MetaExample),
 			},
 
-			"name": {
+			"min_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Minimum package version for rpm and deb filters.",
+			},
+
+			"max_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Maximum package version for rpm and deb filters.",
+			},
+
+			"errata_id": {
 				Type:     schema.TypeString,
-				Required: true,
-				Description: fmt.Sprintf("Filter pattern of this filter %s apt*",
+				Optional: true,
+				Description: fmt.Sprintf("Erratum ID for erratum and erratum_id filters. %s \"RHSA-2024:1234\"",
+					autodoc.MetaExample),
+			},
+
+			"start_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf("Start date of the errata for erratum and erratum_date filters. %s \"2024-01-01\"",
+					autodoc.MetaExample),
+			},
+
+			"end_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf("End date of the errata for erratum and erratum_date filters. %s \"2024-06-30\"",
 					autodoc.MetaExample),
 			},
+
+			"date_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"issued", "updated"}, false),
+				Description: "Whether start_date and end_date refer to the `issued` or `updated` date of the " +
+					"errata. Katello defaults to `updated`.",
+			},
+
+			"types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"security", "enhancement", "bugfix"}, false),
+				},
+				Description: fmt.Sprintf("Errata types for erratum and erratum_date filters. %s [\"security\", \"bugfix\"]",
+					autodoc.MetaExample),
+			},
+
+			"allow_other_types": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Also match errata of types Katello does not know for erratum and " +
+					"erratum_date filters. Defaults to `false`.",
+			},
+
+			"uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "UUID of the package group for package_group filters.",
+			},
+
+			"module_stream_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the module stream for modulemd filters.",
+			},
 		},
 	}
 }
 
+// Rule attributes that may be used with each filter type
+var contentViewFilterRuleAttributes = map[string][]string{
+	"rpm":           {"name", "architecture", "version", "min_version", "max_version"},
+	"deb":           {"name", "architecture", "version", "min_version", "max_version"},
+	"package_group": {"name", "uuid"},
+	"erratum":       {"errata_id", "start_date", "end_date", "date_type", "types", "allow_other_types"},
+	"erratum_id":    {"errata_id"},
+	"erratum_date":  {"start_date", "end_date", "date_type", "types", "allow_other_types"},
+	"docker":        {"name"},
+	"modulemd":      {"module_stream_id"},
+}
+
+// validateForemanKatelloContentViewFilterRule checks that the rule only sets attributes
+// that belong to the type of its filter
+func validateForemanKatelloContentViewFilterRule(filterType string, rule map[string]interface{}) error {
+	isSet := func(key string) bool {
+		switch value := rule[key].(type) {
+		case string:
+			return value != ""
+		case int:
+			return value != 0
+		case bool:
+			return value
+		case []interface{}:
+			return len(value) > 0
+		}
+		return false
+	}
+
+	allowed := contentViewFilterRuleAttributes[filterType]
+	for key := range resourceForemanKatelloContentViewFilterRule().Schema {
+		// date_type is computed and read back for every rule
+		if key == "id" || key == "date_type" {
+			continue
+		}
+		if isSet(key) && !slices.Contains(allowed, key) {
+			return fmt.Errorf("rule attribute %q can not be used with filters of type %q", key, filterType)
+		}
+	}
+
+	switch filterType {
+	case "rpm", "deb":
+		if !isSet("name") {
+			return fmt.Errorf("rules of %q filters require a name", filterType)
+		}
+		if isSet("version") && (isSet("min_version") || isSet("max_version")) {
+			return fmt.Errorf("rule attribute \"version\" conflicts with \"min_version\" and \"max_version\"")
+		}
+	case "package_group", "docker":
+		if !isSet("name") {
+			return fmt.Errorf("rules of %q filters require a name", filterType)
+		}
+	case "erratum_id":
+		if !isSet("errata_id") {
+			return fmt.Errorf("rules of %q filters require an errata_id", filterType)
+		}
+	case "erratum":
+		isDateRule := isSet("start_date") || isSet("end_date") || isSet("types") || isSet("allow_other_types")
+		if isSet("errata_id") && isDateRule {
+			return fmt.Errorf("rule attribute \"errata_id\" conflicts with \"start_date\", \"end_date\", \"types\" and \"allow_other_types\"")
+		}
+		if !isSet("errata_id") && !isDateRule {
+			return fmt.Errorf("rules of %q filters require an errata_id or a start_date, end_date, types or allow_other_types", filterType)
+		}
+	case "modulemd":
+		if !isSet("module_stream_id") {
+			return fmt.Errorf("rules of %q filters require a module_stream_id", filterType)
+		}
+	}
+
+	return nil
+}
+
+// resourceForemanKatelloContentViewCustomizeDiffFilterRules validates the rules of all
+// filters against the filter type during plan
+func resourceForemanKatelloContentViewCustomizeDiffFilterRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	filters, ok := d.GetOk("filter")
+	if !ok {
+		return nil
+	}
+
+	for filterIdx, filter := range filters.([]interface{}) {
+		filter, ok := filter.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		filterType := filter["type"].(string)
+		rules, _ := filter["rule"].([]interface{})
+		for idx, rule := range rules {
+			rule, ok := rule.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			// Rules referring to values that are not known yet are validated on apply.
+			// The computed id and date_type of new rules are never known.
+			known := true
+			for key := range rule {
+				if key == "id" || key == "date_type" {
+					continue
+				}
+				known = known && d.NewValueKnown(fmt.Sprintf("filter.%d.rule.%d.%s", filterIdx, idx, key))
+			}
+			if !known {
+				continue
+			}
+
+			if err := validateForemanKatelloContentViewFilterRule(filterType, rule); err != nil {
+				return fmt.Errorf("filter %q, rule %d: %w", filter["name"], idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Converts a list of integers (= ids) from Terraform TypeList into a Go int slice
 func getIdsFromTerraformList(inputList interface{}) []int {
 	castedList, ok := inputList.([]interface{})
@@ -339,6 +580,21 @@ func buildForemanKatelloContentView(d *schema.ResourceData) *api.ContentView {
 					cvfr.Id = rulesResData["id"].(int)
 					cvfr.Name = rulesResData["name"].(string)
 					cvfr.Architecture = rulesResData["architecture"].(string)
+					cvfr.Version = rulesResData["version"].(string)
+					cvfr.MinVersion = rulesResData["min_version"].(string)
+					cvfr.MaxVersion = rulesResData["max_version"].(string)
+					cvfr.ErrataId = rulesResData["errata_id"].(string)
+					cvfr.StartDate = rulesResData["start_date"].(string)
+					cvfr.EndDate = rulesResData["end_date"].(string)
+					cvfr.DateType = rulesResData["date_type"].(string)
+					cvfr.AllowOtherTypes = rulesResData["allow_other_types"].(bool)
+					cvfr.Uuid = rulesResData["uuid"].(string)
+					cvfr.ModuleStreamId = rulesResData["module_stream_id"].(int)
+					cvfr.FilterType = cvf.Type
+
+					for _, errataType := range rulesResData["types"].([]interface{}) {
+						cvfr.Types = append(cvfr.Types, errataType.(string))
+					}
 
 					cvfrs = append(cvfrs, cvfr)
 				}
@@ -386,9 +642,20 @@ func setResourceDataFromForemanKatelloContentView(d *schema.ResourceData, cv *ap
 		ruleSet := make([]interface{}, len(item.Rules))
 		for idx2, item2 := range item.Rules {
 			newRule := map[string]interface{}{
-				"id":           item2.Id,
-				"name":         item2.Name,
-				"architecture": item2.Architecture,
+				"id":                item2.Id,
+				"name":              item2.Name,
+				"architecture":      item2.Architecture,
+				"version":           item2.Version,
+				"min_version":       item2.MinVersion,
+				"max_version":       item2.MaxVersion,
+				"errata_id":         item2.ErrataId,
+				"start_date":        item2.StartDate,
+				"end_date":          item2.EndDate,
+				"date_type":         item2.DateType,
+				"types":             item2.Types,
+				"allow_other_types": item2.AllowOtherTypes,
+				"uuid":              item2.Uuid,
+				"module_stream_id":  item2.ModuleStreamId,
 			}
 			ruleSet[idx2] = newRule
 		}
@@ -406,6 +673,17 @@ func setResourceDataFromForemanKatelloContentView(d *schema.ResourceData, cv *ap
 		panic(err)
 	}
 
+	versions := make([]interface{}, len(cv.Versions))
+	for idx, version := range cv.Versions {
+		versions[idx] = map[string]interface{}{
+			"id":              version.Id,
+			"version":         version.Version,
+			"published":       version.Published,
+			"environment_ids": version.EnvironmentIds,
+		}
+	}
+	d.Set("versions", versions)
+
 	// Latest published version
 	latest_published_version := 0
 
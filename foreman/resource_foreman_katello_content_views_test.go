@@ -0,0 +1,170 @@
+package foreman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+// contentViewFilterRuleData returns the rule the way Terraform passes it to the
+// provider, with the zero value for every attribute that is not set
+func contentViewFilterRuleData(attrs map[string]interface{}) map[string]interface{} {
+	rule := map[string]interface{}{}
+	for key, s := range resourceForemanKatelloContentViewFilterRule().Schema {
+		rule[key] = s.ZeroValue()
+	}
+	for key, value := range attrs {
+		rule[key] = value
+	}
+	return rule
+}
+
+// -----------------------------------------------------------------------------
+// Filter Rule Validation
+// -----------------------------------------------------------------------------
+
+// Ensures rules are only accepted with the attributes that belong to the type
+// of their filter
+func TestValidateForemanKatelloContentViewFilterRule(t *testing.T) {
+	cases := []struct {
+		filterType string
+		rule       map[string]interface{}
+		valid      bool
+	}{
+		// rpm and deb
+		{"rpm", map[string]interface{}{"name": "bash"}, true},
+		{"rpm", map[string]interface{}{"name": "bash", "architecture": "x86_64", "version": "5.1"}, true},
+		{"rpm", map[string]interface{}{"name": "bash", "min_version": "5.0", "max_version": "5.2"}, true},
+		{"rpm", map[string]interface{}{"architecture": "x86_64"}, false},
+		{"rpm", map[string]interface{}{"name": "bash", "version": "5.1", "min_version": "5.0"}, false},
+		{"rpm", map[string]interface{}{"name": "bash", "version": "5.1", "max_version": "5.2"}, false},
+		{"rpm", map[string]interface{}{"name": "bash", "errata_id": "RHSA-2024:0001"}, false},
+		{"deb", map[string]interface{}{"name": "bash", "version": "5.1"}, true},
+		{"deb", map[string]interface{}{"name": "bash", "uuid": "b4c5"}, false},
+		// package_group
+		{"package_group", map[string]interface{}{"name": "Development Tools", "uuid": "b4c5"}, true},
+		{"package_group", map[string]interface{}{"uuid": "b4c5"}, false},
+		{"package_group", map[string]interface{}{"name": "Development Tools", "version": "1"}, false},
+		// erratum, erratum_id and erratum_date
+		{"erratum", map[string]interface{}{"errata_id": "RHSA-2024:0001"}, true},
+		{"erratum", map[string]interface{}{"start_date": "2024-01-01", "types": []interface{}{"security"}}, true},
+		{"erratum", map[string]interface{}{"errata_id": "RHSA-2024:0001", "start_date": "2024-01-01"}, false},
+		{"erratum", map[string]interface{}{"allow_other_types": true}, true},
+		{"erratum", map[string]interface{}{"errata_id": "RHSA-2024:0001", "allow_other_types": true}, false},
+		{"erratum", map[string]interface{}{}, false},
+		{"erratum_id", map[string]interface{}{"errata_id": "RHSA-2024:0001"}, true},
+		{"erratum_id", map[string]interface{}{"errata_id": "RHSA-2024:0001", "end_date": "2024-12-31"}, false},
+		{"erratum_id", map[string]interface{}{}, false},
+		{"erratum_date", map[string]interface{}{"allow_other_types": true}, true},
+		{"erratum_date", map[string]interface{}{"end_date": "2024-12-31", "allow_other_types": true}, true},
+		{"erratum_date", map[string]interface{}{"types": []interface{}{"bugfix", "enhancement"}}, true},
+		{"erratum_date", map[string]interface{}{"errata_id": "RHSA-2024:0001"}, false},
+		// docker
+		{"docker", map[string]interface{}{"name": "latest"}, true},
+		{"docker", map[string]interface{}{}, false},
+		{"docker", map[string]interface{}{"name": "latest", "architecture": "x86_64"}, false},
+		// modulemd
+		{"modulemd", map[string]interface{}{"module_stream_id": 12}, true},
+		{"modulemd", map[string]interface{}{}, false},
+		{"modulemd", map[string]interface{}{"module_stream_id": 12, "name": "nodejs"}, false},
+		// date_type is read back for every rule
+		{"rpm", map[string]interface{}{"name": "bash", "date_type": "updated"}, true},
+	}
+
+	for _, c := range cases {
+		err := validateForemanKatelloContentViewFilterRule(c.filterType, contentViewFilterRuleData(c.rule))
+		if (err == nil) != c.valid {
+			t.Errorf(
+				"validateForemanKatelloContentViewFilterRule returned [%v] for a %s rule [%v], expected valid [%t]",
+				err,
+				c.filterType,
+				c.rule,
+				c.valid,
+			)
+		}
+	}
+}
+
+// Ensures the rules of all filters are validated when planning a content view
+func TestResourceForemanKatelloContentViewCustomizeDiffFilterRules(t *testing.T) {
+	cases := []struct {
+		filters []interface{}
+		valid   bool
+	}{
+		{nil, true},
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"name": "packages",
+					"type": "rpm",
+					"rule": []interface{}{
+						map[string]interface{}{"name": "bash", "version": "5.1"},
+					},
+				},
+				map[string]interface{}{
+					"name": "security",
+					"type": "erratum_date",
+					"rule": []interface{}{
+						map[string]interface{}{"types": []interface{}{"security"}},
+					},
+				},
+			},
+			true,
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"name": "packages",
+					"type": "rpm",
+					"rule": []interface{}{
+						map[string]interface{}{"name": "bash"},
+					},
+				},
+				map[string]interface{}{
+					"name": "streams",
+					"type": "modulemd",
+					"rule": []interface{}{
+						map[string]interface{}{"module_stream_id": 12},
+						map[string]interface{}{"name": "nodejs"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"name": "errata",
+					"type": "erratum_id",
+					"rule": []interface{}{
+						map[string]interface{}{"errata_id": "RHSA-2024:0001", "start_date": "2024-01-01"},
+					},
+				},
+			},
+			false,
+		},
+	}
+
+	r := resourceForemanKatelloContentView()
+	for _, c := range cases {
+		config := map[string]interface{}{"name": "base"}
+		if c.filters != nil {
+			config["filter"] = c.filters
+		}
+
+		_, err := r.Diff(context.TODO(), nil, terraform.NewResourceConfigRaw(config), nil)
+		if (err == nil) != c.valid {
+			t.Errorf(
+				"Diff returned [%v] for the filters [%v], expected valid [%t]",
+				err,
+				c.filters,
+				c.valid,
+			)
+		}
+	}
+}
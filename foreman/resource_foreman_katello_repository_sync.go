@@ -0,0 +1,186 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanKatelloRepositorySync() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanKatelloRepositorySyncCreate,
+		ReadContext:   resourceForemanKatelloRepositorySyncRead,
+		DeleteContext: resourceForemanKatelloRepositorySyncDelete,
+
+		// Synchronizing a repository is an asynchronous Foreman task, the
+		// timeout defines how long to wait for it to finish.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Synchronizes a repository with its upstream URL and waits for the sync "+
+						"to finish, so that e.g. a content view version can depend on the synced "+
+						"content. Changing `triggers` synchronizes the repository again.",
+					autodoc.MetaSummary,
+				),
+			},
+			"repository_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: fmt.Sprintf(
+					"ID of the repository to synchronize. "+
+						"%s foreman_katello_repository.example.id",
+					autodoc.MetaExample,
+				),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, synchronize the " +
+					"repository again.",
+			},
+			"skip_metadata_check": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+				Description: "Force the sync even if the upstream metadata did not change. " +
+					"Defaults to `false`.",
+			},
+			"validate_contents": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+				Description: "Validate the contents of the repository and repair missing or " +
+					"corrupt content. Defaults to `false`.",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the sync task started by this resource.",
+			},
+			"last_sync_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the last sync of the repository, e.g. `stopped`.",
+			},
+			"last_sync_result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Result of the last sync of the repository, e.g. `success`.",
+			},
+			"last_sync_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp of when the last sync of the repository ended.",
+			},
+			"content_counts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Description: "Number of content units in the repository by content type, " +
+					"e.g. `rpm` or `erratum`.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// setResourceDataFromForemanKatelloRepositorySync sets the sync status attributes of
+// a ResourceData from the supplied ForemanKatelloRepository reference
+func setResourceDataFromForemanKatelloRepositorySync(d *schema.ResourceData, repo *api.ForemanKatelloRepository) {
+	d.Set("repository_id", repo.Id)
+	d.Set("content_counts", repo.ContentCounts)
+
+	if repo.LastSync != nil {
+		d.Set("last_sync_state", repo.LastSync.State)
+		d.Set("last_sync_result", repo.LastSync.Result)
+		d.Set("last_sync_at", repo.LastSync.EndedAt)
+	} else {
+		d.Set("last_sync_state", "")
+		d.Set("last_sync_result", "")
+		d.Set("last_sync_at", "")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanKatelloRepositorySyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_repository_sync.go#Create")
+
+	client := meta.(*api.Client)
+	repoId := d.Get("repository_id").(int)
+	opts := api.ForemanKatelloRepositorySyncOptions{
+		SkipMetadataCheck: d.Get("skip_metadata_check").(bool),
+		ValidateContents:  d.Get("validate_contents").(bool),
+	}
+
+	log.Debugf("repository_id: [%d], opts: [%+v]", repoId, opts)
+
+	syncTask, syncErr := client.SyncKatelloRepository(ctx, repoId, &opts)
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
+	log.Debugf("syncTask: [%+v]", syncTask)
+
+	// A sync resource exists once per sync, the ID is unique for every sync
+	d.SetId(strconv.Itoa(repoId) + "/" + syncTask.Id)
+	d.Set("task_id", syncTask.Id)
+
+	return resourceForemanKatelloRepositorySyncRead(ctx, d, meta)
+}
+
+func resourceForemanKatelloRepositorySyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_repository_sync.go#Read")
+
+	client := meta.(*api.Client)
+	repoId := d.Get("repository_id").(int)
+
+	readRepository, readErr := client.ReadKatelloRepository(ctx, repoId)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanKatelloRepository: [%+v]", readRepository)
+
+	setResourceDataFromForemanKatelloRepositorySync(d, readRepository)
+
+	return nil
+}
+
+func resourceForemanKatelloRepositorySyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_katello_repository_sync.go#Delete")
+
+	// A sync can not be undone. Removing the resource only removes it from
+	// the state.
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package foreman
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanLocation() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanLocationCreate,
+		ReadContext:   resourceForemanLocationRead,
+		UpdateContext: resourceForemanLocationUpdate,
+		DeleteContext: resourceForemanLocationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: foremanTaxonomySchema(
+			"location",
+			"Locations group resources by their physical or logical location, e.g. a "+
+				"datacenter. Locations can be nested.",
+			"Berlin",
+		),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanLocation constructs a ForemanLocation reference from a
+// resource data reference.
+func buildForemanLocation(d *schema.ResourceData) *api.ForemanLocation {
+	log.Tracef("resource_foreman_location.go#buildForemanLocation")
+
+	return &api.ForemanLocation{ForemanTaxonomy: buildForemanTaxonomy(d)}
+}
+
+// setResourceDataFromForemanLocation sets a ResourceData's attributes from
+// the attributes of the supplied ForemanLocation reference
+func setResourceDataFromForemanLocation(d *schema.ResourceData, o *api.ForemanLocation) {
+	log.Tracef("resource_foreman_location.go#setResourceDataFromForemanLocation")
+
+	setResourceDataFromForemanTaxonomy(d, &o.ForemanTaxonomy)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Create")
+
+	client := meta.(*api.Client)
+	o := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", o)
+
+	createdLocation, createErr := client.CreateLocation(ctx, o)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanLocation: [%+v]", createdLocation)
+
+	// The create response does not contain the parameters
+	d.SetId(strconv.Itoa(createdLocation.Id))
+
+	return resourceForemanLocationRead(ctx, d, meta)
+}
+
+func resourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", o)
+
+	readLocation, readErr := client.ReadLocation(ctx, o.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanLocation: [%+v]", readLocation)
+
+	setResourceDataFromForemanLocation(d, readLocation)
+
+	return nil
+}
+
+func resourceForemanLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Update")
+
+	client := meta.(*api.Client)
+	o := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", o)
+
+	updatedLocation, updateErr := client.UpdateLocation(ctx, o)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanLocation: [%+v]", updatedLocation)
+
+	return resourceForemanLocationRead(ctx, d, meta)
+}
+
+func resourceForemanLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Delete")
+
+	client := meta.(*api.Client)
+	o := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", o)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteLocation(ctx, o.Id)))
+}
@@ -0,0 +1,347 @@
+package foreman
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const LocationsURI = api.FOREMAN_API_URL_PREFIX + "/locations"
+const LocationsTestDataPath = "testdata/3.11/locations"
+
+// Given a ForemanLocation, create a mock instance state reference
+func ForemanLocationToInstanceState(obj api.ForemanLocation) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanLocation
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["title"] = obj.Title
+	attr["description"] = obj.Description
+	attr["parent_id"] = strconv.Itoa(obj.ParentId)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanLocation resource, create a
+// mock ResourceData reference.
+func MockForemanLocationResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanLocation()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a location
+// ResourceData reference
+func MockForemanLocationResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanLocation
+	ParseJSONFile(t, path, &obj)
+	s := ForemanLocationToInstanceState(obj)
+	return MockForemanLocationResourceData(s)
+}
+
+// Creates a random ForemanLocation struct
+func RandForemanLocation() api.ForemanLocation {
+	obj := api.ForemanLocation{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Title = tfrand.String(10, tfrand.Lower) + "/" + obj.Name
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+	obj.ParentId = rand.Intn(100) + 1
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanLocation resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanLocationResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanLocation()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestLocationUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanLocation
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the parameters and ignored types of a read response are decoded
+func TestLocationUnmarshalJSON_ReadResponse(t *testing.T) {
+
+	var obj api.ForemanLocation
+	ParseJSONFile(t, LocationsTestDataPath+"/read_response.json", &obj)
+
+	if obj.ParentId != 1 || obj.Title != "Europe/Berlin" {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON did not decode the hierarchy. "+
+				"Got parent_id [%d], title [%s]",
+			obj.ParentId,
+			obj.Title,
+		)
+	}
+
+	expectedTypes := []string{"Subnet"}
+	if !reflect.DeepEqual(obj.IgnoreTypes, expectedTypes) {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON did not decode ignore_types. "+
+				"Expected [%v], got [%v]",
+			expectedTypes,
+			obj.IgnoreTypes,
+		)
+	}
+
+	expectedParams := map[string]string{"ntp_server": "ntp.ber.example.com"}
+	if !reflect.DeepEqual(api.FromKV(obj.Parameters), expectedParams) {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON did not decode parameters. "+
+				"Expected [%v], got [%v]",
+			expectedParams,
+			api.FromKV(obj.Parameters),
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanLocation
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanLocation_Value(t *testing.T) {
+
+	expectedObj := RandForemanLocation()
+	expectedState := ForemanLocationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanLocationResourceData(expectedState)
+
+	actualObj := api.ForemanLocation{}
+	actualState := ForemanLocationToInstanceState(actualObj)
+	actualResourceData := MockForemanLocationResourceData(actualState)
+
+	setResourceDataFromForemanLocation(actualResourceData, &expectedObj)
+
+	ForemanLocationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanLocationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+	locationsURIById := LocationsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationRead",
+				crudFunc:     resourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    locationsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationDelete",
+				crudFunc:     resourceForemanLocationDelete,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    locationsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanLocationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationDelete",
+			crudFunc:     resourceForemanLocationDelete,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanLocationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationCreate",
+			crudFunc:     resourceForemanLocationCreate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationUpdate",
+			crudFunc:     resourceForemanLocationUpdate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationDelete",
+			crudFunc:     resourceForemanLocationDelete,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanLocationEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationCreate",
+			crudFunc:     resourceForemanLocationCreate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationUpdate",
+			crudFunc:     resourceForemanLocationUpdate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanLocationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationCreate",
+				crudFunc:     resourceForemanLocationCreate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanLocationResourceDataFromFile(
+				t,
+				LocationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanLocationResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationRead",
+				crudFunc:     resourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanLocationResourceDataFromFile(
+				t,
+				LocationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanLocationResourceDataCompare,
+		},
+	}
+
+}
@@ -100,6 +100,9 @@ func resourceForemanMedia() *schema.Resource {
 				},
 				Description: "IDs of the operating systems associated with this media.",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("medium"),
+			"organization_ids": foremanOrganizationIdsSchema("medium"),
 		},
 	}
 }
@@ -134,6 +137,8 @@ func buildForemanMedia(d *schema.ResourceData) *api.ForemanMedia {
 		media.OperatingSystemIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
 
+	media.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &media
 }
 
@@ -147,6 +152,7 @@ func setResourceDataFromForemanMedia(d *schema.ResourceData, fm *api.ForemanMedi
 	d.Set("path", fm.Path)
 	d.Set("os_family", fm.OSFamily)
 	d.Set("operatingsystem_ids", fm.OperatingSystemIds)
+	setResourceDataFromForemanTaxonomyIds(d, fm.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
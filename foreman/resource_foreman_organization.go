@@ -0,0 +1,242 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanOrganization() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanOrganizationCreate,
+		ReadContext:   resourceForemanOrganizationRead,
+		UpdateContext: resourceForemanOrganizationUpdate,
+		DeleteContext: resourceForemanOrganizationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: foremanTaxonomySchema(
+			"organization",
+			"Organizations group resources by the tenant, department or team they belong "+
+				"to. Organizations can be nested.",
+			"ACME",
+		),
+	}
+}
+
+// foremanTaxonomySchema returns the schema shared by organizations and
+// locations
+func foremanTaxonomySchema(taxonomyType string, summary string, example string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: {
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s %s",
+				autodoc.MetaSummary,
+				summary,
+			),
+		},
+
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			Description: fmt.Sprintf(
+				"Name of the %s. "+
+					"%s \"%s\"",
+				taxonomyType,
+				autodoc.MetaExample,
+				example,
+			),
+		},
+
+		"title": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"Title of the %s, consisting of the names of its parents and its own "+
+					"name separated by slashes.",
+				taxonomyType,
+			),
+		},
+
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Description of the %s.", taxonomyType),
+		},
+
+		"parent_id": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"ID of the parent %s. Top-level if not set.",
+				taxonomyType,
+			),
+		},
+
+		"ignore_types": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf(
+				"Resource types that are not restricted by the %s, i.e. all resources of "+
+					"these types are part of it. %s [\"Domain\", \"Medium\"]",
+				taxonomyType,
+				autodoc.MetaExample,
+			),
+		},
+
+		"parameters": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: fmt.Sprintf(
+				"A map of parameters that will be saved as %s parameters.",
+				taxonomyType,
+			),
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanTaxonomy constructs a ForemanTaxonomy from a resource data
+// reference of an organization or location
+func buildForemanTaxonomy(d *schema.ResourceData) api.ForemanTaxonomy {
+	t := api.ForemanTaxonomy{}
+	t.ForemanObject = *buildForemanObject(d)
+
+	t.Title = d.Get("title").(string)
+	t.Description = d.Get("description").(string)
+	t.ParentId = d.Get("parent_id").(int)
+
+	if attr, ok := d.GetOk("ignore_types"); ok {
+		for _, item := range attr.(*schema.Set).List() {
+			t.IgnoreTypes = append(t.IgnoreTypes, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk("parameters"); ok {
+		t.Parameters = api.ToKV(attr.(map[string]interface{}))
+	}
+
+	return t
+}
+
+// setResourceDataFromForemanTaxonomy sets a ResourceData's attributes from the
+// attributes of the supplied ForemanTaxonomy reference
+func setResourceDataFromForemanTaxonomy(d *schema.ResourceData, t *api.ForemanTaxonomy) {
+	d.SetId(strconv.Itoa(t.Id))
+	d.Set("name", t.Name)
+	d.Set("title", t.Title)
+	d.Set("description", t.Description)
+	d.Set("parent_id", t.ParentId)
+	d.Set("ignore_types", t.IgnoreTypes)
+	d.Set("parameters", api.FromKV(t.Parameters))
+}
+
+// buildForemanOrganization constructs a ForemanOrganization reference from a
+// resource data reference.
+func buildForemanOrganization(d *schema.ResourceData) *api.ForemanOrganization {
+	log.Tracef("resource_foreman_organization.go#buildForemanOrganization")
+
+	return &api.ForemanOrganization{ForemanTaxonomy: buildForemanTaxonomy(d)}
+}
+
+// setResourceDataFromForemanOrganization sets a ResourceData's attributes from
+// the attributes of the supplied ForemanOrganization reference
+func setResourceDataFromForemanOrganization(d *schema.ResourceData, o *api.ForemanOrganization) {
+	log.Tracef("resource_foreman_organization.go#setResourceDataFromForemanOrganization")
+
+	setResourceDataFromForemanTaxonomy(d, &o.ForemanTaxonomy)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanOrganizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Create")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	createdOrganization, createErr := client.CreateOrganization(ctx, o)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanOrganization: [%+v]", createdOrganization)
+
+	// The create response does not contain the parameters
+	d.SetId(strconv.Itoa(createdOrganization.Id))
+
+	return resourceForemanOrganizationRead(ctx, d, meta)
+}
+
+func resourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	readOrganization, readErr := client.ReadOrganization(ctx, o.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Update")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	updatedOrganization, updateErr := client.UpdateOrganization(ctx, o)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanOrganization: [%+v]", updatedOrganization)
+
+	return resourceForemanOrganizationRead(ctx, d, meta)
+}
+
+func resourceForemanOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Delete")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteOrganization(ctx, o.Id)))
+}
@@ -0,0 +1,347 @@
+package foreman
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const OrganizationsURI = api.FOREMAN_API_URL_PREFIX + "/organizations"
+const OrganizationsTestDataPath = "testdata/3.11/organizations"
+
+// Given a ForemanOrganization, create a mock instance state reference
+func ForemanOrganizationToInstanceState(obj api.ForemanOrganization) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanOrganization
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["title"] = obj.Title
+	attr["description"] = obj.Description
+	attr["parent_id"] = strconv.Itoa(obj.ParentId)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanOrganization resource, create a
+// mock ResourceData reference.
+func MockForemanOrganizationResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanOrganization()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an organization
+// ResourceData reference
+func MockForemanOrganizationResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanOrganization
+	ParseJSONFile(t, path, &obj)
+	s := ForemanOrganizationToInstanceState(obj)
+	return MockForemanOrganizationResourceData(s)
+}
+
+// Creates a random ForemanOrganization struct
+func RandForemanOrganization() api.ForemanOrganization {
+	obj := api.ForemanOrganization{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Title = tfrand.String(10, tfrand.Lower) + "/" + obj.Name
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+	obj.ParentId = rand.Intn(100) + 1
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanOrganization resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanOrganizationResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanOrganization()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestOrganizationUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanOrganization
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the parameters and ignored types of a read response are decoded
+func TestOrganizationUnmarshalJSON_ReadResponse(t *testing.T) {
+
+	var obj api.ForemanOrganization
+	ParseJSONFile(t, OrganizationsTestDataPath+"/read_response.json", &obj)
+
+	if obj.ParentId != 1 || obj.Title != "ACME/Engineering" {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not decode the hierarchy. "+
+				"Got parent_id [%d], title [%s]",
+			obj.ParentId,
+			obj.Title,
+		)
+	}
+
+	expectedTypes := []string{"Domain", "Medium"}
+	if !reflect.DeepEqual(obj.IgnoreTypes, expectedTypes) {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not decode ignore_types. "+
+				"Expected [%v], got [%v]",
+			expectedTypes,
+			obj.IgnoreTypes,
+		)
+	}
+
+	expectedParams := map[string]string{"cost_center": "4711"}
+	if !reflect.DeepEqual(api.FromKV(obj.Parameters), expectedParams) {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not decode parameters. "+
+				"Expected [%v], got [%v]",
+			expectedParams,
+			api.FromKV(obj.Parameters),
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanOrganization
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanOrganization_Value(t *testing.T) {
+
+	expectedObj := RandForemanOrganization()
+	expectedState := ForemanOrganizationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanOrganizationResourceData(expectedState)
+
+	actualObj := api.ForemanOrganization{}
+	actualState := ForemanOrganizationToInstanceState(actualObj)
+	actualResourceData := MockForemanOrganizationResourceData(actualState)
+
+	setResourceDataFromForemanOrganization(actualResourceData, &expectedObj)
+
+	ForemanOrganizationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanOrganizationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+	organizationsURIById := OrganizationsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    organizationsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationDelete",
+				crudFunc:     resourceForemanOrganizationDelete,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    organizationsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanOrganizationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanOrganizationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanOrganizationEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanOrganizationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+	}
+
+}
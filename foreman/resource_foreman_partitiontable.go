@@ -146,6 +146,9 @@ func resourceForemanPartitionTable() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the partition table",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("partition table"),
+			"organization_ids": foremanOrganizationIdsSchema("partition table"),
 		},
 	}
 }
@@ -206,6 +209,8 @@ func buildForemanPartitionTable(d *schema.ResourceData) *api.ForemanPartitionTab
 		table.Description = attr.(string)
 	}
 
+	table.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &table
 }
 
@@ -255,6 +260,7 @@ func setResourceDataFromForemanPartitionTable(d *schema.ResourceData, ft *api.Fo
 	if attr, ok = d.GetOk("description"); ok {
 		d.Set("description", attr.(string))
 	}
+	setResourceDataFromForemanTaxonomyIds(d, ft.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,195 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanPersonalAccessToken() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanPersonalAccessTokenCreate,
+		ReadContext:   resourceForemanPersonalAccessTokenRead,
+		DeleteContext: resourceForemanPersonalAccessTokenDelete,
+
+		// NOTE(ALL): the token value is only returned on creation, importing
+		//   a token would leave it empty
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Personal access token of a user. The token authenticates "+
+						"against the API instead of the user's password. The token is "+
+						"revoked when the resource is destroyed. Revoked and expired "+
+						"tokens are removed from the state and created again.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"user_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the user the token belongs to.",
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the token. "+
+						"%s \"terraform\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description: fmt.Sprintf(
+					"Expiry of the token in RFC 3339 format. The token does not expire if "+
+						"unset. %s \"2030-01-01T00:00:00Z\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret token. Foreman only returns it when the token is created.",
+			},
+
+			"last_used_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp the token was last used at.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanPersonalAccessToken constructs a ForemanPersonalAccessToken
+// reference from a resource data reference.  The struct's members are
+// populated from the data populated in the resource data.  Missing members
+// will be left to the zero value for that member's type.
+func buildForemanPersonalAccessToken(d *schema.ResourceData) *api.ForemanPersonalAccessToken {
+	log.Tracef("resource_foreman_personal_access_token.go#buildForemanPersonalAccessToken")
+
+	token := api.ForemanPersonalAccessToken{}
+
+	obj := buildForemanObject(d)
+	token.ForemanObject = *obj
+
+	token.UserId = d.Get("user_id").(int)
+	token.ExpiresAt = d.Get("expires_at").(string)
+
+	return &token
+}
+
+// setResourceDataFromForemanPersonalAccessToken sets a ResourceData's
+// attributes from the attributes of the supplied ForemanPersonalAccessToken
+// reference
+func setResourceDataFromForemanPersonalAccessToken(d *schema.ResourceData, ft *api.ForemanPersonalAccessToken) {
+	log.Tracef("resource_foreman_personal_access_token.go#setResourceDataFromForemanPersonalAccessToken")
+
+	// NOTE(ALL): the expiry is kept as configured since Foreman returns it in
+	//   a different format
+	d.SetId(strconv.Itoa(ft.Id))
+	d.Set("name", ft.Name)
+	d.Set("last_used_at", ft.LastUsedAt)
+
+	if ft.UserId != 0 {
+		d.Set("user_id", ft.UserId)
+	}
+
+	// only the create response contains the token value
+	if ft.TokenValue != "" {
+		d.Set("token", ft.TokenValue)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanPersonalAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Create")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	log.Debugf("ForemanPersonalAccessToken: [%+v]", t)
+
+	createdToken, createErr := client.CreatePersonalAccessToken(ctx, t.UserId, t)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	setResourceDataFromForemanPersonalAccessToken(d, createdToken)
+
+	return nil
+}
+
+func resourceForemanPersonalAccessTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Read")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	log.Debugf("ForemanPersonalAccessToken: [%+v]", t)
+
+	readToken, readErr := client.ReadPersonalAccessToken(ctx, t.UserId, t.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanPersonalAccessToken: [%+v]", readToken)
+
+	// a revoked or expired token can no longer be used, remove it from the
+	// state so that a new one is created
+	if readToken.Revoked || readToken.Expired() {
+		log.Warningf(
+			"Personal access token [%d] of user [%d] is revoked or expired, removing it from the state",
+			readToken.Id,
+			t.UserId,
+		)
+		d.SetId("")
+		return nil
+	}
+
+	setResourceDataFromForemanPersonalAccessToken(d, readToken)
+
+	return nil
+}
+
+func resourceForemanPersonalAccessTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Delete")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	log.Debugf("ForemanPersonalAccessToken: [%+v]", t)
+
+	return diag.FromErr(api.CheckDeleted(d, client.RevokePersonalAccessToken(ctx, t.UserId, t.Id)))
+}
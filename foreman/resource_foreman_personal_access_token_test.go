@@ -0,0 +1,369 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const PersonalAccessTokensTestDataPath = "testdata/3.11/personal_access_tokens"
+
+// Given a ForemanPersonalAccessToken, create a mock instance state reference
+func ForemanPersonalAccessTokenToInstanceState(obj api.ForemanPersonalAccessToken) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanPersonalAccessToken
+	attr := map[string]string{}
+	attr["user_id"] = strconv.Itoa(obj.UserId)
+	attr["name"] = obj.Name
+	attr["expires_at"] = obj.ExpiresAt
+	attr["last_used_at"] = obj.LastUsedAt
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanPersonalAccessToken resource, create a
+// mock ResourceData reference.
+func MockForemanPersonalAccessTokenResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanPersonalAccessToken()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a personal access token
+// ResourceData reference
+func MockForemanPersonalAccessTokenResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanPersonalAccessToken
+	ParseJSONFile(t, path, &obj)
+	// the expiry is kept as configured and not read from the response
+	obj.ExpiresAt = ""
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+	return MockForemanPersonalAccessTokenResourceData(s)
+}
+
+// Creates a random ForemanPersonalAccessToken struct
+func RandForemanPersonalAccessToken() api.ForemanPersonalAccessToken {
+	obj := api.ForemanPersonalAccessToken{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.UserId = rand.Intn(100) + 1
+	obj.LastUsedAt = tfrand.String(20, tfrand.Digit)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanPersonalAccessToken resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanPersonalAccessTokenResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanPersonalAccessToken()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanPersonalAccessTokenRead
+// -----------------------------------------------------------------------------
+
+// Ensures revoked and expired tokens are removed from the state
+func TestResourceForemanPersonalAccessTokenRead_RevokedOrExpired(t *testing.T) {
+
+	responses := []string{
+		`{"id":3,"name":"terraform","user_id":5,"revoked?":true}`,
+		`{"id":3,"name":"terraform","user_id":5,"expires_at":"2020-01-01 00:00:00 UTC"}`,
+	}
+
+	for _, response := range responses {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		mux.HandleFunc(UsersURI+"/5/personal_access_tokens/3", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, response)
+		})
+
+		obj := api.ForemanPersonalAccessToken{UserId: 5}
+		obj.Id = 3
+		rd := MockForemanPersonalAccessTokenResourceData(ForemanPersonalAccessTokenToInstanceState(obj))
+
+		diags := resourceForemanPersonalAccessTokenRead(context.Background(), rd, client)
+		server.Close()
+
+		if diags.HasError() {
+			t.Fatalf("resourceForemanPersonalAccessTokenRead returned an error: [%+v]", diags)
+		}
+		if rd.Id() != "" {
+			t.Errorf("resourceForemanPersonalAccessTokenRead kept the token [%s] in the state", response)
+		}
+	}
+
+}
+
+// Ensures a token which is neither revoked nor expired is kept in the state
+func TestResourceForemanPersonalAccessTokenRead_Valid(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(UsersURI+"/5/personal_access_tokens/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"terraform","user_id":5,"revoked?":false,`+
+			`"expires_at":"2099-01-01 00:00:00 UTC","last_used_at":"2024-05-01 10:00:00 UTC"}`)
+	})
+
+	obj := api.ForemanPersonalAccessToken{UserId: 5}
+	obj.Id = 3
+	rd := MockForemanPersonalAccessTokenResourceData(ForemanPersonalAccessTokenToInstanceState(obj))
+
+	if diags := resourceForemanPersonalAccessTokenRead(context.Background(), rd, client); diags.HasError() {
+		t.Fatalf("resourceForemanPersonalAccessTokenRead returned an error: [%+v]", diags)
+	}
+	if rd.Id() != "3" || rd.Get("last_used_at").(string) != "2024-05-01 10:00:00 UTC" {
+		t.Errorf(
+			"resourceForemanPersonalAccessTokenRead stored the token [%s] last used at [%s]",
+			rd.Id(),
+			rd.Get("last_used_at"),
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanPersonalAccessTokenCreate
+// -----------------------------------------------------------------------------
+
+// Ensures a created token is stored with its secret value, which is only
+// part of the create response
+func TestResourceForemanPersonalAccessTokenCreate(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var body map[string]map[string]interface{}
+	mux.HandleFunc(UsersURI+"/5/personal_access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("The token was created with method [%s], expected [POST]", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{"id":3,"name":"terraform","user_id":5,"expires_at":"2099-01-01 00:00:00 UTC",`+
+			`"token_value":"s3cr3t"}`)
+	})
+
+	rd := resourceForemanPersonalAccessToken().TestResourceData()
+	rd.Set("user_id", 5)
+	rd.Set("name", "terraform")
+	rd.Set("expires_at", "2099-01-01T00:00:00Z")
+
+	if diags := resourceForemanPersonalAccessTokenCreate(context.Background(), rd, client); diags.HasError() {
+		t.Fatalf("resourceForemanPersonalAccessTokenCreate returned an error: [%+v]", diags)
+	}
+
+	expected := map[string]interface{}{"name": "terraform", "expires_at": "2099-01-01T00:00:00Z"}
+	if !reflect.DeepEqual(body["personal_access_token"], expected) {
+		t.Errorf("resourceForemanPersonalAccessTokenCreate sent [%v], expected [%v]", body, expected)
+	}
+	if rd.Id() != "3" || rd.Get("token").(string) != "s3cr3t" {
+		t.Errorf(
+			"resourceForemanPersonalAccessTokenCreate stored the token [%s] with value [%s]",
+			rd.Id(),
+			rd.Get("token"),
+		)
+	}
+	if rd.Get("expires_at").(string) != "2099-01-01T00:00:00Z" {
+		t.Errorf("resourceForemanPersonalAccessTokenCreate changed the expiry to [%s]", rd.Get("expires_at"))
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanPersonalAccessTokenCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+	tokensURI := UsersURI + "/" + strconv.Itoa(obj.UserId) + "/personal_access_tokens"
+	tokensURIById := tokensURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenCreate",
+				crudFunc:     resourceForemanPersonalAccessTokenCreate,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenRead",
+				crudFunc:     resourceForemanPersonalAccessTokenRead,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenDelete",
+				crudFunc:     resourceForemanPersonalAccessTokenDelete,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanPersonalAccessTokenRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenDelete",
+			crudFunc:     resourceForemanPersonalAccessTokenDelete,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanPersonalAccessTokenStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenCreate",
+			crudFunc:     resourceForemanPersonalAccessTokenCreate,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenDelete",
+			crudFunc:     resourceForemanPersonalAccessTokenDelete,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanPersonalAccessTokenEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenCreate",
+			crudFunc:     resourceForemanPersonalAccessTokenCreate,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanPersonalAccessTokenMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenCreate",
+				crudFunc:     resourceForemanPersonalAccessTokenCreate,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			responseFile: PersonalAccessTokensTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanPersonalAccessTokenResourceDataFromFile(
+				t,
+				PersonalAccessTokensTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanPersonalAccessTokenResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenRead",
+				crudFunc:     resourceForemanPersonalAccessTokenRead,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			responseFile: PersonalAccessTokensTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanPersonalAccessTokenResourceDataFromFile(
+				t,
+				PersonalAccessTokensTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanPersonalAccessTokenResourceDataCompare,
+		},
+	}
+
+}
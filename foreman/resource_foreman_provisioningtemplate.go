@@ -123,6 +123,9 @@ func resourceForemanProvisioningTemplate() *schema.Resource {
 				Optional:    true,
 				Description: "A description of the provisioning template.",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("provisioning template"),
+			"organization_ids": foremanOrganizationIdsSchema("provisioning template"),
 		},
 	}
 }
@@ -203,6 +206,8 @@ func buildForemanProvisioningTemplate(d *schema.ResourceData) *api.ForemanProvis
 
 	template.TemplateCombinationsAttributes = buildForemanTemplateCombinationsAttributes(d)
 
+	template.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &template
 }
 
@@ -295,6 +300,7 @@ func setResourceDataFromForemanProvisioningTemplate(d *schema.ResourceData, ft *
 
 	setResourceDataFromForemanTemplateCombinationsAttributes(d, ft.TemplateCombinationsAttributes)
 
+	setResourceDataFromForemanTaxonomyIds(d, ft.ForemanTaxonomyIds)
 }
 
 // setResourceDataFromForemanTemplateCombinationsAttributes sets a
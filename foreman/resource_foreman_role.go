@@ -0,0 +1,187 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanRole() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanRoleCreate,
+		ReadContext:   resourceForemanRoleRead,
+		UpdateContext: resourceForemanRoleUpdate,
+		DeleteContext: resourceForemanRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Roles bundle permissions which can be assigned to users and "+
+						"usergroups. The permissions of a role are granted by its filters, "+
+						"see `foreman_filter`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the role. "+
+						"%s \"Host operators\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the role.",
+			},
+
+			"filter_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the filters of the role.",
+			},
+
+			"location_ids":     foremanLocationIdsSchema("role"),
+			"organization_ids": foremanOrganizationIdsSchema("role"),
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRole constructs a ForemanRole reference from a resource data
+// reference. The struct's members are populated from the data populated in
+// the resource data. Missing members will be left to the zero value for that
+// member's type.
+func buildForemanRole(d *schema.ResourceData) *api.ForemanRole {
+	log.Tracef("resource_foreman_role.go#buildForemanRole")
+
+	role := api.ForemanRole{}
+
+	obj := buildForemanObject(d)
+	role.ForemanObject = *obj
+
+	role.Description = d.Get("description").(string)
+	role.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
+	return &role
+}
+
+// setResourceDataFromForemanRole sets a ResourceData's attributes from the
+// attributes of the supplied ForemanRole reference
+func setResourceDataFromForemanRole(d *schema.ResourceData, fr *api.ForemanRole) {
+	log.Tracef("resource_foreman_role.go#setResourceDataFromForemanRole")
+
+	d.SetId(strconv.Itoa(fr.Id))
+	d.Set("name", fr.Name)
+	d.Set("description", fr.Description)
+
+	filterIds := make([]int, 0, len(fr.Filters))
+	for _, filter := range fr.Filters {
+		filterIds = append(filterIds, filter.Id)
+	}
+	d.Set("filter_ids", filterIds)
+
+	setResourceDataFromForemanTaxonomyIds(d, fr.ForemanTaxonomyIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Create")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	createdRole, createErr := client.CreateRole(ctx, r)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanRole: [%+v]", createdRole)
+
+	setResourceDataFromForemanRole(d, createdRole)
+
+	return nil
+}
+
+func resourceForemanRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	readRole, readErr := client.ReadRole(ctx, r.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanRole: [%+v]", readRole)
+
+	setResourceDataFromForemanRole(d, readRole)
+
+	return nil
+}
+
+func resourceForemanRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Update")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	updatedRole, updateErr := client.UpdateRole(ctx, r)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanRole: [%+v]", updatedRole)
+
+	setResourceDataFromForemanRole(d, updatedRole)
+
+	return nil
+}
+
+func resourceForemanRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Delete")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteRole(ctx, r.Id)))
+}
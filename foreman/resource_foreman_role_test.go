@@ -0,0 +1,428 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const RolesURI = api.FOREMAN_API_URL_PREFIX + "/roles"
+const RolesTestDataPath = "testdata/3.11/roles"
+
+// Given a ForemanRole, create a mock instance state reference
+func ForemanRoleToInstanceState(obj api.ForemanRole) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanRole
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["description"] = obj.Description
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanRole resource, create a
+// mock ResourceData reference.
+func MockForemanRoleResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanRole()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a role
+// ResourceData reference
+func MockForemanRoleResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanRole
+	ParseJSONFile(t, path, &obj)
+	s := ForemanRoleToInstanceState(obj)
+	return MockForemanRoleResourceData(s)
+}
+
+// Creates a random ForemanRole struct
+func RandForemanRole() api.ForemanRole {
+	obj := api.ForemanRole{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanRole resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanRoleResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanRole()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestRoleUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanRole
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the filters and taxonomies of a read response are decoded
+func TestRoleUnmarshalJSON_ReadResponse(t *testing.T) {
+
+	var obj api.ForemanRole
+	ParseJSONFile(t, RolesTestDataPath+"/read_response.json", &obj)
+
+	if len(obj.Filters) != 2 || obj.Filters[0].Id != 212 || obj.Filters[1].Id != 213 {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not decode the filters. Got [%+v]",
+			obj.Filters,
+		)
+	}
+
+	locationIds, ok := obj.ReadLocationIds()
+	if !ok || !reflect.DeepEqual(locationIds, []int{2}) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not decode the locations. "+
+				"Expected [[2]], got [%v]",
+			locationIds,
+		)
+	}
+
+	organizationIds, ok := obj.ReadOrganizationIds()
+	if !ok || !reflect.DeepEqual(organizationIds, []int{4}) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not decode the organizations. "+
+				"Expected [[4]], got [%v]",
+			organizationIds,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanRole
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanRole_Value(t *testing.T) {
+
+	expectedObj := RandForemanRole()
+	expectedState := ForemanRoleToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRoleResourceData(expectedState)
+
+	actualObj := api.ForemanRole{}
+	actualState := ForemanRoleToInstanceState(actualObj)
+	actualResourceData := MockForemanRoleResourceData(actualState)
+
+	setResourceDataFromForemanRole(actualResourceData, &expectedObj)
+
+	ForemanRoleResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanRoleCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+	rolesURIById := RolesURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    rolesURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleDelete",
+				crudFunc:     resourceForemanRoleDelete,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    rolesURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanRoleRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanRole{}
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanRoleStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanRoleEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanRoleMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// Role Assignment
+// -----------------------------------------------------------------------------
+
+// Ensures users and usergroups send an emptied role_ids set as an empty list,
+// which removes all roles, and leave the roles alone while they are unchanged
+func TestRoleAssignment_EmptyRoleIds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resource *schema.Resource
+		uri      string
+		object   string
+		config   map[string]interface{}
+		update   func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+		response string
+	}{
+		{
+			name:     "user",
+			resource: resourceForemanUser(),
+			uri:      api.FOREMAN_API_URL_PREFIX + "/users/5",
+			object:   "user",
+			config:   map[string]interface{}{"login": "jdoe"},
+			update:   resourceForemanUserUpdate,
+			response: `{"id":5,"login":"jdoe","auth_source_id":1,"roles":[{"id":1,"name":"Default role"}]}`,
+		},
+		{
+			name:     "usergroup",
+			resource: resourceForemanUsergroup(),
+			uri:      UsergroupsURI + "/5",
+			object:   "usergroup",
+			config:   map[string]interface{}{"name": "operators"},
+			update:   resourceForemanUsergroupUpdate,
+			response: `{"id":5,"name":"operators","roles":[]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+			defer server.Close()
+
+			var body map[string]map[string]interface{}
+			mux.HandleFunc(tc.uri, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					json.NewDecoder(r.Body).Decode(&body)
+				}
+				fmt.Fprint(w, tc.response)
+			})
+
+			current := tc.resource.TestResourceData()
+			current.SetId("5")
+			for key, value := range tc.config {
+				current.Set(key, value)
+			}
+			current.Set("role_ids", []interface{}{23})
+			state := current.State()
+
+			// Unchanged roles are sent as they are
+			d := MockResourceDataDiff(t, tc.resource, state, tc.config)
+			if diags := tc.update(context.TODO(), d, client); diags.HasError() {
+				t.Fatalf("Update returned [%+v]", diags)
+			}
+			if roleIds := body[tc.object]["role_ids"]; !reflect.DeepEqual(roleIds, []interface{}{23.0}) {
+				t.Errorf("Update sent role_ids [%v], expected [23]", roleIds)
+			}
+
+			// Emptied roles are sent as an empty list
+			config := map[string]interface{}{"role_ids": []interface{}{}}
+			for key, value := range tc.config {
+				config[key] = value
+			}
+			d = MockResourceDataDiff(t, tc.resource, state, config)
+			if diags := tc.update(context.TODO(), d, client); diags.HasError() {
+				t.Fatalf("Update returned [%+v]", diags)
+			}
+			if roleIds, ok := body[tc.object]["role_ids"]; !ok || !reflect.DeepEqual(roleIds, []interface{}{}) {
+				t.Errorf("Update sent role_ids [%v], expected []", roleIds)
+			}
+			if d.Get("role_ids").(*schema.Set).Len() != 0 {
+				t.Errorf("Update set role_ids [%v], expected none", d.Get("role_ids"))
+			}
+		})
+	}
+}
@@ -60,6 +60,9 @@ func resourceForemanSmartProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"location_ids":     foremanLocationIdsSchema("smart proxy"),
+			"organization_ids": foremanOrganizationIdsSchema("smart proxy"),
 		},
 	}
 }
@@ -82,6 +85,8 @@ func buildForemanSmartProxy(d *schema.ResourceData) *api.ForemanSmartProxy {
 
 	proxy.URL = d.Get("url").(string)
 
+	proxy.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &proxy
 }
 
@@ -93,6 +98,7 @@ func setResourceDataFromForemanSmartProxy(d *schema.ResourceData, fp *api.Forema
 	d.SetId(strconv.Itoa(fp.Id))
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+	setResourceDataFromForemanTaxonomyIds(d, fp.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -191,6 +191,9 @@ func resourceForemanSubnet() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the subnet",
 			},
+
+			"location_ids":     foremanLocationIdsSchema("subnet"),
+			"organization_ids": foremanOrganizationIdsSchema("subnet"),
 		},
 	}
 }
@@ -277,6 +280,9 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 	if attr, ok = d.GetOk("description"); ok {
 		s.Description = attr.(string)
 	}
+
+	s.ForemanTaxonomyIds = buildForemanTaxonomyIds(d)
+
 	return &s
 }
 
@@ -307,6 +313,7 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 	d.Set("domain_ids", fs.DomainIDs)
 	d.Set("network_type", fs.NetworkType)
 	d.Set("description", fs.Description)
+	setResourceDataFromForemanTaxonomyIds(d, fs.ForemanTaxonomyIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,206 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanTaskWait() *schema.Resource {
+	s := foremanTaskSchema()
+
+	s[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Waits for a Foreman task (e.g. a repository sync or content view publish started "+
+				"outside of Terraform) to finish. Creating this resource blocks until the task is no "+
+				"longer pending, so other resources can depend on it.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	s["task_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: fmt.Sprintf(
+			"UUID of the task to wait for. "+
+				"%s \"0b9c0a2e-5a6d-4d41-b0a5-0d2b3b9e3c17\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	s["fail_on_warning"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		ForceNew: true,
+		Description: "Whether a task finishing with the result `warning` is treated as a " +
+			"failure. Tasks finishing with `error` or `cancelled` always fail. Defaults to `false`.",
+	}
+
+	return &schema.Resource{
+
+		CreateContext: resourceForemanTaskWaitCreate,
+		ReadContext:   resourceForemanTaskWaitRead,
+		DeleteContext: resourceForemanTaskWaitDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: s,
+	}
+}
+
+// foremanTaskSchema returns the computed attributes of a Foreman task shared
+// by the task wait resource and the task data source.
+func foremanTaskSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"label": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Label of the task, e.g. `Actions::Katello::Repository::Sync`.",
+		},
+		"action": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Human readable action of the task.",
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "State of the task, e.g. `planned`, `running`, `paused` " +
+				"or `stopped`.",
+		},
+		"result": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Result of the task, e.g. `pending`, `success`, `warning`, " +
+				"`error` or `cancelled`.",
+		},
+		"pending": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the task is still pending.",
+		},
+		"progress": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Progress of the task between 0 and 1.",
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User who started the task.",
+		},
+		"parent_task_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UUID of the parent task, if any.",
+		},
+		"started_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp of when the task was started.",
+		},
+		"ended_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp of when the task ended.",
+		},
+		"humanized_errors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Human readable errors reported by the task.",
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// setResourceDataFromForemanTask sets a ResourceData's attributes from the
+// attributes of the supplied ForemanTask reference
+func setResourceDataFromForemanTask(d *schema.ResourceData, task *api.ForemanTask) {
+	d.SetId(task.Id)
+	d.Set("label", task.Label)
+	d.Set("action", task.Action)
+	d.Set("state", task.State)
+	d.Set("result", task.Result)
+	d.Set("pending", task.Pending)
+	d.Set("progress", task.Progress)
+	d.Set("username", task.Username)
+	d.Set("parent_task_id", task.ParentTaskId)
+	d.Set("started_at", task.StartedAt)
+	d.Set("ended_at", task.EndedAt)
+	d.Set("humanized_errors", task.Humanized.Errors)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanTaskWaitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_task_wait.go#Create")
+
+	client := meta.(*api.Client)
+	taskID := d.Get("task_id").(string)
+
+	task, waitErr := client.WaitForForemanTask(ctx, taskID)
+	if waitErr != nil {
+		return diag.FromErr(waitErr)
+	}
+
+	log.Debugf("ForemanTask: [%+v]", task)
+
+	if task.Result == "warning" && d.Get("fail_on_warning").(bool) {
+		return diag.Errorf(
+			"task %s (%s) finished with result [warning]: %v",
+			task.Id,
+			task.Label,
+			task.Humanized.Errors,
+		)
+	}
+
+	setResourceDataFromForemanTask(d, task)
+
+	return nil
+}
+
+func resourceForemanTaskWaitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_task_wait.go#Read")
+
+	client := meta.(*api.Client)
+
+	task, readErr := client.ReadForemanTask(ctx, d.Id())
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("ForemanTask: [%+v]", task)
+
+	setResourceDataFromForemanTask(d, task)
+
+	return nil
+}
+
+func resourceForemanTaskWaitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_task_wait.go#Delete")
+
+	// Tasks are part of Foreman's history and are not deleted. Removing the
+	// resource only removes it from the state.
+	d.SetId("")
+
+	return nil
+}
@@ -96,8 +96,9 @@ func resourceForemanUser() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      1,
-				ValidateFunc: validation.IntBetween(1, 2),
-				Description:  "Set the authentication source, i.e internal (1,default) or external (2)",
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Set the authentication source, i.e internal (1,default), external (2) or " +
+					"the ID of a `foreman_auth_source_ldap`",
 			},
 
 			"locale": {
@@ -111,8 +112,9 @@ func resourceForemanUser() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
-				Optional:    true,
-				Description: "List of all locations a user has access to",
+				Optional: true,
+				Description: "List of all locations a user has access to. Removing the list removes " +
+					"the user from all locations.",
 			},
 
 			"organization_ids": {
@@ -120,8 +122,20 @@ func resourceForemanUser() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
-				Optional:    true,
-				Description: "List of all organizations a user has access to",
+				Optional: true,
+				Description: "List of all organizations a user has access to. Removing the list removes " +
+					"the user from all organizations.",
+			},
+
+			"role_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional: true,
+				Computed: true,
+				Description: "List of all roles assigned to the user. Foreman assigns " +
+					"the builtin \"Default role\" to every user, it is not part of the list.",
 			},
 		},
 	}
@@ -179,14 +193,17 @@ func buildForemanUser(d *schema.ResourceData) *api.ForemanUser {
 	if attr, ok = d.GetOk("locale"); ok {
 		u.Locale = attr.(string)
 	}
-	if attr, ok = d.GetOk("location_ids"); ok {
+	// the taxonomies are not computed, removing them from the configuration
+	// removes the user from all of them
+	if attr, ok = d.GetOk("location_ids"); ok || d.HasChange("location_ids") {
 		attrSet := attr.(*schema.Set)
 		u.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
-	if attr, ok = d.GetOk("organization_ids"); ok {
+	if attr, ok = d.GetOk("organization_ids"); ok || d.HasChange("organization_ids") {
 		attrSet := attr.(*schema.Set)
 		u.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
+	u.RoleIds = buildForemanIds(d, "role_ids")
 	return &u
 }
 
@@ -207,8 +224,8 @@ func setResourceDataFromForemanUser(d *schema.ResourceData, fu *api.ForemanUser)
 	d.Set("default_organization_id", fu.DefaultOrganizationId)
 	d.Set("auth_source_id", fu.AuthSourceId)
 	d.Set("locale", fu.Locale)
-	d.Set("location_ids", fu.LocationIds)
-	d.Set("organization_ids", fu.OrganizationIds)
+	setResourceDataFromForemanTaxonomyIds(d, fu.ForemanTaxonomyIds)
+	d.Set("role_ids", fu.RoleIds)
 }
 
 // -----------------------------------------------------------------------------
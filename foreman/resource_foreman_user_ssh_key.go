@@ -0,0 +1,204 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanUserSSHKey() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanUserSSHKeyCreate,
+		ReadContext:   resourceForemanUserSSHKeyRead,
+		DeleteContext: resourceForemanUserSSHKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceForemanUserSSHKeyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Public SSH key of a user. The keys of a user are deployed to "+
+						"the hosts for remote execution. Import with `<user_id>/<id>`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"user_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the user the key belongs to.",
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the key. "+
+						"%s \"remote-execution\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+				Description: fmt.Sprintf(
+					"The public key in OpenSSH format. "+
+						"%s \"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA... rex@example.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of the key.",
+			},
+
+			"length": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Length of the key in bits.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanSSHKey constructs a ForemanSSHKey reference from a resource data
+// reference.  The struct's members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanSSHKey(d *schema.ResourceData) *api.ForemanSSHKey {
+	log.Tracef("resource_foreman_user_ssh_key.go#buildForemanSSHKey")
+
+	key := api.ForemanSSHKey{}
+
+	obj := buildForemanObject(d)
+	key.ForemanObject = *obj
+
+	key.UserId = d.Get("user_id").(int)
+	key.Key = d.Get("key").(string)
+
+	return &key
+}
+
+// setResourceDataFromForemanSSHKey sets a ResourceData's attributes from the
+// attributes of the supplied ForemanSSHKey reference
+func setResourceDataFromForemanSSHKey(d *schema.ResourceData, fk *api.ForemanSSHKey) {
+	log.Tracef("resource_foreman_user_ssh_key.go#setResourceDataFromForemanSSHKey")
+
+	d.SetId(strconv.Itoa(fk.Id))
+	d.Set("name", fk.Name)
+	d.Set("key", fk.Key)
+	d.Set("fingerprint", fk.Fingerprint)
+	d.Set("length", fk.Length)
+
+	if fk.UserId != 0 {
+		d.Set("user_id", fk.UserId)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_user_ssh_key.go#Create")
+
+	client := meta.(*api.Client)
+	k := buildForemanSSHKey(d)
+
+	log.Debugf("ForemanSSHKey: [%+v]", k)
+
+	createdSSHKey, createErr := client.CreateSSHKey(ctx, k.UserId, k)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanSSHKey: [%+v]", createdSSHKey)
+
+	setResourceDataFromForemanSSHKey(d, createdSSHKey)
+
+	return nil
+}
+
+func resourceForemanUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_user_ssh_key.go#Read")
+
+	client := meta.(*api.Client)
+	k := buildForemanSSHKey(d)
+
+	log.Debugf("ForemanSSHKey: [%+v]", k)
+
+	readSSHKey, readErr := client.ReadSSHKey(ctx, k.UserId, k.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanSSHKey: [%+v]", readSSHKey)
+
+	setResourceDataFromForemanSSHKey(d, readSSHKey)
+
+	return nil
+}
+
+func resourceForemanUserSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_user_ssh_key.go#Delete")
+
+	client := meta.(*api.Client)
+	k := buildForemanSSHKey(d)
+
+	log.Debugf("ForemanSSHKey: [%+v]", k)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteSSHKey(ctx, k.UserId, k.Id)))
+}
+
+// resourceForemanUserSSHKeyImport splits the import ID "<user_id>/<id>" into
+// the user and the key since keys are only addressable through their user
+func resourceForemanUserSSHKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_user_ssh_key.go#Import")
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Unexpected import ID [%s], expected <user_id>/<id>", d.Id())
+	}
+
+	userId, userErr := strconv.Atoi(parts[0])
+	if userErr != nil {
+		return nil, fmt.Errorf("Unexpected user ID [%s] in import ID: %s", parts[0], userErr)
+	}
+	if _, idErr := strconv.Atoi(parts[1]); idErr != nil {
+		return nil, fmt.Errorf("Unexpected key ID [%s] in import ID: %s", parts[1], idErr)
+	}
+
+	d.SetId(parts[1])
+	d.Set("user_id", userId)
+
+	return []*schema.ResourceData{d}, nil
+}
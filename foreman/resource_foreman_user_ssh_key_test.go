@@ -0,0 +1,323 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const UsersURI = api.FOREMAN_API_URL_PREFIX + "/users"
+const SSHKeysTestDataPath = "testdata/3.11/ssh_keys"
+
+// Given a ForemanSSHKey, create a mock instance state reference
+func ForemanSSHKeyToInstanceState(obj api.ForemanSSHKey) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanSSHKey
+	attr := map[string]string{}
+	attr["user_id"] = strconv.Itoa(obj.UserId)
+	attr["name"] = obj.Name
+	attr["key"] = obj.Key
+	attr["fingerprint"] = obj.Fingerprint
+	attr["length"] = strconv.Itoa(obj.Length)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanSSHKey resource, create a
+// mock ResourceData reference.
+func MockForemanSSHKeyResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanUserSSHKey()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a SSH key
+// ResourceData reference
+func MockForemanSSHKeyResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanSSHKey
+	ParseJSONFile(t, path, &obj)
+	s := ForemanSSHKeyToInstanceState(obj)
+	return MockForemanSSHKeyResourceData(s)
+}
+
+// Creates a random ForemanSSHKey struct
+func RandForemanSSHKey() api.ForemanSSHKey {
+	obj := api.ForemanSSHKey{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.UserId = rand.Intn(100) + 1
+	obj.Key = "ssh-ed25519 " + tfrand.String(68, tfrand.Lower+tfrand.Upper)
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanSSHKey resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanSSHKeyResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanUserSSHKey()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanUserSSHKeyImport
+// -----------------------------------------------------------------------------
+
+// Ensures the import ID is split into the user and the key
+func TestResourceForemanUserSSHKeyImport(t *testing.T) {
+
+	rd := MockForemanSSHKeyResourceData(&terraform.InstanceState{ID: "5/12"})
+
+	results, err := resourceForemanUserSSHKeyImport(context.Background(), rd, nil)
+	if err != nil {
+		t.Fatalf("resourceForemanUserSSHKeyImport returned an error: [%s]", err)
+	}
+	if results[0].Id() != "12" || results[0].Get("user_id").(int) != 5 {
+		t.Errorf(
+			"resourceForemanUserSSHKeyImport set ID [%s] and user [%d], expected [12] and [5]",
+			results[0].Id(),
+			results[0].Get("user_id").(int),
+		)
+	}
+
+	for _, id := range []string{"12", "5/12/1", "user/12", "5/key"} {
+		rd := MockForemanSSHKeyResourceData(&terraform.InstanceState{ID: id})
+		if _, err := resourceForemanUserSSHKeyImport(context.Background(), rd, nil); err == nil {
+			t.Errorf("resourceForemanUserSSHKeyImport accepted the import ID [%s]", id)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanUserSSHKeyCreate
+// -----------------------------------------------------------------------------
+
+// Ensures the trailing newline of a public key file is not sent, Foreman only
+// accepts a single line
+func TestResourceForemanUserSSHKeyCreate_TrailingNewline(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var body map[string]map[string]interface{}
+	mux.HandleFunc(UsersURI+"/5/ssh_keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{"id":12,"name":"laptop","user_id":5,"key":"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA rex@example.com"}`)
+	})
+
+	rd := resourceForemanUserSSHKey().TestResourceData()
+	rd.Set("user_id", 5)
+	rd.Set("name", "laptop")
+	rd.Set("key", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA rex@example.com\n")
+
+	if diags := resourceForemanUserSSHKeyCreate(context.Background(), rd, client); diags.HasError() {
+		t.Fatalf("resourceForemanUserSSHKeyCreate returned an error: [%+v]", diags)
+	}
+	if key := body["ssh_key"]["key"]; key != "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA rex@example.com" {
+		t.Errorf("resourceForemanUserSSHKeyCreate sent the key [%q]", key)
+	}
+	if rd.Id() != "12" {
+		t.Errorf("resourceForemanUserSSHKeyCreate set the ID [%s], expected [12]", rd.Id())
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanUserSSHKeyCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanSSHKey()
+	s := ForemanSSHKeyToInstanceState(obj)
+	sshKeysURI := UsersURI + "/" + strconv.Itoa(obj.UserId) + "/ssh_keys"
+	sshKeysURIById := sshKeysURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserSSHKeyCreate",
+				crudFunc:     resourceForemanUserSSHKeyCreate,
+				resourceData: MockForemanSSHKeyResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    sshKeysURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserSSHKeyRead",
+				crudFunc:     resourceForemanUserSSHKeyRead,
+				resourceData: MockForemanSSHKeyResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    sshKeysURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserSSHKeyDelete",
+				crudFunc:     resourceForemanUserSSHKeyDelete,
+				resourceData: MockForemanSSHKeyResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    sshKeysURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanUserSSHKeyRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSSHKey()
+	s := ForemanSSHKeyToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanUserSSHKeyRead",
+			crudFunc:     resourceForemanUserSSHKeyRead,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanUserSSHKeyDelete",
+			crudFunc:     resourceForemanUserSSHKeyDelete,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanUserSSHKeyStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSSHKey()
+	s := ForemanSSHKeyToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanUserSSHKeyCreate",
+			crudFunc:     resourceForemanUserSSHKeyCreate,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanUserSSHKeyRead",
+			crudFunc:     resourceForemanUserSSHKeyRead,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanUserSSHKeyDelete",
+			crudFunc:     resourceForemanUserSSHKeyDelete,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanUserSSHKeyEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSSHKey()
+	s := ForemanSSHKeyToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanUserSSHKeyCreate",
+			crudFunc:     resourceForemanUserSSHKeyCreate,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanUserSSHKeyRead",
+			crudFunc:     resourceForemanUserSSHKeyRead,
+			resourceData: MockForemanSSHKeyResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanUserSSHKeyMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanSSHKey()
+	s := ForemanSSHKeyToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserSSHKeyCreate",
+				crudFunc:     resourceForemanUserSSHKeyCreate,
+				resourceData: MockForemanSSHKeyResourceData(s),
+			},
+			responseFile: SSHKeysTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanSSHKeyResourceDataFromFile(
+				t,
+				SSHKeysTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanSSHKeyResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanUserSSHKeyRead",
+				crudFunc:     resourceForemanUserSSHKeyRead,
+				resourceData: MockForemanSSHKeyResourceData(s),
+			},
+			responseFile: SSHKeysTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanSSHKeyResourceDataFromFile(
+				t,
+				SSHKeysTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanSSHKeyResourceDataCompare,
+		},
+	}
+
+}
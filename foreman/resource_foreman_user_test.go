@@ -0,0 +1,95 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// -----------------------------------------------------------------------------
+// Taxonomies
+// -----------------------------------------------------------------------------
+
+// Ensures the locations and organizations of a user are only kept as long as
+// they are configured, removing or emptying them removes the user from all of
+// them
+func TestResourceForemanUserUpdate_Taxonomies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "unchanged",
+			config: map[string]interface{}{
+				"login":            "jdoe",
+				"location_ids":     []interface{}{3},
+				"organization_ids": []interface{}{4},
+			},
+			expected: map[string]interface{}{
+				"location_ids":     []interface{}{3.0},
+				"organization_ids": []interface{}{4.0},
+			},
+		},
+		{
+			name: "emptied",
+			config: map[string]interface{}{
+				"login":            "jdoe",
+				"location_ids":     []interface{}{},
+				"organization_ids": []interface{}{4},
+			},
+			expected: map[string]interface{}{
+				"location_ids":     []interface{}{},
+				"organization_ids": []interface{}{4.0},
+			},
+		},
+		{
+			name: "removed",
+			config: map[string]interface{}{
+				"login": "jdoe",
+			},
+			expected: map[string]interface{}{
+				"location_ids":     []interface{}{},
+				"organization_ids": []interface{}{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+			defer server.Close()
+
+			var body map[string]map[string]interface{}
+			mux.HandleFunc(api.FOREMAN_API_URL_PREFIX+"/users/5", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					json.NewDecoder(r.Body).Decode(&body)
+				}
+				fmt.Fprint(w, `{"id":5,"login":"jdoe","auth_source_id":1}`)
+			})
+
+			current := resourceForemanUser().TestResourceData()
+			current.SetId("5")
+			current.Set("login", "jdoe")
+			current.Set("location_ids", []interface{}{3})
+			current.Set("organization_ids", []interface{}{4})
+
+			d := MockResourceDataDiff(t, resourceForemanUser(), current.State(), tc.config)
+			if diags := resourceForemanUserUpdate(context.TODO(), d, client); diags.HasError() {
+				t.Fatalf("Update of the user returned [%+v]", diags)
+			}
+
+			for key, expected := range tc.expected {
+				if ids, ok := body["user"][key]; !ok || !reflect.DeepEqual(ids, expected) {
+					t.Errorf("Update of the user sent %s [%v], expected [%v]", key, ids, expected)
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanUsergroup() *schema.Resource {
@@ -55,6 +56,63 @@ func resourceForemanUsergroup() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"role_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional:    true,
+				Computed:    true,
+				Description: "List of all roles assigned to the usergroup and thereby to its members.",
+			},
+
+			"user_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the users that are members of the usergroup. Members of the " +
+					"`external_group`s are added by Foreman and should be listed here as well.",
+			},
+
+			"usergroup_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the usergroups nested in the usergroup. Their members inherit the roles of the usergroup.",
+			},
+
+			"external_group": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"Name of the group in the authentication source. "+
+									"%s \"cn=admins,ou=groups,dc=example,dc=com\"",
+								autodoc.MetaExample,
+							),
+						},
+						"auth_source_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "ID of the authentication source the group is looked up in.",
+						},
+					},
+				},
+				Description: "External groups (eg. LDAP groups) mapped to the usergroup. Foreman " +
+					"synchronizes the members of the external groups into the usergroup.",
+			},
 		},
 	}
 }
@@ -84,6 +142,12 @@ func buildForemanUsergroup(d *schema.ResourceData) *api.ForemanUsergroup {
 
 	usergroup.Admin = d.Get("admin").(bool)
 
+	usergroup.RoleIds = buildForemanIds(d, "role_ids")
+	usergroup.UserIds = buildForemanIds(d, "user_ids")
+	usergroup.UsergroupIds = buildForemanIds(d, "usergroup_ids")
+
+	usergroup.ExternalUsergroups = buildForemanExternalUsergroups(d)
+
 	return &usergroup
 }
 
@@ -95,6 +159,95 @@ func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fh *api.Foreman
 	d.SetId(strconv.Itoa(fh.Id))
 	d.Set("name", fh.Name)
 	d.Set("admin", fh.Admin)
+	d.Set("role_ids", fh.RoleIds)
+	d.Set("user_ids", fh.UserIds)
+	d.Set("usergroup_ids", fh.UsergroupIds)
+	setResourceDataFromForemanExternalUsergroups(d, fh.ExternalUsergroups)
+}
+
+// buildForemanExternalUsergroups constructs the external usergroups of the
+// usergroup from the "external_group" blocks of the resource data
+func buildForemanExternalUsergroups(d *schema.ResourceData) []api.ForemanExternalUsergroup {
+	log.Tracef("resource_foreman_usergroup.go#buildForemanExternalUsergroups")
+
+	var externalUsergroups []api.ForemanExternalUsergroup
+
+	for _, item := range d.Get("external_group").(*schema.Set).List() {
+		m := item.(map[string]interface{})
+		eu := api.ForemanExternalUsergroup{
+			AuthSourceId: m["auth_source_id"].(int),
+		}
+		eu.Name = m["name"].(string)
+		externalUsergroups = append(externalUsergroups, eu)
+	}
+
+	return externalUsergroups
+}
+
+// setResourceDataFromForemanExternalUsergroups sets the "external_group"
+// blocks of the resource data from the supplied external usergroups
+func setResourceDataFromForemanExternalUsergroups(d *schema.ResourceData, externalUsergroups []api.ForemanExternalUsergroup) {
+	log.Tracef("resource_foreman_usergroup.go#setResourceDataFromForemanExternalUsergroups")
+
+	groups := make([]map[string]interface{}, 0, len(externalUsergroups))
+	for _, eu := range externalUsergroups {
+		groups = append(groups, map[string]interface{}{
+			"name":           eu.Name,
+			"auth_source_id": eu.AuthSourceId,
+		})
+	}
+	d.Set("external_group", groups)
+}
+
+// syncForemanExternalUsergroups maps the desired external groups to the
+// usergroup and removes the ones which are no longer desired. External groups
+// are identified by their name and authentication source. If the mapping
+// changed, the usergroup is read again to pick up the synchronized members.
+func syncForemanExternalUsergroups(ctx context.Context, client *api.Client, usergroup *api.ForemanUsergroup, desired []api.ForemanExternalUsergroup) (*api.ForemanUsergroup, error) {
+	log.Tracef("resource_foreman_usergroup.go#syncForemanExternalUsergroups")
+
+	key := func(eu api.ForemanExternalUsergroup) string {
+		return fmt.Sprintf("%d/%s", eu.AuthSourceId, eu.Name)
+	}
+
+	current := map[string]api.ForemanExternalUsergroup{}
+	for _, eu := range usergroup.ExternalUsergroups {
+		current[key(eu)] = eu
+	}
+	wanted := map[string]bool{}
+	for _, eu := range desired {
+		wanted[key(eu)] = true
+	}
+
+	changed := false
+
+	for k, eu := range current {
+		if wanted[k] {
+			continue
+		}
+		log.Debugf("Removing external usergroup [%s] from usergroup [%d]", k, usergroup.Id)
+		if deleteErr := client.DeleteExternalUsergroup(ctx, usergroup.Id, eu.Id); deleteErr != nil {
+			return nil, deleteErr
+		}
+		changed = true
+	}
+
+	for _, eu := range desired {
+		if _, ok := current[key(eu)]; ok {
+			continue
+		}
+		log.Debugf("Adding external usergroup [%s] to usergroup [%d]", key(eu), usergroup.Id)
+		if _, createErr := client.CreateExternalUsergroup(ctx, usergroup.Id, &eu); createErr != nil {
+			return nil, createErr
+		}
+		changed = true
+	}
+
+	if !changed {
+		return usergroup, nil
+	}
+
+	return client.ReadUsergroup(ctx, usergroup.Id)
 }
 
 // -----------------------------------------------------------------------------
@@ -114,6 +267,15 @@ func resourceForemanUsergroupCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(createErr)
 	}
 
+	// the usergroup exists from here on, even if mapping the external groups
+	// fails
+	d.SetId(strconv.Itoa(createdUsergroup.Id))
+
+	createdUsergroup, syncErr := syncForemanExternalUsergroups(ctx, client, createdUsergroup, h.ExternalUsergroups)
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
 	log.Debugf("Created ForemanUsergroup: [%+v]", createdUsergroup)
 
 	setResourceDataFromForemanUsergroup(d, createdUsergroup)
@@ -159,6 +321,11 @@ func resourceForemanUsergroupUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(updateErr)
 	}
 
+	updatedUsergroup, syncErr := syncForemanExternalUsergroups(ctx, client, updatedUsergroup, h.ExternalUsergroups)
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
 	log.Debugf("Updated ForemanUsergroup: [%+v]", updatedUsergroup)
 
 	setResourceDataFromForemanUsergroup(d, updatedUsergroup)
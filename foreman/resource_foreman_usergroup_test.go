@@ -1,7 +1,9 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -400,3 +402,135 @@ func ResourceForemanUsergroupMockResponseTestCases(t *testing.T) []TestCaseMockR
 	}
 
 }
+
+// -----------------------------------------------------------------------------
+// Membership
+// -----------------------------------------------------------------------------
+
+// Ensures emptied member sets are sent as empty lists to remove all members
+func TestResourceForemanUsergroupUpdate_EmptySets(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var body map[string]map[string]interface{}
+	mux.HandleFunc(UsergroupsURI+"/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		fmt.Fprint(w, `{"id":3,"name":"operators","usergroups":[{"id":9}],"roles":[{"id":2}]}`)
+	})
+
+	current := resourceForemanUsergroup().TestResourceData()
+	current.SetId("3")
+	current.Set("name", "operators")
+	current.Set("user_ids", []interface{}{5, 6})
+	current.Set("usergroup_ids", []interface{}{9})
+	current.Set("role_ids", []interface{}{2})
+	state := current.State()
+
+	config := map[string]interface{}{
+		"name":          "operators",
+		"user_ids":      []interface{}{},
+		"usergroup_ids": []interface{}{9},
+	}
+	d := MockResourceDataDiff(t, resourceForemanUsergroup(), state, config)
+	if diags := resourceForemanUsergroupUpdate(context.TODO(), d, client); diags.HasError() {
+		t.Fatalf("Update of the usergroup returned [%+v]", diags)
+	}
+
+	usergroup := body["usergroup"]
+	if userIds, ok := usergroup["user_ids"]; !ok || !reflect.DeepEqual(userIds, []interface{}{}) {
+		t.Errorf("Update of the usergroup sent user_ids [%v], expected []", userIds)
+	}
+	if usergroupIds := usergroup["usergroup_ids"]; !reflect.DeepEqual(usergroupIds, []interface{}{9.0}) {
+		t.Errorf("Update of the usergroup sent usergroup_ids [%v], expected [9]", usergroupIds)
+	}
+	if d.Get("user_ids").(*schema.Set).Len() != 0 {
+		t.Errorf("Update of the usergroup set user_ids [%v], expected none", d.Get("user_ids"))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// syncForemanExternalUsergroups
+// -----------------------------------------------------------------------------
+
+// Ensures external groups are diffed by name and authentication source: groups
+// which are no longer desired are removed, new groups are added, unchanged
+// groups are kept and the usergroup is read again afterwards.
+func TestSyncForemanExternalUsergroups(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	deleted := []string{}
+	created := []string{}
+
+	mux.HandleFunc(UsergroupsURI+"/3/external_usergroups/9", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.Method+" ops")
+		fmt.Fprint(w, `{"id":9,"name":"ops"}`)
+	})
+	mux.HandleFunc(UsergroupsURI+"/3/external_usergroups", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]api.ForemanExternalUsergroup
+		json.NewDecoder(r.Body).Decode(&body)
+		created = append(created, r.Method+" "+body["external_usergroup"].Name)
+		fmt.Fprint(w, `{"id":11,"name":"dba","auth_source":{"id":7}}`)
+	})
+	mux.HandleFunc(UsergroupsURI+"/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"name":"operators","users":[{"id":5}],"external_usergroups":[`+
+			`{"id":10,"name":"admins","auth_source":{"id":7}},`+
+			`{"id":11,"name":"dba","auth_source":{"id":7}}]}`)
+	})
+
+	current := api.ForemanUsergroup{
+		ExternalUsergroups: []api.ForemanExternalUsergroup{
+			{ForemanObject: api.ForemanObject{Id: 9, Name: "ops"}, AuthSourceId: 7},
+			{ForemanObject: api.ForemanObject{Id: 10, Name: "admins"}, AuthSourceId: 7},
+		},
+	}
+	current.Id = 3
+	desired := []api.ForemanExternalUsergroup{
+		{ForemanObject: api.ForemanObject{Name: "admins"}, AuthSourceId: 7},
+		{ForemanObject: api.ForemanObject{Name: "dba"}, AuthSourceId: 7},
+	}
+
+	synced, err := syncForemanExternalUsergroups(context.Background(), client, &current, desired)
+	if err != nil {
+		t.Fatalf("syncForemanExternalUsergroups returned an error: [%s]", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"DELETE ops"}) {
+		t.Errorf("syncForemanExternalUsergroups removed [%v], expected [[DELETE ops]]", deleted)
+	}
+	if !reflect.DeepEqual(created, []string{"POST dba"}) {
+		t.Errorf("syncForemanExternalUsergroups added [%v], expected [[POST dba]]", created)
+	}
+	if len(synced.ExternalUsergroups) != 2 || !reflect.DeepEqual(synced.UserIds, []int{5}) {
+		t.Errorf("syncForemanExternalUsergroups did not read the usergroup again: [%+v]", synced)
+	}
+}
+
+// Ensures no requests are sent if the external groups did not change
+func TestSyncForemanExternalUsergroups_Unchanged(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("syncForemanExternalUsergroups sent an unexpected request [%s %s]", r.Method, r.URL.Path)
+	})
+
+	current := api.ForemanUsergroup{
+		ExternalUsergroups: []api.ForemanExternalUsergroup{
+			{ForemanObject: api.ForemanObject{Id: 10, Name: "admins"}, AuthSourceId: 7},
+		},
+	}
+	current.Id = 3
+	desired := []api.ForemanExternalUsergroup{
+		{ForemanObject: api.ForemanObject{Name: "admins"}, AuthSourceId: 7},
+	}
+
+	synced, err := syncForemanExternalUsergroups(context.Background(), client, &current, desired)
+	if err != nil {
+		t.Fatalf("syncForemanExternalUsergroups returned an error: [%s]", err)
+	}
+	if synced != &current {
+		t.Errorf("syncForemanExternalUsergroups did not return the unchanged usergroup")
+	}
+}
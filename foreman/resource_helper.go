@@ -1,8 +1,10 @@
 package foreman
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -41,3 +43,97 @@ func buildForemanObject(d *schema.ResourceData) *api.ForemanObject {
 
 	return &obj
 }
+
+// foremanLocationIdsSchema returns the schema of the location_ids attribute
+// of a taxonomy-aware resource. The object is the name used in the
+// description, e.g. "domain".
+func foremanLocationIdsSchema(object string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf(
+			"IDs of the locations the %s is assigned to. If set, the provider's "+
+				"`location_id` is not used for the %s. An empty list removes the %s "+
+				"from all locations.",
+			object,
+			object,
+			object,
+		),
+	}
+}
+
+// foremanOrganizationIdsSchema returns the schema of the organization_ids
+// attribute of a taxonomy-aware resource. The object is the name used in the
+// description, e.g. "domain".
+func foremanOrganizationIdsSchema(object string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf(
+			"IDs of the organizations the %s is assigned to. If set, the provider's "+
+				"`organization_id` is not used for the %s. An empty list removes the %s "+
+				"from all organizations.",
+			object,
+			object,
+			object,
+		),
+	}
+}
+
+// buildForemanIds returns the IDs in the set attribute with the supplied key.
+// A set which was emptied in the configuration returns an empty list, which
+// removes all of the IDs in Foreman, while a set which is not configured
+// returns nil, which leaves the IDs in Foreman unchanged.
+func buildForemanIds(d *schema.ResourceData, key string) []int {
+	if attr, ok := d.GetOk(key); ok {
+		return conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	if d.HasChange(key) && attributeConfigured(d, key) {
+		return []int{}
+	}
+	return nil
+}
+
+// attributeConfigured reports whether the attribute with the supplied key is
+// set in the configuration.  Optional computed attributes which are not set
+// keep their value.  If the configuration is not available, the attribute
+// is considered to be set.
+func attributeConfigured(d *schema.ResourceData, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(key) {
+		return true
+	}
+	return !raw.GetAttr(key).IsNull()
+}
+
+// buildForemanTaxonomyIds constructs the organizations and locations of a
+// taxonomy-aware object from the location_ids and organization_ids of a
+// resource data reference. Emptied sets remove all organizations or
+// locations of the object, see buildForemanIds.
+func buildForemanTaxonomyIds(d *schema.ResourceData) api.ForemanTaxonomyIds {
+	return api.ForemanTaxonomyIds{
+		LocationIds:     buildForemanIds(d, "location_ids"),
+		OrganizationIds: buildForemanIds(d, "organization_ids"),
+	}
+}
+
+// setResourceDataFromForemanTaxonomyIds sets the location_ids and
+// organization_ids of a ResourceData from the organizations and locations
+// the object was read with. Responses without them, e.g. search results,
+// leave the attributes untouched.
+func setResourceDataFromForemanTaxonomyIds(d *schema.ResourceData, t api.ForemanTaxonomyIds) {
+	if ids, ok := t.ReadLocationIds(); ok {
+		d.Set("location_ids", ids)
+	}
+	if ids, ok := t.ReadOrganizationIds(); ok {
+		d.Set("organization_ids", ids)
+	}
+}